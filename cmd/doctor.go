@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/doctor"
+	"github.com/spf13/cobra"
+)
+
+var doctorHyprlandConfig string
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the environment the daemon runs in",
+	Long: `Run a set of checks for the most common reasons profiles are not applied and print
+pass/fail for each of them together with a hint on how to fix it:
+- the configuration and its templates are valid
+- the destination directory is writable and the destination is not a stale symlink
+- the destination is sourced from hyprland.conf (source= lines are followed recursively)
+- the system and session D-Bus are reachable
+- UPower reports a power line and the power and lid objects can be queried
+- HYPRLAND_INSTANCE_SIGNATURE is set and the Hyprland sockets accept connections
+- the systemd user environment has the current HYPRLAND_INSTANCE_SIGNATURE
+
+Run it from within the Hyprland session with the same flags the daemon uses.
+The command exits with a non-zero code when any check fails.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		d := doctor.NewDoctor(doctor.Options{
+			ConfigPath:          configPath,
+			HyprlandConfigPath:  doctorHyprlandConfig,
+			ConnectToSessionBus: connectToSessionBus,
+		})
+		if err := d.Run(cmd.Context(), os.Stdout); err != nil {
+			return fmt.Errorf("environment is not healthy: %w", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().StringVar(
+		&doctorHyprlandConfig,
+		"hyprland-config",
+		"",
+		"Path to the Hyprland config that should source the destination (defaults to $XDG_CONFIG_HOME/hypr/hyprland.conf)",
+	)
+	doctorCmd.Flags().BoolVar(
+		&connectToSessionBus,
+		"connect-to-session-bus",
+		false,
+		"Check the session bus instead of the system bus for power and lid events, as the daemon flag",
+	)
+}
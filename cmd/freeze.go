@@ -59,7 +59,7 @@ This is useful for quickly creating new profiles based on your current working s
 			return fmt.Errorf("the current config is not valid: %w", err)
 		}
 
-		hyprIPC, err := hypr.NewIPC(ctx)
+		hyprIPC, err := hypr.NewIPC(ctx, cfg)
 		if err != nil {
 			return fmt.Errorf("failed to initialize Hyprland IPC: %w", err)
 		}
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/profilemaker"
+	"github.com/spf13/cobra"
+)
+
+var (
+	profileMoveConfigFile   bool
+	profileCopyConfigFile   bool
+	profileDeleteConfigFile bool
+	profileUnsetCondition   bool
+	profileConfigFileTarget string
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "List, inspect and edit profiles in the configuration file",
+	Long: `List, inspect and edit the profiles defined in the configuration file.
+
+The configuration file is edited in place: only the lines of the affected profile change, so
+comments, formatting and the order of the profiles (which decides ties) are preserved. The
+edited configuration is validated before it is written, nothing changes when it is not valid.
+
+Profiles have to be written as [profiles.<name>] tables (the way freeze writes them) to be
+edited, profiles defined with dotted keys or inline tables are reported and left untouched.`,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles in the order they are defined",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.NewConfig(configPath)
+		if err != nil {
+			return fmt.Errorf("the current config is not valid: %w", err)
+		}
+		raw := cfg.Get()
+
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "NAME\tTYPE\tCONFIG FILE\tCONDITIONS")
+		for _, name := range raw.OrderedProfileKeys() {
+			profile := raw.Profiles[name]
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", name, profile.ConfigType.Value(),
+				displayPath(raw.ConfigDirPath, profile.ConfigFile), describeConditions(profile.Conditions))
+		}
+		if raw.FallbackProfile != nil {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "(fallback)", raw.FallbackProfile.ConfigType.Value(),
+				displayPath(raw.ConfigDirPath, raw.FallbackProfile.ConfigFile), "-")
+		}
+		if err := w.Flush(); err != nil {
+			return fmt.Errorf("cant print profiles: %w", err)
+		}
+		return nil
+	},
+}
+
+var profileShowCmd = &cobra.Command{
+	Use:   "show <profile>",
+	Short: "Print the profile as it is written in the configuration file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		service, err := profileService()
+		if err != nil {
+			return err
+		}
+		profile, err := service.Show(args[0])
+		if err != nil {
+			return fmt.Errorf("cant show the profile: %w", err)
+		}
+		_, _ = fmt.Fprint(cmd.OutOrStdout(), profile)
+		return nil
+	},
+}
+
+var profileRenameCmd = &cobra.Command{
+	Use:   "rename <profile> <new-name>",
+	Short: "Rename a profile keeping its position in the file",
+	Long: `Rename a profile keeping its position in the configuration file.
+
+With --move-config-file the profile config file is moved as well, the profile name in the file
+name is replaced with the new one (or the name gets a -<new-name> suffix), use
+--config-file-location to pick the destination. Files used by other profiles are not moved.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		service, err := profileService()
+		if err != nil {
+			return err
+		}
+		move := profilemaker.ConfigFileAction{
+			Enabled:  profileMoveConfigFile || profileConfigFileTarget != "",
+			Location: profileConfigFileTarget,
+		}
+		if err := service.Rename(args[0], args[1], move); err != nil {
+			return fmt.Errorf("cant rename %s: %w", args[0], err)
+		}
+		return nil
+	},
+}
+
+var profileDuplicateCmd = &cobra.Command{
+	Use:   "duplicate <profile> <new-name>",
+	Short: "Copy a profile under a new name right after the original",
+	Long: `Copy a profile under a new name, the copy is placed right after the original profile so
+it wins ties against it.
+
+Both profiles use the same config file unless --copy-config-file is passed, the copy's file name
+is derived the same way as for rename, use --config-file-location to pick the destination.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		service, err := profileService()
+		if err != nil {
+			return err
+		}
+		copyFile := profilemaker.ConfigFileAction{
+			Enabled:  profileCopyConfigFile || profileConfigFileTarget != "",
+			Location: profileConfigFileTarget,
+		}
+		if err := service.Duplicate(args[0], args[1], copyFile); err != nil {
+			return fmt.Errorf("cant duplicate %s: %w", args[0], err)
+		}
+		return nil
+	},
+}
+
+var profileDeleteCmd = &cobra.Command{
+	Use:   "delete <profile>",
+	Short: "Remove a profile and the comments directly above it",
+	Long: `Remove a profile together with the comments directly above its tables.
+
+With --delete-config-file the profile config file is removed too, unless another profile uses it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		service, err := profileService()
+		if err != nil {
+			return err
+		}
+		if err := service.Delete(args[0], profileDeleteConfigFile); err != nil {
+			return fmt.Errorf("cant delete %s: %w", args[0], err)
+		}
+		return nil
+	},
+}
+
+var profileSetConditionCmd = &cobra.Command{
+	Use:   "set-condition <profile> <condition> [value]",
+	Short: "Set or remove a single profile condition",
+	Long: fmt.Sprintf(`Set or remove a single profile condition, available conditions: %s.
+
+The [profiles.<name>.conditions] table is created when missing, an existing value is replaced in
+place keeping its comment. Pass --unset instead of a value to remove the condition.`,
+		strings.Join(profilemaker.ConditionKeys, ", ")),
+	Args: cobra.RangeArgs(2, 3),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 1 {
+			return profilemaker.ConditionKeys, cobra.ShellCompDirectiveNoFileComp
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		value := ""
+		switch {
+		case profileUnsetCondition && len(args) == 3:
+			return fmt.Errorf("--unset does not take a value")
+		case !profileUnsetCondition && len(args) == 2:
+			return fmt.Errorf("a value is required, pass --unset to remove %s", args[1])
+		case len(args) == 3:
+			value = args[2]
+		}
+
+		service, err := profileService()
+		if err != nil {
+			return err
+		}
+		if err := service.SetCondition(args[0], args[1], value); err != nil {
+			return fmt.Errorf("cant update %s: %w", args[0], err)
+		}
+		return nil
+	},
+}
+
+func profileService() (*profilemaker.Service, error) {
+	cfg, err := config.NewConfig(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("the current config is not valid: %w", err)
+	}
+	return profilemaker.NewService(cfg, nil), nil
+}
+
+func displayPath(dir, path string) string {
+	if relative, err := filepath.Rel(dir, path); err == nil && !strings.HasPrefix(relative, "..") {
+		return relative
+	}
+	if home, err := os.UserHomeDir(); err == nil && strings.HasPrefix(path, home+"/") {
+		return "~" + strings.TrimPrefix(path, home)
+	}
+	return path
+}
+
+func describeConditions(conditions *config.ProfileCondition) string {
+	if summary := conditions.Summary(); summary != "" {
+		return summary
+	}
+	return "-"
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileListCmd, profileShowCmd, profileRenameCmd, profileDuplicateCmd,
+		profileDeleteCmd, profileSetConditionCmd)
+
+	profileRenameCmd.Flags().BoolVar(&profileMoveConfigFile, "move-config-file", false,
+		"Move the profile config file to a name derived from the new profile name")
+	profileRenameCmd.Flags().StringVar(&profileConfigFileTarget, "config-file-location", "",
+		"Where to move the profile config file (implies --move-config-file)")
+
+	profileDuplicateCmd.Flags().BoolVar(&profileCopyConfigFile, "copy-config-file", false,
+		"Copy the profile config file instead of sharing it")
+	profileDuplicateCmd.Flags().StringVar(&profileConfigFileTarget, "config-file-location", "",
+		"Where to copy the profile config file (implies --copy-config-file)")
+
+	profileDeleteCmd.Flags().BoolVar(&profileDeleteConfigFile, "delete-config-file", false,
+		"Remove the profile config file unless another profile uses it")
+
+	profileSetConditionCmd.Flags().BoolVar(&profileUnsetCondition, "unset", false,
+		"Remove the condition instead of setting it")
+}
@@ -17,6 +17,7 @@ var (
 	connectToSessionBus  bool
 	disablePowerEvents   bool
 	enableLidEvents      bool
+	enableBatteryEvents  bool
 )
 
 var runCmd = &cobra.Command{
@@ -37,7 +38,7 @@ var runCmd = &cobra.Command{
 
 		ctx, cancel := context.WithCancelCause(context.Background())
 		app, err := app.NewApplication(&configPath, &dryRun, ctx, cancel, &disablePowerEvents,
-			&disableAutoHotReload, &connectToSessionBus, &enableLidEvents)
+			&disableAutoHotReload, &connectToSessionBus, &enableLidEvents, &enableBatteryEvents)
 		if err != nil {
 			return fmt.Errorf("cant create application: %w", err)
 		}
@@ -91,4 +92,10 @@ func init() {
 		false,
 		"Enable listening to dbus lid events",
 	)
+	runCmd.Flags().BoolVar(
+		&enableBatteryEvents,
+		"enable-battery-events",
+		false,
+		"Enable listening to dbus battery percentage and power profile events",
+	)
 }
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/simulate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	scenarioPath         string
+	simulateDestination  string
+	simulateRunCallbacks bool
+)
+
+var simulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Replay a monitor, power and lid scenario against the configuration",
+	Long: `Replay a scripted scenario through the same profile matching and config generation
+pipeline the daemon uses, without Hyprland, UPower or real hardware.
+
+A scenario is a JSON file with a list of steps. Each step starts at offset_ms (relative to the
+start of the simulation) and sets any of: monitors (inline, in the hyprctl monitors -j format),
+monitors_file (a file in the same format, relative to the scenario), power_state (AC/BAT),
+lid_state (Opened/Closed), battery_percentage and power_profile. Fields that are not set carry
+over from the previous step, the first step needs to start at 0 and define the monitors.
+
+Example:
+  {"steps": [
+    {"offset_ms": 0, "monitors_file": "laptop.json", "power_state": "AC", "lid_state": "Opened"},
+    {"offset_ms": 1000, "monitors_file": "docked.json", "lid_state": "Closed"},
+    {"offset_ms": 5000, "power_state": "BAT"}
+  ]}
+
+Debounce settings from the configuration are honored, so steps closer together than the
+debounce time are coalesced just like in the daemon. The generated config is written to a
+scratch destination, desktop notifications are never sent, and pre/post apply exec callbacks
+only run with --run-callbacks.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.NewConfig(configPath)
+		if err != nil {
+			return fmt.Errorf("the current config is not valid: %w", err)
+		}
+
+		scenario, err := simulate.LoadScenario(scenarioPath)
+		if err != nil {
+			return fmt.Errorf("cant load scenario: %w", err)
+		}
+
+		destination := simulateDestination
+		if destination == "" {
+			dir, err := os.MkdirTemp("", "hyprdynamicmonitors-simulate-")
+			if err != nil {
+				return fmt.Errorf("cant create a scratch directory: %w", err)
+			}
+			destination = filepath.Join(dir, "monitors.conf")
+		}
+		simulate.PrepareConfig(cfg, destination, simulateRunCallbacks)
+
+		if err := simulate.Run(context.Background(), cfg, scenario, os.Stdout); err != nil {
+			return fmt.Errorf("cant simulate scenario: %w", err)
+		}
+		fmt.Printf("Generated config written to %s\n", destination)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(simulateCmd)
+
+	simulateCmd.Flags().StringVar(
+		&scenarioPath,
+		"scenario",
+		"",
+		"Path to the JSON scenario file",
+	)
+	_ = simulateCmd.MarkFlagRequired("scenario")
+	simulateCmd.Flags().StringVar(
+		&simulateDestination,
+		"destination",
+		"",
+		"Where to write the generated config (defaults to a file in a new temporary directory)",
+	)
+	simulateCmd.Flags().BoolVar(
+		&simulateRunCallbacks,
+		"run-callbacks",
+		false,
+		"Run the pre/post apply exec callbacks from the configuration",
+	)
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/configtest"
+	"github.com/spf13/cobra"
+)
+
+var (
+	testSuitePath string
+	testUpdate    bool
+)
+
+var testCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Run configuration test cases",
+	Long: `Run the configuration test suite, a TOML file with cases that describe the connected
+monitors, power and lid state together with the expected profile and, optionally, the expected
+rendered output. Every case is evaluated in memory through the same matcher and generator the
+daemon uses, the destination is never written to.
+
+By default the suite is read from tests.toml next to the configuration file. Paths inside the
+suite are relative to the suite file.
+
+Example:
+  [[case]]
+  name = "docked with the lid closed"
+  monitors_file = "testdata/docked.json"  # hyprctl monitors -j output
+  power_state = "AC"
+  lid_state = "Closed"
+  expected_profile = "docked"
+  expected_output_file = "testdata/docked.conf"
+  expected_output_contains = ["monitor=eDP-1,disable"]
+
+  [[case]]
+  name = "unknown monitor"
+  expected_profile = ""  # no profile matches
+  [[case.monitors]]
+  name = "HDMI-A-1"
+  description = "Unknown"
+
+The command exits with a non-zero code when any case fails, printing a diff for mismatched
+output. Use --update to write the rendered output to the expected output files instead.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.NewConfig(configPath)
+		if err != nil {
+			return fmt.Errorf("the current config is not valid: %w", err)
+		}
+
+		path := testSuitePath
+		if path == "" {
+			path = filepath.Join(filepath.Dir(configPath), configtest.DefaultFileName)
+		}
+		suite, err := configtest.LoadSuite(path)
+		if err != nil {
+			return fmt.Errorf("cant load the test suite: %w", err)
+		}
+
+		runner, err := configtest.NewRunner(cfg, testUpdate)
+		if err != nil {
+			return fmt.Errorf("cant create the test runner: %w", err)
+		}
+		if err := runner.Run(suite, os.Stdout); err != nil {
+			return fmt.Errorf("config tests failed: %w", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(testCmd)
+
+	testCmd.Flags().StringVar(
+		&testSuitePath,
+		"suite",
+		"",
+		"Path to the test suite (defaults to tests.toml next to the config file)",
+	)
+	testCmd.Flags().BoolVar(
+		&testUpdate,
+		"update",
+		false,
+		"Write the rendered output to expected_output_file instead of comparing it",
+	)
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/fiffeek/hyprdynamicmonitors/internal/analyzer"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/generators"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
@@ -8,10 +9,30 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	validateFailOnWarnings bool
+	validateFixtures       []string
+)
+
 var validateCmd = &cobra.Command{
 	Use:   "validate",
 	Short: "Validate configuration file",
-	Long:  `Validate the configuration file for syntax errors and logical consistency.`,
+	Long: `Validate the configuration file for syntax errors and logical consistency.
+
+The configuration is also analyzed for problems that do not make it invalid, these are
+reported as warnings:
+- profiles that can never be selected because another profile matches whenever they do and scores higher (or the same, but is defined later)
+- profiles that can match at the same time with equal scores, where only the order in the file decides
+- required monitors that can never match, e.g. the same name required twice or a regex that matches nothing
+- monitor tags used in a template (.MonitorsByTag) but not defined in the profile's required_monitors
+- monitor= lines in the rendered output that do not follow the Hyprland syntax
+
+Every template is rendered for each power and lid state its profile can match in, with monitors
+synthesized from the profile's required_monitors. Recorded monitor sets (hyprctl monitors -j) can be
+passed with --monitors-fixture, they are run through the matcher and the selected profile is
+rendered as well. Templates that fail to render make the validation fail.
+
+Use --fail-on-warnings to exit with a non-zero code when there are any warnings, e.g. in CI.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		logrus.WithField("config_path", configPath).Debug("Validating configuration")
 
@@ -22,17 +43,64 @@ var validateCmd = &cobra.Command{
 			return
 		}
 
-		_, err = generators.NewConfigGenerator(cfg)
+		generator, err := generators.NewConfigGenerator(cfg)
 		if err != nil {
 			utils.PrettyPrintError(err)
 			logrus.Fatal("Configuration validation failed")
 			return
 		}
 
+		fixtures := []*analyzer.Fixture{}
+		for _, path := range validateFixtures {
+			fixture, err := analyzer.LoadFixture(path)
+			if err != nil {
+				utils.PrettyPrintError(err)
+				logrus.Fatal("Configuration validation failed")
+				return
+			}
+			fixtures = append(fixtures, fixture)
+		}
+
+		a := analyzer.NewAnalyzer()
+		warnings := a.Analyze(cfg.Get())
+		renderWarnings, renderErrs := a.CheckRendering(cfg.Get(), generator, fixtures)
+		warnings = append(warnings, renderWarnings...)
+		for _, err := range renderErrs {
+			utils.PrettyPrintError(err)
+		}
+		if len(renderErrs) > 0 {
+			logrus.WithField("errors", len(renderErrs)).Fatal("Configuration validation failed")
+			return
+		}
+
+		for _, warning := range warnings {
+			logrus.WithFields(logrus.Fields{
+				"profile": warning.Profile,
+				"check":   warning.Check.String(),
+			}).Warn(warning.Message)
+		}
+		if len(warnings) > 0 && validateFailOnWarnings {
+			logrus.WithField("warnings", len(warnings)).Fatal("Configuration validation failed")
+			return
+		}
+
 		logrus.Info("Configuration is valid")
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(validateCmd)
+
+	validateCmd.Flags().BoolVar(
+		&validateFailOnWarnings,
+		"fail-on-warnings",
+		false,
+		"Exit with a non-zero code when the analysis reports any warnings",
+	)
+	validateCmd.Flags().StringArrayVar(
+		&validateFixtures,
+		"monitors-fixture",
+		[]string{},
+		"Monitor set in the hyprctl monitors -j format to render the matching profile with, can be repeated",
+	)
 }
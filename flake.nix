@@ -137,7 +137,7 @@
               after = [ cfg.systemdTarget "hyprdynamicmonitors-prepare.service" ];
 
               serviceConfig = {
-                Type = "simple";
+                Type = "notify";
                 ExecStart = "${cfg.package}/bin/hyprdynamicmonitors run --config ${cfg.configPath} ${lib.escapeShellArgs cfg.extraFlags}";
                 Slice = "session.slice";
                 Restart = "on-failure";
@@ -290,6 +290,7 @@
                 Requires = hmCfg.systemdTarget;
               };
               Service = {
+                Type = "notify";
                 ExecStart = "${hmCfg.package}/bin/hyprdynamicmonitors run --config ${hmCfg.configPath} ${lib.escapeShellArgs hmCfg.extraFlags}";
                 Restart = "on-failure";
                 RestartSec = 5;
@@ -0,0 +1,533 @@
+// Package analyzer statically inspects a configuration for profiles and rules that
+// can never take effect, the findings are warnings since the config is still valid
+package analyzer
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/matchers"
+)
+
+type Check int
+
+const (
+	UnreachableProfileCheck Check = iota
+	AmbiguousTieCheck
+	ImpossibleRuleCheck
+	UndefinedTagCheck
+	MalformedMonitorLineCheck
+)
+
+func (c Check) String() string {
+	switch c {
+	case UnreachableProfileCheck:
+		return "unreachable_profile"
+	case AmbiguousTieCheck:
+		return "ambiguous_tie"
+	case ImpossibleRuleCheck:
+		return "impossible_rule"
+	case UndefinedTagCheck:
+		return "undefined_tag"
+	case MalformedMonitorLineCheck:
+		return "malformed_monitor_line"
+	default:
+		return "unknown"
+	}
+}
+
+type Warning struct {
+	Check   Check
+	Profile string
+	Message string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("profile %s: %s", w.Profile, w.Message)
+}
+
+// monitorTagReference finds .MonitorsByTag.tag and index .MonitorsByTag "tag" in templates
+var monitorTagReference = regexp.MustCompile(`MonitorsByTag\.([A-Za-z0-9_]+)|index\s+\$?\.MonitorsByTag\s+"([^"]+)"`)
+
+type Analyzer struct {
+	matcher *matchers.Matcher
+}
+
+func NewAnalyzer() *Analyzer {
+	return &Analyzer{matcher: matchers.NewMatcher()}
+}
+
+// Analyze returns the warnings for cfg ordered by profile position in the config
+func (a *Analyzer) Analyze(cfg *config.RawConfig) []Warning {
+	warnings := []Warning{}
+	ordered := cfg.OrderedProfileKeys()
+
+	for _, name := range ordered {
+		warnings = append(warnings, a.impossibleRules(name, cfg.Profiles[name])...)
+		warnings = append(warnings, a.undefinedTags(name, cfg.Profiles[name])...)
+	}
+	warnings = append(warnings, a.shadowedProfiles(cfg, ordered)...)
+
+	position := map[string]int{}
+	for i, name := range ordered {
+		position[name] = i
+	}
+	sort.SliceStable(warnings, func(i, j int) bool {
+		return position[warnings[i].Profile] < position[warnings[j].Profile]
+	})
+	return warnings
+}
+
+// shadowedProfiles reports profiles that lose to another profile whenever they fully match, and
+// profiles that can match at the same time with equal scores where the order in the file decides
+func (a *Analyzer) shadowedProfiles(cfg *config.RawConfig, ordered []string) []Warning {
+	warnings := []Warning{}
+	unreachable := map[string]bool{}
+
+	for i, name := range ordered {
+		profile := cfg.Profiles[name]
+		score := a.matcher.FullProfileScore(cfg, profile.Conditions)
+		for j, otherName := range ordered {
+			if i == j {
+				continue
+			}
+			other := cfg.Profiles[otherName]
+			otherScore := a.matcher.FullProfileScore(cfg, other.Conditions)
+			if !implies(profile.Conditions, other.Conditions) {
+				continue
+			}
+			// the last profile in the file wins ties
+			if otherScore > score || (otherScore == score && j > i) {
+				unreachable[name] = true
+				warnings = append(warnings, Warning{
+					Check: UnreachableProfileCheck, Profile: name,
+					Message: fmt.Sprintf("can never be selected, %s matches whenever it does and wins "+
+						"(score %d vs %d, defined %s)", otherName, otherScore, score, relativeOrder(i, j)),
+				})
+				break
+			}
+		}
+	}
+
+	for i, name := range ordered {
+		for j := i + 1; j < len(ordered); j++ {
+			otherName := ordered[j]
+			if unreachable[name] || unreachable[otherName] {
+				continue
+			}
+			profile, other := cfg.Profiles[name], cfg.Profiles[otherName]
+			if a.matcher.FullProfileScore(cfg, profile.Conditions) != a.matcher.FullProfileScore(cfg, other.Conditions) ||
+				!compatible(profile.Conditions, other.Conditions) {
+				continue
+			}
+			warnings = append(warnings, Warning{
+				Check: AmbiguousTieCheck, Profile: name,
+				Message: fmt.Sprintf("can match at the same time as %s with an equal score, "+
+					"%s wins the tie because it is defined later", otherName, otherName),
+			})
+		}
+	}
+
+	return warnings
+}
+
+func relativeOrder(i, j int) string {
+	if j > i {
+		return "later"
+	}
+	return "earlier"
+}
+
+// implies reports whether every world that fully matches a also fully matches b
+func implies(a, b *config.ProfileCondition) bool {
+	if b.PowerState != nil && (a.PowerState == nil || *a.PowerState != *b.PowerState) {
+		return false
+	}
+	if b.LidState != nil && (a.LidState == nil || *a.LidState != *b.LidState) {
+		return false
+	}
+	if b.PowerProfile != nil && (a.PowerProfile == nil || *a.PowerProfile != *b.PowerProfile) {
+		return false
+	}
+	if b.BatteryBelow != nil && (a.BatteryBelow == nil || *a.BatteryBelow > *b.BatteryBelow) {
+		return false
+	}
+	if b.BatteryAbove != nil && (a.BatteryAbove == nil || *a.BatteryAbove < *b.BatteryAbove) {
+		return false
+	}
+
+	// every rule of b needs a distinct rule of a that is at least as strict
+	assigned := make([]int, len(a.RequiredMonitors))
+	for i := range assigned {
+		assigned[i] = -1
+	}
+	for ruleB := range b.RequiredMonitors {
+		if !assignRule(ruleB, a.RequiredMonitors, b.RequiredMonitors, assigned, make([]bool, len(a.RequiredMonitors))) {
+			return false
+		}
+	}
+	return true
+}
+
+// assignRule finds an augmenting path for a bipartite matching of b rules onto a rules
+func assignRule(ruleB int, rulesA, rulesB []*config.RequiredMonitor, assigned []int, visited []bool) bool {
+	for ruleA := range rulesA {
+		if visited[ruleA] || !ruleImplies(rulesA[ruleA], rulesB[ruleB]) {
+			continue
+		}
+		visited[ruleA] = true
+		if assigned[ruleA] == -1 || assignRule(assigned[ruleA], rulesA, rulesB, assigned, visited) {
+			assigned[ruleA] = ruleB
+			return true
+		}
+	}
+	return false
+}
+
+// ruleImplies reports whether a monitor matching rule a always matches rule b
+func ruleImplies(a, b *config.RequiredMonitor) bool {
+	if b.HasName() && (!a.HasName() || *a.Name != *b.Name || isRegex(a.MatchNameUsingRegex) != isRegex(b.MatchNameUsingRegex)) {
+		return false
+	}
+	if b.HasDescription() && (!a.HasDescription() || *a.Description != *b.Description ||
+		isRegex(a.MatchDescriptionUsingRegex) != isRegex(b.MatchDescriptionUsingRegex)) {
+		return false
+	}
+	return true
+}
+
+func isRegex(flag *bool) bool {
+	return flag != nil && *flag
+}
+
+func literalName(rule *config.RequiredMonitor) bool {
+	return rule.HasName() && !isRegex(rule.MatchNameUsingRegex)
+}
+
+func literalDescription(rule *config.RequiredMonitor) bool {
+	return rule.HasDescription() && !isRegex(rule.MatchDescriptionUsingRegex)
+}
+
+// compatible reports whether a and b can fully match at the same time, the monitors required
+// by both can be connected at once unless they need one name with different descriptions
+func compatible(a, b *config.ProfileCondition) bool {
+	if a.PowerState != nil && b.PowerState != nil && *a.PowerState != *b.PowerState {
+		return false
+	}
+	if a.LidState != nil && b.LidState != nil && *a.LidState != *b.LidState {
+		return false
+	}
+	if a.PowerProfile != nil && b.PowerProfile != nil && *a.PowerProfile != *b.PowerProfile {
+		return false
+	}
+
+	for _, ruleA := range a.RequiredMonitors {
+		for _, ruleB := range b.RequiredMonitors {
+			if literalName(ruleA) && literalName(ruleB) && *ruleA.Name == *ruleB.Name &&
+				literalDescription(ruleA) && literalDescription(ruleB) && *ruleA.Description != *ruleB.Description {
+				return false
+			}
+		}
+	}
+
+	lower, upper := 0, 101
+	for _, above := range []*int{a.BatteryAbove, b.BatteryAbove} {
+		if above != nil {
+			lower = max(lower, *above)
+		}
+	}
+	for _, below := range []*int{a.BatteryBelow, b.BatteryBelow} {
+		if below != nil {
+			upper = min(upper, *below)
+		}
+	}
+	return lower < upper
+}
+
+// impossibleRules reports required monitors that can never be matched, the profile
+// can not be selected in that case either
+func (a *Analyzer) impossibleRules(name string, profile *config.Profile) []Warning {
+	rules := profile.Conditions.RequiredMonitors
+	warnings := sharedNames(name, rules)
+
+	for i, rule := range rules {
+		if rule.NameRegex != nil && neverMatches(rule.NameRegex) {
+			warnings = append(warnings, Warning{
+				Check: ImpossibleRuleCheck, Profile: name,
+				Message: fmt.Sprintf("required_monitors[%d] name regex %q can never match", i, *rule.Name),
+			})
+		}
+		if rule.DescriptionRegex != nil && neverMatches(rule.DescriptionRegex) {
+			warnings = append(warnings, Warning{
+				Check: ImpossibleRuleCheck, Profile: name,
+				Message: fmt.Sprintf("required_monitors[%d] description regex %q can never match", i, *rule.Description),
+			})
+		}
+	}
+
+	return warnings
+}
+
+// maxPinnedNames bounds the expansion of anchored name regexes like ^DP-[1-3]$
+const maxPinnedNames = 64
+
+// sharedNames reports rules that need more distinct monitors than there are names they
+// can match, each rule consumes its own monitor and monitor names are unique
+func sharedNames(name string, rules []*config.RequiredMonitor) []Warning {
+	warnings := []Warning{}
+	pinned := make([][]string, len(rules))
+	for i, rule := range rules {
+		pinned[i] = pinnedNames(rule)
+	}
+
+	owner := map[string]int{}
+	for i := range rules {
+		if pinned[i] == nil {
+			continue
+		}
+		visited := map[string]bool{}
+		if assignName(i, pinned, owner, visited) {
+			continue
+		}
+
+		names := []string{}
+		conflicting := []int{i}
+		for candidate := range visited {
+			names = append(names, candidate)
+			conflicting = append(conflicting, owner[candidate])
+		}
+		sort.Strings(names)
+		sort.Ints(conflicting)
+
+		message := fmt.Sprintf("%s can only match the names %q, "+
+			"monitor names are unique so not all of them can match", ruleList(conflicting), names)
+		if len(names) == 1 {
+			message = fmt.Sprintf("required_monitors[%d] and required_monitors[%d] both require the name %q, "+
+				"monitor names are unique so both can never match", conflicting[0], conflicting[1], names[0])
+		}
+		warnings = append(warnings, Warning{Check: ImpossibleRuleCheck, Profile: name, Message: message})
+	}
+
+	return warnings
+}
+
+func ruleList(indexes []int) string {
+	rules := make([]string, len(indexes))
+	for i, index := range indexes {
+		rules[i] = fmt.Sprintf("required_monitors[%d]", index)
+	}
+	return strings.Join(rules[:len(rules)-1], ", ") + " and " + rules[len(rules)-1]
+}
+
+// assignName finds an augmenting path for a bipartite matching of rules onto monitor names
+func assignName(rule int, pinned [][]string, owner map[string]int, visited map[string]bool) bool {
+	for _, candidate := range pinned[rule] {
+		if visited[candidate] {
+			continue
+		}
+		visited[candidate] = true
+		current, taken := owner[candidate]
+		if !taken || assignName(current, pinned, owner, visited) {
+			owner[candidate] = rule
+			return true
+		}
+	}
+	return false
+}
+
+// pinnedNames returns every name the rule can match, or nil when that set is not known
+// to be small, only literal names and fully anchored regexes like ^(DP-1|HDMI-A-1)$ pin it
+func pinnedNames(rule *config.RequiredMonitor) []string {
+	if literalName(rule) {
+		return []string{*rule.Name}
+	}
+	if rule.NameRegex == nil {
+		return nil
+	}
+
+	parsed, err := syntax.Parse(rule.NameRegex.String(), syntax.Perl)
+	if err != nil {
+		return nil
+	}
+	parsed = parsed.Simplify()
+	for parsed.Op == syntax.OpCapture {
+		parsed = parsed.Sub[0]
+	}
+	if parsed.Op != syntax.OpConcat || len(parsed.Sub) < 2 ||
+		parsed.Sub[0].Op != syntax.OpBeginText || parsed.Sub[len(parsed.Sub)-1].Op != syntax.OpEndText {
+		return nil
+	}
+
+	names, ok := expand(&syntax.Regexp{Op: syntax.OpConcat, Sub: parsed.Sub[1 : len(parsed.Sub)-1]})
+	if !ok || len(names) == 0 {
+		return nil
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// expand lists the strings matched by a regex without repetitions or anchors
+func expand(re *syntax.Regexp) ([]string, bool) {
+	switch re.Op {
+	case syntax.OpEmptyMatch:
+		return []string{""}, true
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase != 0 {
+			return nil, false
+		}
+		return []string{string(re.Rune)}, true
+	case syntax.OpCharClass:
+		names := []string{}
+		for i := 0; i+1 < len(re.Rune); i += 2 {
+			for r := re.Rune[i]; r <= re.Rune[i+1]; r++ {
+				if len(names) == maxPinnedNames {
+					return nil, false
+				}
+				names = append(names, string(r))
+			}
+		}
+		return names, true
+	case syntax.OpCapture:
+		return expand(re.Sub[0])
+	case syntax.OpQuest:
+		names, ok := expand(re.Sub[0])
+		return append(names, ""), ok
+	case syntax.OpAlternate:
+		names := []string{}
+		for _, sub := range re.Sub {
+			expanded, ok := expand(sub)
+			if !ok || len(names)+len(expanded) > maxPinnedNames {
+				return nil, false
+			}
+			names = append(names, expanded...)
+		}
+		return names, true
+	case syntax.OpConcat:
+		names := []string{""}
+		for _, sub := range re.Sub {
+			expanded, ok := expand(sub)
+			if !ok || len(names)*len(expanded) > maxPinnedNames {
+				return nil, false
+			}
+			joined := make([]string, 0, len(names)*len(expanded))
+			for _, prefix := range names {
+				for _, suffix := range expanded {
+					joined = append(joined, prefix+suffix)
+				}
+			}
+			names = joined
+		}
+		return names, true
+	default:
+		return nil, false
+	}
+}
+
+// neverMatches detects regexes that can not match any input, e.g. empty classes or
+// characters required after the end of the text
+func neverMatches(re *regexp.Regexp) bool {
+	parsed, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return false
+	}
+	return !satisfiable(parsed.Simplify())
+}
+
+func satisfiable(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return false
+	case syntax.OpCharClass:
+		return len(re.Rune) > 0
+	case syntax.OpCapture, syntax.OpPlus:
+		return satisfiable(re.Sub[0])
+	case syntax.OpRepeat:
+		return re.Min == 0 || satisfiable(re.Sub[0])
+	case syntax.OpAlternate:
+		return slices.ContainsFunc(re.Sub, satisfiable)
+	case syntax.OpConcat:
+		if !slices.ContainsFunc(re.Sub, func(sub *syntax.Regexp) bool { return !satisfiable(sub) }) {
+			return anchorsSatisfiable(re.Sub)
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// anchorsSatisfiable rejects concatenations that consume input before \A or after \z
+func anchorsSatisfiable(subs []*syntax.Regexp) bool {
+	consumed := false
+	for i, sub := range subs {
+		if sub.Op == syntax.OpBeginText && consumed {
+			return false
+		}
+		if sub.Op == syntax.OpEndText && slices.ContainsFunc(subs[i+1:], consumes) {
+			return false
+		}
+		consumed = consumed || consumes(sub)
+	}
+	return true
+}
+
+// consumes reports whether every match of re is at least one character long
+func consumes(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return len(re.Rune) > 0
+	case syntax.OpCharClass, syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return true
+	case syntax.OpCapture, syntax.OpPlus:
+		return consumes(re.Sub[0])
+	case syntax.OpRepeat:
+		return re.Min > 0 && consumes(re.Sub[0])
+	case syntax.OpConcat:
+		return slices.ContainsFunc(re.Sub, consumes)
+	case syntax.OpAlternate:
+		return !slices.ContainsFunc(re.Sub, func(sub *syntax.Regexp) bool { return !consumes(sub) })
+	default:
+		return false
+	}
+}
+
+// undefinedTags reports tags referenced by the template that no required monitor defines,
+// the template renders them as empty values
+func (a *Analyzer) undefinedTags(name string, profile *config.Profile) []Warning {
+	if profile.ConfigType == nil || *profile.ConfigType != config.Template {
+		return nil
+	}
+	//nolint:gosec
+	contents, err := os.ReadFile(profile.ConfigFile)
+	if err != nil {
+		return nil
+	}
+
+	defined := map[string]bool{}
+	for _, rule := range profile.Conditions.RequiredMonitors {
+		if rule.MonitorTag != nil {
+			defined[*rule.MonitorTag] = true
+		}
+	}
+
+	warnings := []Warning{}
+	reported := map[string]bool{}
+	for _, match := range monitorTagReference.FindAllStringSubmatch(string(contents), -1) {
+		tag := match[1] + match[2]
+		if defined[tag] || reported[tag] {
+			continue
+		}
+		reported[tag] = true
+		warnings = append(warnings, Warning{
+			Check: UndefinedTagCheck, Profile: name,
+			Message: fmt.Sprintf("template %s uses the monitor tag %q which no required_monitors defines",
+				profile.ConfigFile, tag),
+		})
+	}
+	return warnings
+}
@@ -0,0 +1,231 @@
+package analyzer_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/analyzer"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/testutils"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func monitors(rules ...*config.RequiredMonitor) *config.ProfileCondition {
+	return &config.ProfileCondition{RequiredMonitors: rules}
+}
+
+func byName(name string) *config.RequiredMonitor {
+	return &config.RequiredMonitor{Name: utils.StringPtr(name)}
+}
+
+func byDescription(description string) *config.RequiredMonitor {
+	return &config.RequiredMonitor{Description: utils.StringPtr(description)}
+}
+
+func TestAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name     string
+		profiles map[string]*config.Profile
+		expected []analyzer.Warning
+	}{
+		{
+			name: "distinct profiles",
+			profiles: map[string]*config.Profile{
+				"a_laptop": {Conditions: monitors(byName("eDP-1"))},
+				"b_docked": {Conditions: monitors(byName("eDP-1"), byName("DP-1"))},
+			},
+			expected: []analyzer.Warning{},
+		},
+		{
+			name: "identical conditions, the later profile wins",
+			profiles: map[string]*config.Profile{
+				"a_first":  {Conditions: monitors(byName("eDP-1"))},
+				"b_second": {Conditions: monitors(byName("eDP-1"))},
+			},
+			expected: []analyzer.Warning{
+				{
+					Check: analyzer.UnreachableProfileCheck, Profile: "a_first",
+					Message: "can never be selected, b_second matches whenever it does and wins (score 1 vs 1, defined later)",
+				},
+			},
+		},
+		{
+			name: "narrower battery range with an equal score",
+			profiles: map[string]*config.Profile{
+				"a_critical": {Conditions: &config.ProfileCondition{
+					BatteryBelow:     utils.IntPtr(10),
+					RequiredMonitors: []*config.RequiredMonitor{byName("eDP-1")},
+				}},
+				"b_low": {Conditions: &config.ProfileCondition{
+					BatteryBelow:     utils.IntPtr(30),
+					RequiredMonitors: []*config.RequiredMonitor{byName("eDP-1")},
+				}},
+			},
+			expected: []analyzer.Warning{
+				{
+					Check: analyzer.UnreachableProfileCheck, Profile: "a_critical",
+					Message: "can never be selected, b_low matches whenever it does and wins (score 2 vs 2, defined later)",
+				},
+			},
+		},
+		{
+			name: "stricter profile defined first still wins",
+			profiles: map[string]*config.Profile{
+				"a_docked": {Conditions: monitors(byName("eDP-1"), byName("DP-1"))},
+				"b_laptop": {Conditions: monitors(byName("eDP-1"))},
+			},
+			expected: []analyzer.Warning{},
+		},
+		{
+			name: "ambiguous tie",
+			profiles: map[string]*config.Profile{
+				"a_by_name":        {Conditions: monitors(byName("DP-1"))},
+				"b_by_description": {Conditions: monitors(byDescription("LG Electronics"))},
+			},
+			expected: []analyzer.Warning{
+				{
+					Check: analyzer.AmbiguousTieCheck, Profile: "a_by_name",
+					Message: "can match at the same time as b_by_description with an equal score, " +
+						"b_by_description wins the tie because it is defined later",
+				},
+			},
+		},
+		{
+			name: "no tie across power states",
+			profiles: map[string]*config.Profile{
+				"a_ac": {Conditions: &config.ProfileCondition{
+					PowerState:       utils.JustPtr(config.AC),
+					RequiredMonitors: []*config.RequiredMonitor{byName("DP-1")},
+				}},
+				"b_bat": {Conditions: &config.ProfileCondition{
+					PowerState:       utils.JustPtr(config.BAT),
+					RequiredMonitors: []*config.RequiredMonitor{byDescription("LG Electronics")},
+				}},
+			},
+			expected: []analyzer.Warning{},
+		},
+		{
+			name: "no tie when the same name needs different descriptions",
+			profiles: map[string]*config.Profile{
+				"a_home": {Conditions: monitors(&config.RequiredMonitor{
+					Name: utils.StringPtr("DP-1"), Description: utils.StringPtr("LG"),
+				})},
+				"b_work": {Conditions: monitors(&config.RequiredMonitor{
+					Name: utils.StringPtr("DP-1"), Description: utils.StringPtr("Dell"),
+				})},
+			},
+			expected: []analyzer.Warning{},
+		},
+		{
+			name: "impossible rules",
+			profiles: map[string]*config.Profile{
+				"a_profile": {Conditions: monitors(
+					byName("DP-1"),
+					byName("DP-1"),
+					&config.RequiredMonitor{Name: utils.StringPtr("DP-$1"), MatchNameUsingRegex: utils.BoolPtr(true)},
+					&config.RequiredMonitor{Description: utils.StringPtr("LG|^Dell"), MatchDescriptionUsingRegex: utils.BoolPtr(true)},
+					&config.RequiredMonitor{Description: utils.StringPtr("x^Dell"), MatchDescriptionUsingRegex: utils.BoolPtr(true)},
+				)},
+			},
+			expected: []analyzer.Warning{
+				{
+					Check: analyzer.ImpossibleRuleCheck, Profile: "a_profile",
+					Message: "required_monitors[0] and required_monitors[1] both require the name \"DP-1\", " +
+						"monitor names are unique so both can never match",
+				},
+				{
+					Check: analyzer.ImpossibleRuleCheck, Profile: "a_profile",
+					Message: "required_monitors[2] name regex \"DP-$1\" can never match",
+				},
+				{
+					Check: analyzer.ImpossibleRuleCheck, Profile: "a_profile",
+					Message: "required_monitors[4] description regex \"x^Dell\" can never match",
+				},
+			},
+		},
+		{
+			name: "anchored name regexes competing for the same monitors",
+			profiles: map[string]*config.Profile{
+				"a_profile": {Conditions: monitors(
+					byName("DP-1"),
+					&config.RequiredMonitor{Name: utils.StringPtr("^DP-[12]$"), MatchNameUsingRegex: utils.BoolPtr(true)},
+					&config.RequiredMonitor{Name: utils.StringPtr("^(DP-2|DP-1)$"), MatchNameUsingRegex: utils.BoolPtr(true)},
+				)},
+				"b_profile": {Conditions: monitors(
+					&config.RequiredMonitor{
+						Name: utils.StringPtr("^HDMI-A-1$"), MatchNameUsingRegex: utils.BoolPtr(true),
+						Description: utils.StringPtr("LG"),
+					},
+					&config.RequiredMonitor{
+						Name: utils.StringPtr("HDMI-A-1"), Description: utils.StringPtr("Dell"),
+					},
+				)},
+			},
+			expected: []analyzer.Warning{
+				{
+					Check: analyzer.ImpossibleRuleCheck, Profile: "a_profile",
+					Message: "required_monitors[0], required_monitors[1] and required_monitors[2] can only match " +
+						"the names [\"DP-1\" \"DP-2\"], monitor names are unique so not all of them can match",
+				},
+				{
+					Check: analyzer.ImpossibleRuleCheck, Profile: "b_profile",
+					Message: "required_monitors[0] and required_monitors[1] both require the name \"HDMI-A-1\", " +
+						"monitor names are unique so both can never match",
+				},
+			},
+		},
+		{
+			name: "unanchored or open ended name regexes can match many monitors",
+			profiles: map[string]*config.Profile{
+				"a_profile": {Conditions: monitors(
+					byName("DP-1"),
+					&config.RequiredMonitor{Name: utils.StringPtr("DP-1"), MatchNameUsingRegex: utils.BoolPtr(true)},
+					&config.RequiredMonitor{Name: utils.StringPtr("^DP-1.*$"), MatchNameUsingRegex: utils.BoolPtr(true)},
+					&config.RequiredMonitor{Name: utils.StringPtr("(?i)^dp-1$"), MatchNameUsingRegex: utils.BoolPtr(true)},
+				)},
+			},
+			expected: []analyzer.Warning{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, profile := range tt.profiles {
+				profile.Name = name
+			}
+			cfg := testutils.NewTestConfig(t).WithProfiles(tt.profiles).Get()
+			assert.Equal(t, tt.expected, analyzer.NewAnalyzer().Analyze(cfg.Get()))
+		})
+	}
+}
+
+func TestAnalyzer_Analyze_UndefinedTags(t *testing.T) {
+	template := filepath.Join(t.TempDir(), "template.go.tmpl")
+	// nolint:gosec
+	assert.NoError(t, os.WriteFile(template, []byte(`
+monitor={{ .MonitorsByTag.laptop.Name }},preferred,auto,1
+monitor={{ (index .MonitorsByTag "external").Name }},preferred,auto,1
+monitor={{ $.MonitorsByTag.tv.Name }},preferred,auto,1
+`), 0o644))
+
+	cfg := testutils.NewTestConfig(t).WithProfiles(map[string]*config.Profile{
+		"docked": {
+			Name:       "docked",
+			ConfigType: utils.JustPtr(config.Template),
+			Conditions: monitors(
+				&config.RequiredMonitor{Name: utils.StringPtr("eDP-1"), MonitorTag: utils.StringPtr("laptop")},
+				&config.RequiredMonitor{Name: utils.StringPtr("DP-1")},
+			),
+		},
+	}).FillProfileConfigFile("docked", template).Get()
+
+	warnings := analyzer.NewAnalyzer().Analyze(cfg.Get())
+	assert.Len(t, warnings, 2)
+	for i, tag := range []string{"external", "tv"} {
+		assert.Equal(t, analyzer.UndefinedTagCheck, warnings[i].Check)
+		assert.Equal(t, "docked", warnings[i].Profile)
+		assert.Contains(t, warnings[i].Message, "uses the monitor tag \""+tag+"\" which no required_monitors defines")
+	}
+}
@@ -0,0 +1,262 @@
+package analyzer
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/generators"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/hypr"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/matchers"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/power"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+)
+
+// Fixture is a recorded monitor set (hyprctl monitors -j) that is run through the matcher
+type Fixture struct {
+	Name     string
+	Monitors hypr.MonitorSpecs
+}
+
+// LoadFixture reads a monitor set in the hyprctl monitors -j format
+func LoadFixture(path string) (*Fixture, error) {
+	//nolint:gosec
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cant read fixture: %w", err)
+	}
+	var monitors hypr.MonitorSpecs
+	if err := utils.UnmarshalResponse(contents, &monitors); err != nil {
+		return nil, fmt.Errorf("cant parse fixture %s: %w", path, err)
+	}
+	if err := monitors.Validate(); err != nil {
+		return nil, fmt.Errorf("fixture %s is invalid: %w", path, err)
+	}
+	return &Fixture{Name: path, Monitors: monitors}, nil
+}
+
+// world is one combination of the power, lid and battery state used for rendering
+type world struct {
+	power   power.PowerState
+	lid     power.LidState
+	battery power.BatteryState
+}
+
+func (w world) String() string {
+	return fmt.Sprintf("power %s, lid %s, battery %s", w.power.String(), w.lid.String(), w.battery.String())
+}
+
+// CheckRendering executes every template for each power/lid combination its profile can match with,
+// using monitors synthesized from the required_monitors and, for the fixtures, the profile
+// the matcher picks. Template execution failures are returned as errors, monitor lines that
+// do not follow the Hyprland syntax as warnings
+func (a *Analyzer) CheckRendering(cfg *config.RawConfig, generator *generators.ConfigGenerator,
+	fixtures []*Fixture,
+) ([]Warning, []error) {
+	warnings := []Warning{}
+	errs := []error{}
+	reported := map[string]bool{}
+
+	check := func(matched *matchers.MatchedProfile, monitors hypr.MonitorSpecs, w world, source string) {
+		name := matched.Profile.Name
+		rendered, err := generator.Render(cfg, matched, monitors, w.power, w.lid, w.battery)
+		if err != nil {
+			if key := name + err.Error(); !reported[key] {
+				reported[key] = true
+				errs = append(errs, fmt.Errorf("profile %s does not render with %s (%s): %w", name, source, w, err))
+			}
+			return
+		}
+		for _, warning := range malformedMonitorLines(name, matched.Profile.ConfigFile, rendered) {
+			if key := name + warning.Message; !reported[key] {
+				reported[key] = true
+				warnings = append(warnings, warning)
+			}
+		}
+	}
+
+	for _, name := range cfg.OrderedProfileKeys() {
+		profile := cfg.Profiles[name]
+		monitors, monitorToRule, err := synthesizeMonitors(profile.Conditions)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cant synthesize monitors for profile %s: %w", name, err))
+			continue
+		}
+		for _, w := range worlds(profile.Conditions) {
+			check(matchers.NewMatchedProfile(profile, monitorToRule), monitors, w, "synthetic monitors")
+		}
+	}
+
+	if cfg.FallbackProfile != nil {
+		monitors, _, err := synthesizeMonitors(&config.ProfileCondition{
+			RequiredMonitors: []*config.RequiredMonitor{{}},
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cant synthesize monitors for the fallback profile: %w", err))
+		} else {
+			for _, w := range worlds(&config.ProfileCondition{}) {
+				check(matchers.NewFallbackProfile(cfg.FallbackProfile), monitors, w, "synthetic monitors")
+			}
+		}
+	}
+
+	for _, fixture := range fixtures {
+		for _, w := range worlds(&config.ProfileCondition{}) {
+			found, matched, err := a.matcher.Match(cfg, fixture.Monitors, w.power, w.lid, w.battery)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("cant match fixture %s (%s): %w", fixture.Name, w, err))
+				continue
+			}
+			if found {
+				check(matched, fixture.Monitors, w, "fixture "+fixture.Name)
+			}
+		}
+	}
+
+	return warnings, errs
+}
+
+// worlds lists the power and lid states the profile can be matched in, battery
+// conditions are satisfied with a percentage within the required range
+func worlds(conditions *config.ProfileCondition) []world {
+	powerStates := []power.PowerState{power.ACPowerState, power.BatteryPowerState}
+	if conditions.PowerState != nil {
+		powerStates = []power.PowerState{power.ACPowerState}
+		if *conditions.PowerState == config.BAT {
+			powerStates = []power.PowerState{power.BatteryPowerState}
+		}
+	}
+
+	lidStates := []power.LidState{power.OpenedLidState, power.ClosedLidState}
+	if conditions.LidState != nil {
+		lidStates = []power.LidState{power.OpenedLidState}
+		if *conditions.LidState == config.ClosedLidStateType {
+			lidStates = []power.LidState{power.ClosedLidState}
+		}
+	}
+
+	battery := power.BatteryState{}
+	if conditions.BatteryBelow != nil || conditions.BatteryAbove != nil || conditions.PowerProfile != nil {
+		lower, upper := 0, 101
+		if conditions.BatteryAbove != nil {
+			lower = *conditions.BatteryAbove
+		}
+		if conditions.BatteryBelow != nil {
+			upper = *conditions.BatteryBelow
+		}
+		profile := power.UnknownPowerProfile
+		if conditions.PowerProfile != nil {
+			profile, _ = power.ParsePowerProfile(conditions.PowerProfile.Value())
+		}
+		battery = power.NewBatteryState(float64(lower+upper-1)/2, profile)
+	}
+
+	result := []world{}
+	for _, powerState := range powerStates {
+		for _, lidState := range lidStates {
+			result = append(result, world{power: powerState, lid: lidState, battery: battery})
+		}
+	}
+	return result
+}
+
+// synthesizeMonitors creates one monitor per required monitor rule that satisfies the rule
+func synthesizeMonitors(
+	conditions *config.ProfileCondition,
+) (hypr.MonitorSpecs, map[int]*config.RequiredMonitor, error) {
+	monitors := hypr.MonitorSpecs{}
+	monitorToRule := map[int]*config.RequiredMonitor{}
+	for i, rule := range conditions.RequiredMonitors {
+		monitor := &hypr.MonitorSpec{
+			ID:             utils.IntPtr(i),
+			Name:           synthesize(rule.Name, rule.NameRegex, fmt.Sprintf("HDMI-A-%d", i+1)),
+			Description:    synthesize(rule.Description, rule.DescriptionRegex, fmt.Sprintf("Synthetic Monitor %d", i+1)),
+			Width:          1920,
+			Height:         1080,
+			RefreshRate:    60,
+			Scale:          1,
+			X:              i * 1920,
+			AvailableModes: []string{"1920x1080@60.00Hz"},
+			Mirror:         "none",
+			CurrentFormat:  "XRGB8888",
+			DpmsStatus:     true,
+		}
+		if err := monitor.Validate(); err != nil {
+			return nil, nil, fmt.Errorf("synthetic monitor %d is invalid: %w", i, err)
+		}
+		monitors = append(monitors, monitor)
+		monitorToRule[i] = rule
+	}
+	return monitors, monitorToRule, nil
+}
+
+// synthesize returns the literal value, a string matching the regex, or the fallback
+func synthesize(value *string, re *regexp.Regexp, fallback string) string {
+	if value == nil {
+		return fallback
+	}
+	if re == nil {
+		return *value
+	}
+	parsed, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return fallback
+	}
+	var sample strings.Builder
+	writeSample(&sample, parsed.Simplify())
+	if !re.MatchString(sample.String()) {
+		return fallback
+	}
+	return sample.String()
+}
+
+// writeSample writes the shortest string the regex matches for the common constructs
+func writeSample(out *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		out.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		if len(re.Rune) > 0 {
+			out.WriteRune(re.Rune[0])
+		}
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		out.WriteRune('x')
+	case syntax.OpCapture, syntax.OpPlus:
+		writeSample(out, re.Sub[0])
+	case syntax.OpRepeat:
+		for range re.Min {
+			writeSample(out, re.Sub[0])
+		}
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			writeSample(out, sub)
+		}
+	case syntax.OpAlternate:
+		writeSample(out, re.Sub[0])
+	default:
+	}
+}
+
+// malformedMonitorLines validates every monitor= line of the rendered config
+func malformedMonitorLines(profile, configFile string, rendered []byte) []Warning {
+	warnings := []Warning{}
+	scanner := bufio.NewScanner(strings.NewReader(string(rendered)))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		value, ok := hypr.MonitorLineValue(scanner.Text())
+		if !ok {
+			continue
+		}
+		if err := hypr.ValidateMonitorLine(value); err != nil {
+			warnings = append(warnings, Warning{
+				Check: MalformedMonitorLineCheck, Profile: profile,
+				Message: fmt.Sprintf("%s renders a malformed monitor line %d %q: %v",
+					configFile, lineNumber, strings.TrimSpace(scanner.Text()), err),
+			})
+		}
+	}
+	return warnings
+}
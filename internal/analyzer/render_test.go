@@ -0,0 +1,139 @@
+package analyzer_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/analyzer"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/generators"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/testutils"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTemplate(t *testing.T, contents string) string {
+	path := filepath.Join(t.TempDir(), "template.go.tmpl")
+	// nolint:gosec
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+	return path
+}
+
+func TestAnalyzer_CheckRendering(t *testing.T) {
+	tests := []struct {
+		name             string
+		template         string
+		conditions       *config.ProfileCondition
+		expectedWarnings []string
+		expectedErrors   []string
+	}{
+		{
+			name: "renders for every state",
+			template: `{{- range .RequiredMonitors }}
+monitor={{ .Name }},{{ index .AvailableModes 0 }},auto,1
+{{- end }}
+{{- if isOnBattery }}
+monitor={{ .MonitorsByTag.laptop.Name }},preferred,auto,1.5
+{{- end }}`,
+			conditions: monitors(
+				&config.RequiredMonitor{Name: utils.StringPtr("eDP-1"), MonitorTag: utils.StringPtr("laptop")},
+				&config.RequiredMonitor{
+					Name: utils.StringPtr(`^DP-\d+$`), MatchNameUsingRegex: utils.BoolPtr(true),
+				},
+			),
+		},
+		{
+			name:       "missing monitor only fails on battery",
+			template:   `{{ if isOnBattery }}monitor={{ (index .RequiredMonitors 1).Name }},preferred,auto,1{{ end }}`,
+			conditions: monitors(byName("eDP-1")),
+			expectedErrors: []string{
+				"profile profile does not render with synthetic monitors (power BAT, lid Opened, battery UNKNOWN)",
+			},
+		},
+		{
+			name:     "power condition limits the rendered states",
+			template: `{{ if isOnBattery }}monitor={{ (index .RequiredMonitors 1).Name }},preferred,auto,1{{ end }}`,
+			conditions: &config.ProfileCondition{
+				PowerState:       utils.JustPtr(config.AC),
+				RequiredMonitors: []*config.RequiredMonitor{byName("eDP-1")},
+			},
+		},
+		{
+			name:     "invalid synthetic monitor",
+			template: `monitor={{ (index .RequiredMonitors 0).Name }},preferred,auto,1`,
+			conditions: monitors(&config.RequiredMonitor{
+				Name: utils.StringPtr(`^.*$`), MatchNameUsingRegex: utils.BoolPtr(true),
+			}),
+			expectedErrors: []string{
+				"cant synthesize monitors for profile profile: synthetic monitor 0 is invalid: name cant be empty",
+			},
+		},
+		{
+			name: "malformed lines",
+			template: `monitor={{ .missing }},preferred,auto,1
+monitor=eDP-1,preferred,auto
+monitor=eDP-1,preferred,auto,1 # a comment`,
+			conditions: monitors(byName("eDP-1")),
+			expectedWarnings: []string{
+				"renders a malformed monitor line 1 \"monitor=<no value>,preferred,auto,1\": contains <no value>",
+				"renders a malformed monitor line 2 \"monitor=eDP-1,preferred,auto\": expected name, resolution, position and scale",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testutils.NewTestConfig(t).WithProfiles(map[string]*config.Profile{
+				"profile": {Name: "profile", ConfigType: utils.JustPtr(config.Template), Conditions: tt.conditions},
+			}).FillProfileConfigFile("profile", writeTemplate(t, tt.template)).Get()
+			generator, err := generators.NewConfigGenerator(cfg)
+			require.NoError(t, err)
+
+			warnings, errs := analyzer.NewAnalyzer().CheckRendering(cfg.Get(), generator, nil)
+
+			require.Len(t, warnings, len(tt.expectedWarnings))
+			for i, expected := range tt.expectedWarnings {
+				assert.Equal(t, analyzer.MalformedMonitorLineCheck, warnings[i].Check)
+				assert.Contains(t, warnings[i].Message, expected)
+			}
+			require.Len(t, errs, len(tt.expectedErrors))
+			for i, expected := range tt.expectedErrors {
+				assert.Contains(t, errs[i].Error(), expected)
+			}
+		})
+	}
+}
+
+func TestAnalyzer_CheckRendering_Fixtures(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "docked.json")
+	// nolint:gosec
+	require.NoError(t, os.WriteFile(fixture, []byte(`[
+		{"id": 0, "name": "eDP-1", "description": "BOE NE135A1M-NY1"},
+		{"id": 1, "name": "DP-1", "description": "LG Electronics LG SDQHD"}
+	]`), 0o644))
+	loaded, err := analyzer.LoadFixture(fixture)
+	require.NoError(t, err)
+
+	cfg := testutils.NewTestConfig(t).WithProfiles(map[string]*config.Profile{
+		"laptop": {
+			Name: "laptop", ConfigType: utils.JustPtr(config.Template),
+			Conditions: monitors(byName("eDP-1")),
+		},
+	}).FillProfileConfigFile("laptop", writeTemplate(t, `{{ range .ExtraMonitors }}
+monitor={{ .Name }},{{ index .AvailableModes 0 }},auto,1
+{{- end }}`)).Get()
+	generator, err := generators.NewConfigGenerator(cfg)
+	require.NoError(t, err)
+
+	warnings, errs := analyzer.NewAnalyzer().CheckRendering(cfg.Get(), generator, nil)
+	assert.Empty(t, warnings)
+	assert.Empty(t, errs, "synthetic monitors have no extra monitors")
+
+	warnings, errs = analyzer.NewAnalyzer().CheckRendering(cfg.Get(), generator, []*analyzer.Fixture{loaded})
+	assert.Empty(t, warnings)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "profile laptop does not render with fixture "+fixture)
+	assert.Contains(t, errs[0].Error(), "error calling index")
+}
@@ -9,10 +9,12 @@ import (
 	"github.com/fiffeek/hyprdynamicmonitors/internal/filewatcher"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/generators"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/hypr"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/logind"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/matchers"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/notifications"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/power"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/reloader"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/sdnotify"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/signal"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/userconfigupdater"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
@@ -22,58 +24,59 @@ import (
 )
 
 type Application struct {
-	cfg           *config.Config
-	hyprIPC       *hypr.IPC
-	fswatcher     *filewatcher.Service
-	powerDetector *power.PowerDetector
-	lidDetector   *power.LidStateDetector
-	matcher       *matchers.Matcher
-	generator     *generators.ConfigGenerator
-	notifications *notifications.Service
-	svc           *userconfigupdater.Service
-	reloader      *reloader.Service
-	signal        *signal.Handler
+	cfg             *config.Config
+	hyprIPC         *hypr.IPC
+	fswatcher       *filewatcher.Service
+	powerDetector   IPowerDetector
+	lidDetector     ILidDetector
+	batteryDetector *power.BatteryDetector
+	matcher         *matchers.Matcher
+	generator       *generators.ConfigGenerator
+	notifications   *notifications.Service
+	svc             *userconfigupdater.Service
+	reloader        *reloader.Service
+	signal          *signal.Handler
+	sdNotifier      *sdnotify.Notifier
+	lidInhibitor    *logind.LidSwitchInhibitor
 }
 
 func NewApplication(
 	configPath *string, dryRun *bool, ctx context.Context,
 	cancel context.CancelCauseFunc, disablePowerEvents, disableAutoHotReload *bool,
-	connectToSessionBus, enableLidEvents *bool,
+	connectToSessionBus, enableLidEvents, enableBatteryEvents *bool,
 ) (*Application, error) {
 	cfg, err := config.NewConfig(*configPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load configuration: %w", err)
 	}
 
-	hyprIPC, err := hypr.NewIPC(ctx)
+	hyprIPC, err := hypr.NewIPC(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize Hyprland IPC: %w", err)
 	}
 
 	fswatcher := filewatcher.NewService(cfg, disableAutoHotReload)
 
-	var dbusPowerEvents *dbus.Conn
-	if !*disablePowerEvents {
-		dbusPowerEvents, err = getBus(*connectToSessionBus)
-		if err != nil {
-			return nil, fmt.Errorf("cant connect to dbus: %w", err)
-		}
-	}
-	powerDetector, err := power.NewPowerDetector(ctx, cfg, dbusPowerEvents, *disablePowerEvents)
+	powerDetector, err := newPowerDetector(ctx, cfg, *disablePowerEvents, *connectToSessionBus)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize PowerDetector: %w", err)
 	}
 
-	var dbusLidEvents *dbus.Conn
-	if *enableLidEvents {
-		dbusLidEvents, err = getBus(*connectToSessionBus)
+	lidDetector, err := newLidDetector(ctx, cfg, *enableLidEvents, *connectToSessionBus)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize LidDetector: %w", err)
+	}
+
+	var dbusBatteryEvents *dbus.Conn
+	if *enableBatteryEvents {
+		dbusBatteryEvents, err = getBus(*connectToSessionBus)
 		if err != nil {
 			return nil, fmt.Errorf("cant connect to dbus: %w", err)
 		}
 	}
-	lidDetector, err := power.NewLidStateDetector(ctx, cfg, dbusLidEvents, *enableLidEvents)
+	batteryDetector, err := power.NewBatteryDetector(ctx, cfg, dbusBatteryEvents, *enableBatteryEvents)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize LidDetector: %w", err)
+		return nil, fmt.Errorf("failed to initialize BatteryDetector: %w", err)
 	}
 
 	matcher := matchers.NewMatcher()
@@ -83,27 +86,35 @@ func NewApplication(
 		return nil, fmt.Errorf("can't create config generator, likely an issue with one of the template files: %w", err)
 	}
 	notifications := notifications.NewService(cfg)
+	sdNotifier := sdnotify.NewNotifier()
+	lidInhibitor := logind.NewLidSwitchInhibitor(func() (*dbus.Conn, error) {
+		return getBus(*connectToSessionBus)
+	})
 
 	svc := userconfigupdater.NewService(cfg, hyprIPC, powerDetector, &userconfigupdater.Config{
 		DryRun: *dryRun,
-	}, matcher, generator, notifications, lidDetector)
+	}, matcher, generator, notifications, lidDetector, batteryDetector, sdNotifier, lidInhibitor)
 
-	reloader := reloader.NewService(cfg, fswatcher, powerDetector, svc, *disableAutoHotReload, lidDetector, generator)
+	reloader := reloader.NewService(cfg, fswatcher, powerDetector, svc, *disableAutoHotReload, lidDetector,
+		batteryDetector, generator, notifications, sdNotifier)
 
 	signalHandler := signal.NewHandler(cancel, reloader, svc)
 
 	return &Application{
-		cfg:           cfg,
-		hyprIPC:       hyprIPC,
-		fswatcher:     fswatcher,
-		powerDetector: powerDetector,
-		matcher:       matcher,
-		generator:     generator,
-		notifications: notifications,
-		svc:           svc,
-		reloader:      reloader,
-		signal:        signalHandler,
-		lidDetector:   lidDetector,
+		cfg:             cfg,
+		hyprIPC:         hyprIPC,
+		fswatcher:       fswatcher,
+		powerDetector:   powerDetector,
+		matcher:         matcher,
+		generator:       generator,
+		notifications:   notifications,
+		svc:             svc,
+		reloader:        reloader,
+		signal:          signalHandler,
+		lidDetector:     lidDetector,
+		batteryDetector: batteryDetector,
+		sdNotifier:      sdNotifier,
+		lidInhibitor:    lidInhibitor,
 	}, nil
 }
 
@@ -126,10 +137,12 @@ func (a *Application) Run(ctx context.Context) error {
 		{Fun: a.signal.Run, Name: "signal handler"},
 		{Fun: a.fswatcher.Run, Name: "filewatcher"},
 		{Fun: a.hyprIPC.RunEventLoop, Name: "hypr ipc"},
-		{Fun: a.powerDetector.Run, Name: "power detector dbus"},
-		{Fun: a.lidDetector.Run, Name: "lid detector dbus"},
+		{Fun: a.powerDetector.Run, Name: "power detector"},
+		{Fun: a.lidDetector.Run, Name: "lid detector"},
+		{Fun: a.batteryDetector.Run, Name: "battery detector dbus"},
 		{Fun: a.reloader.Run, Name: "reloader"},
 		{Fun: a.svc.Run, Name: "main service"},
+		{Fun: a.runWatchdog, Name: "systemd watchdog"},
 	}
 	for _, bg := range backgroundGoroutines {
 		eg.Go(func() error {
@@ -147,6 +160,12 @@ func (a *Application) Run(ctx context.Context) error {
 	eg.Go(func() error {
 		<-ctx.Done()
 		logrus.Debug("Context cancelled, shutting down")
+		if err := a.sdNotifier.Stopping(); err != nil {
+			logrus.WithError(err).Warn("Cant notify systemd about stopping")
+		}
+		if err := a.lidInhibitor.Release(); err != nil {
+			logrus.WithError(err).Warn("Cant release the lid switch inhibitor")
+		}
 		return context.Cause(ctx)
 	})
 
@@ -157,3 +176,11 @@ func (a *Application) Run(ctx context.Context) error {
 	logrus.Info("Shutdown complete")
 	return nil
 }
+
+func (a *Application) runWatchdog(ctx context.Context) error {
+	if err := a.sdNotifier.RunWatchdog(ctx, a.hyprIPC.Healthy, a.powerDetector.Healthy, a.lidDetector.Healthy,
+		a.batteryDetector.Healthy); err != nil {
+		return fmt.Errorf("watchdog failed: %w", err)
+	}
+	return nil
+}
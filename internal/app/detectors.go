@@ -0,0 +1,130 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/power"
+	"github.com/sirupsen/logrus"
+)
+
+type IPowerDetector interface {
+	GetCurrentState() power.PowerState
+	Listen() <-chan power.PowerEvent
+	Reload(context.Context) error
+	Healthy() error
+	Run(context.Context) error
+}
+
+type ILidDetector interface {
+	GetCurrentState() power.LidState
+	Listen() <-chan power.LidEvent
+	Reload(context.Context) error
+	Healthy() error
+	Run(context.Context) error
+}
+
+// newPowerDetector picks the power backend, in auto mode UPower is preferred and
+// sysfs is used when the bus or UPower is not available
+func newPowerDetector(ctx context.Context, cfg *config.Config, disablePowerEvents,
+	connectToSessionBus bool,
+) (IPowerDetector, error) {
+	if disablePowerEvents {
+		detector, err := power.NewPowerDetector(ctx, cfg, nil, true)
+		if err != nil {
+			return nil, fmt.Errorf("cant init power detector: %w", err)
+		}
+		return detector, nil
+	}
+
+	backend := *cfg.Get().PowerEvents.Backend
+	if backend == config.SysfsDetectionBackend {
+		return newSysfsPowerDetector(cfg)
+	}
+
+	detector, err := newDbusPowerDetector(ctx, cfg, connectToSessionBus)
+	if err == nil || backend == config.DbusDetectionBackend {
+		return detector, err
+	}
+
+	logrus.WithError(err).Warn("D-Bus power detection is not available, falling back to sysfs")
+	fallback, sysfsErr := newSysfsPowerDetector(cfg)
+	if sysfsErr != nil {
+		logrus.WithError(sysfsErr).Error("Sysfs power detection is not available either")
+		return nil, err
+	}
+	return fallback, nil
+}
+
+func newDbusPowerDetector(ctx context.Context, cfg *config.Config, connectToSessionBus bool) (IPowerDetector, error) {
+	conn, err := getBus(connectToSessionBus)
+	if err != nil {
+		return nil, fmt.Errorf("cant connect to dbus: %w", err)
+	}
+	detector, err := power.NewPowerDetector(ctx, cfg, conn, false)
+	if err != nil {
+		return nil, fmt.Errorf("cant init power detector: %w", err)
+	}
+	return detector, nil
+}
+
+func newSysfsPowerDetector(cfg *config.Config) (IPowerDetector, error) {
+	detector, err := power.NewSysfsPowerDetector(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("cant init sysfs power detector: %w", err)
+	}
+	return detector, nil
+}
+
+// newLidDetector picks the lid backend, in auto mode UPower is preferred and
+// procfs is used when the bus or UPower is not available
+func newLidDetector(ctx context.Context, cfg *config.Config, enableLidEvents,
+	connectToSessionBus bool,
+) (ILidDetector, error) {
+	if !enableLidEvents {
+		detector, err := power.NewLidStateDetector(ctx, cfg, nil, false)
+		if err != nil {
+			return nil, fmt.Errorf("cant init lid detector: %w", err)
+		}
+		return detector, nil
+	}
+
+	backend := *cfg.Get().LidEvents.Backend
+	if backend == config.SysfsDetectionBackend {
+		return newSysfsLidDetector(cfg)
+	}
+
+	detector, err := newDbusLidDetector(ctx, cfg, connectToSessionBus)
+	if err == nil || backend == config.DbusDetectionBackend {
+		return detector, err
+	}
+
+	logrus.WithError(err).Warn("D-Bus lid detection is not available, falling back to procfs")
+	fallback, sysfsErr := newSysfsLidDetector(cfg)
+	if sysfsErr != nil {
+		logrus.WithError(sysfsErr).Error("Procfs lid detection is not available either")
+		return nil, err
+	}
+	return fallback, nil
+}
+
+func newDbusLidDetector(ctx context.Context, cfg *config.Config, connectToSessionBus bool) (ILidDetector, error) {
+	conn, err := getBus(connectToSessionBus)
+	if err != nil {
+		return nil, fmt.Errorf("cant connect to dbus: %w", err)
+	}
+	detector, err := power.NewLidStateDetector(ctx, cfg, conn, true)
+	if err != nil {
+		return nil, fmt.Errorf("cant init lid detector: %w", err)
+	}
+	return detector, nil
+}
+
+func newSysfsLidDetector(cfg *config.Config) (ILidDetector, error) {
+	detector, err := power.NewSysfsLidDetector(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("cant init procfs lid detector: %w", err)
+	}
+	return detector, nil
+}
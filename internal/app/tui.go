@@ -22,8 +22,8 @@ type TUI struct {
 	program   *tea.Program
 	fswatcher *filewatcher.Service
 	cfg       *config.Config
-	pw        *power.PowerDetector
-	ld        *power.LidStateDetector
+	pw        IPowerDetector
+	ld        ILidDetector
 }
 
 func NewTUI(ctx context.Context, configPath, mockedHyprMonitors string,
@@ -50,7 +50,7 @@ func NewTUI(ctx context.Context, configPath, mockedHyprMonitors string,
 
 		profileMaker = profilemaker.NewService(cfg, nil)
 	} else {
-		hyprIPC, err := hypr.NewIPC(ctx)
+		hyprIPC, err := hypr.NewIPC(ctx, cfg)
 		if err != nil {
 			return nil, fmt.Errorf("failed to connect to Hyprland IPC: %w", err)
 		}
@@ -64,31 +64,23 @@ func NewTUI(ctx context.Context, configPath, mockedHyprMonitors string,
 	}
 
 	var fw *filewatcher.Service
-	var pw *power.PowerDetector
+	var pw IPowerDetector
 	var currentState power.PowerState
 	if cfg != nil {
 		fw = filewatcher.NewService(cfg, utils.BoolPtr(false))
 	}
 	if cfg != nil && !disablePowerEvents {
-		conn, err := getBus(connectToSessionBus)
-		if err != nil {
-			return nil, fmt.Errorf("cant connect to dbus: %w", err)
-		}
-		pw, err = power.NewPowerDetector(ctx, cfg, conn, disablePowerEvents)
+		pw, err = newPowerDetector(ctx, cfg, disablePowerEvents, connectToSessionBus)
 		if err != nil {
 			return nil, fmt.Errorf("cant init power detector: %w", err)
 		}
 		currentState = pw.GetCurrentState()
 	}
 
-	var ld *power.LidStateDetector
+	var ld ILidDetector
 	var lidState power.LidState
 	if cfg != nil && enableLidEvents {
-		conn, err := getBus(connectToSessionBus)
-		if err != nil {
-			return nil, fmt.Errorf("cant connect to dbus: %w", err)
-		}
-		ld, err = power.NewLidStateDetector(ctx, cfg, conn, enableLidEvents)
+		ld, err = newLidDetector(ctx, cfg, enableLidEvents, connectToSessionBus)
 		if err != nil {
 			return nil, fmt.Errorf("cant init lid detector: %w", err)
 		}
@@ -134,7 +126,12 @@ func (t *TUI) Run(ctx context.Context, cancel context.CancelCauseFunc) error {
 					}
 					logrus.Debug("Watcher event received")
 					if err := t.cfg.Reload(); err != nil {
-						return fmt.Errorf("cant reload user configuration: %w", err)
+						logrus.WithError(err).Error("Invalid configuration, keeping the previous one")
+						continue
+					}
+					// a newly picked theme may live in a directory that is not watched yet
+					if err := t.fswatcher.Update(); err != nil {
+						logrus.WithError(err).Error("Cant update the watched paths")
 					}
 					t.program.Send(tui.ConfigReloaded{})
 
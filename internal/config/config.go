@@ -50,35 +50,60 @@ func (c *Config) Get() *RawConfig {
 	return c.cfg
 }
 
-func (c *Config) Reload() error {
+// Reload reads the configuration from disk and swaps it in only if it is valid,
+// the optional validators are run against the freshly loaded config before it is applied;
+// on any failure the last known good configuration is kept
+func (c *Config) Reload(validators ...func(*RawConfig) error) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	cfg, err := Load(c.path)
 	if err != nil {
 		return fmt.Errorf("cant reload config from %s: %w", c.path, err)
 	}
+	for _, validate := range validators {
+		if err := validate(cfg); err != nil {
+			return fmt.Errorf("cant reload config from %s: %w", c.path, err)
+		}
+	}
 	c.cfg = cfg
 	return nil
 }
 
 type RawConfig struct {
-	ConfigDirPath        string              `toml:"-"`
-	ConfigPath           string              `toml:"-"`
-	Profiles             map[string]*Profile `toml:"profiles"`
-	FallbackProfile      *Profile            `toml:"fallback_profile"`
-	General              *GeneralSection     `toml:"general"`
-	Scoring              *ScoringSection     `toml:"scoring"`
-	PowerEvents          *PowerSection       `toml:"power_events"`
-	LidEvents            *LidSection         `toml:"lid_events"`
-	HotReload            *HotReloadSection   `toml:"hot_reload_section"`
-	Notifications        *Notifications      `toml:"notifications"`
-	StaticTemplateValues map[string]string   `toml:"static_template_values"`
-	KeysOrder            []string            `toml:"-"`
-	TUISection           *TUISection         `toml:"tui"`
+	ConfigDirPath        string                `toml:"-"`
+	ConfigPath           string                `toml:"-"`
+	Profiles             map[string]*Profile   `toml:"profiles"`
+	FallbackProfile      *Profile              `toml:"fallback_profile"`
+	General              *GeneralSection       `toml:"general"`
+	Scoring              *ScoringSection       `toml:"scoring"`
+	PowerEvents          *PowerSection         `toml:"power_events"`
+	LidEvents            *LidSection           `toml:"lid_events"`
+	BatteryEvents        *BatterySection       `toml:"battery_events"`
+	HotReload            *HotReloadSection     `toml:"hot_reload_section"`
+	Notifications        *Notifications        `toml:"notifications"`
+	StaticTemplateValues map[string]string     `toml:"static_template_values"`
+	KeysOrder            []string              `toml:"-"`
+	TUISection           *TUISection           `toml:"tui"`
+	HyprIPC              *HyprIPCSection       `toml:"hypr_ipc"`
+	FlapDetection        *FlapDetectionSection `toml:"flap_detection"`
+	LayoutChecks         *LayoutChecksSection  `toml:"layout_checks"`
 }
 
 type TUISection struct {
 	Colors *TUIColors `toml:"colors"`
+	// Keys remaps the TUI bindings by the action name, the actions not listed keep the defaults
+	Keys map[string][]string `toml:"keys"`
+}
+
+// TUIKeysValidator checks the [tui.keys] remapping, the actions, their default keys and the views
+// they are active in belong to the TUI so it registers the validator
+type TUIKeysValidator func(keys map[string][]string) error
+
+var tuiKeysValidator TUIKeysValidator
+
+// RegisterTUIKeysValidator sets the validator TUISection.Validate runs on the remapped keys
+func RegisterTUIKeysValidator(validator TUIKeysValidator) {
+	tuiKeysValidator = validator
 }
 
 type TUIColors struct {
@@ -150,6 +175,154 @@ type TUIColors struct {
 	HelpSeparatorColor   *string `toml:"help_separator_color"`
 }
 
+type HyprIPCSection struct {
+	ReconnectInitialBackoffMs *int               `toml:"reconnect_initial_backoff_ms"`
+	ReconnectMaxBackoffMs     *int               `toml:"reconnect_max_backoff_ms"`
+	ReevaluateOn              []HyprEventTrigger `toml:"reevaluate_on"`
+	SelfWriteGracePeriodMs    *int               `toml:"self_write_grace_period_ms"`
+	ReconnectUnhealthyAfterMs *int               `toml:"reconnect_unhealthy_after_ms"`
+}
+
+// FlapDetectionSection configures quarantining of monitors that connect and disconnect
+// repeatedly in a short time span, e.g. behind a flaky dock
+type FlapDetectionSection struct {
+	Enabled        *bool `toml:"enabled"`
+	WindowMs       *int  `toml:"window_ms"`
+	MaxTransitions *int  `toml:"max_transitions"`
+	CooldownMs     *int  `toml:"cooldown_ms"`
+}
+
+// LayoutChecksSection configures the sanity checks run on the generated monitor layout
+// before it is written to the destination
+type LayoutChecksSection struct {
+	Policy *LayoutCheckPolicy `toml:"policy"`
+}
+
+// LayoutCheckPolicy decides what happens when the generated layout fails a check
+type LayoutCheckPolicy int
+
+const (
+	WarnLayoutCheckPolicy LayoutCheckPolicy = iota
+	BlockLayoutCheckPolicy
+	OffLayoutCheckPolicy
+)
+
+func (e LayoutCheckPolicy) Value() string {
+	switch e {
+	case WarnLayoutCheckPolicy:
+		return "warn"
+	case BlockLayoutCheckPolicy:
+		return "block"
+	case OffLayoutCheckPolicy:
+		return "off"
+	}
+	return ""
+}
+
+var allLayoutCheckPolicies = []LayoutCheckPolicy{WarnLayoutCheckPolicy, BlockLayoutCheckPolicy, OffLayoutCheckPolicy}
+
+func (e *LayoutCheckPolicy) UnmarshalTOML(value any) error {
+	sValue, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("value %v is not a string type", value)
+	}
+	for _, enum := range allLayoutCheckPolicies {
+		if enum.Value() == sValue {
+			*e = enum
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid enum value, expecting one of %s",
+		utils.FormatEnumTypes(allLayoutCheckPolicies))
+}
+
+func (e *LayoutCheckPolicy) MarshalTOML() ([]byte, error) {
+	return []byte("\"" + e.Value() + "\""), nil
+}
+
+type HyprEventTrigger int
+
+const (
+	MonitorAddedTrigger HyprEventTrigger = iota
+	MonitorRemovedTrigger
+	ConfigReloadedTrigger
+)
+
+func (e HyprEventTrigger) Value() string {
+	switch e {
+	case MonitorAddedTrigger:
+		return "monitoraddedv2"
+	case MonitorRemovedTrigger:
+		return "monitorremovedv2"
+	case ConfigReloadedTrigger:
+		return "configreloaded"
+	}
+	return ""
+}
+
+var allHyprEventTriggers = []HyprEventTrigger{MonitorAddedTrigger, MonitorRemovedTrigger, ConfigReloadedTrigger}
+
+func (e *HyprEventTrigger) UnmarshalTOML(value any) error {
+	sValue, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("value %v is not a string type", value)
+	}
+	for _, enum := range allHyprEventTriggers {
+		if enum.Value() == sValue {
+			*e = enum
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid enum value, expecting one of %s",
+		utils.FormatEnumTypes(allHyprEventTriggers))
+}
+
+func (e *HyprEventTrigger) MarshalTOML() ([]byte, error) {
+	return []byte("\"" + e.Value() + "\""), nil
+}
+
+// DetectionBackend selects where the power and lid states are read from
+type DetectionBackend int
+
+const (
+	AutoDetectionBackend DetectionBackend = iota
+	DbusDetectionBackend
+	SysfsDetectionBackend
+)
+
+func (e DetectionBackend) Value() string {
+	switch e {
+	case AutoDetectionBackend:
+		return "auto"
+	case DbusDetectionBackend:
+		return "dbus"
+	case SysfsDetectionBackend:
+		return "sysfs"
+	}
+	return ""
+}
+
+var allDetectionBackends = []DetectionBackend{AutoDetectionBackend, DbusDetectionBackend, SysfsDetectionBackend}
+
+func (e *DetectionBackend) UnmarshalTOML(value any) error {
+	sValue, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("value %v is not a string type", value)
+	}
+	for _, enum := range allDetectionBackends {
+		if enum.Value() == sValue {
+			*e = enum
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid enum value, expecting one of %s",
+		utils.FormatEnumTypes(allDetectionBackends))
+}
+
+func (e *DetectionBackend) MarshalTOML() ([]byte, error) {
+	return []byte("\"" + e.Value() + "\""), nil
+}
+
 type HotReloadSection struct {
 	UpdateDebounceTimer *int `toml:"debounce_time_ms"`
 }
@@ -160,12 +333,26 @@ type Notifications struct {
 }
 
 type LidSection struct {
+	Backend                  *DetectionBackend          `toml:"backend"`
+	SysfsPollIntervalMs      *int                       `toml:"sysfs_poll_interval_ms"`
 	DbusSignalMatchRules     []*DbusSignalMatchRule     `toml:"dbus_signal_match_rules"`
 	DbusSignalReceiveFilters []*DbusSignalReceiveFilter `toml:"dbus_signal_receive_filters"`
 	DbusQueryObject          *DbusQueryObject           `toml:"dbus_query_object"`
 }
 
+// BatterySection configures the battery percentage (UPower display device)
+// and the active power profile (power-profiles-daemon) detection
+type BatterySection struct {
+	HysteresisPercent        *int                       `toml:"hysteresis_percent"`
+	DbusSignalMatchRules     []*DbusSignalMatchRule     `toml:"dbus_signal_match_rules"`
+	DbusSignalReceiveFilters []*DbusSignalReceiveFilter `toml:"dbus_signal_receive_filters"`
+	PercentageQueryObject    *DbusQueryObject           `toml:"percentage_query_object"`
+	PowerProfileQueryObject  *DbusQueryObject           `toml:"power_profile_query_object"`
+}
+
 type PowerSection struct {
+	Backend                  *DetectionBackend          `toml:"backend"`
+	SysfsPollIntervalMs      *int                       `toml:"sysfs_poll_interval_ms"`
 	DbusSignalMatchRules     []*DbusSignalMatchRule     `toml:"dbus_signal_match_rules"`
 	DbusSignalReceiveFilters []*DbusSignalReceiveFilter `toml:"dbus_signal_receive_filters"`
 	DbusQueryObject          *DbusQueryObject           `toml:"dbus_query_object"`
@@ -197,23 +384,30 @@ type DbusSignalMatchRule struct {
 }
 
 type GeneralSection struct {
-	Destination    *string `toml:"destination"`
-	DebounceTimeMs *int    `toml:"debounce_time_ms"`
-	PostApplyExec  *string `toml:"post_apply_exec"`
-	PreApplyExec   *string `toml:"pre_apply_exec"`
+	Destination           *string `toml:"destination"`
+	DebounceTimeMs        *int    `toml:"debounce_time_ms"`
+	MonitorDebounceTimeMs *int    `toml:"monitor_debounce_time_ms"`
+	PowerDebounceTimeMs   *int    `toml:"power_debounce_time_ms"`
+	LidDebounceTimeMs     *int    `toml:"lid_debounce_time_ms"`
+	PostApplyExec         *string `toml:"post_apply_exec"`
+	PreApplyExec          *string `toml:"pre_apply_exec"`
 }
 
 type ScoringSection struct {
-	NameMatch        *int `toml:"name_match"`
-	DescriptionMatch *int `toml:"description_match"`
-	PowerStateMatch  *int `toml:"power_state_match"`
-	LidStateMatch    *int `toml:"lid_state_match"`
+	NameMatch         *int `toml:"name_match"`
+	DescriptionMatch  *int `toml:"description_match"`
+	PowerStateMatch   *int `toml:"power_state_match"`
+	LidStateMatch     *int `toml:"lid_state_match"`
+	BatteryMatch      *int `toml:"battery_match"`
+	PowerProfileMatch *int `toml:"power_profile_match"`
 }
 
 var reservedTemplateVariables = map[string]bool{
-	"MonitorsByTag": true,
-	"Monitors":      true,
-	"PowerState":    true,
+	"MonitorsByTag":     true,
+	"Monitors":          true,
+	"PowerState":        true,
+	"BatteryPercentage": true,
+	"PowerProfile":      true,
 }
 
 type ConfigFileType int
@@ -265,6 +459,7 @@ type Profile struct {
 	IsFallbackProfile    bool              `toml:"-"`
 	PostApplyExec        *string           `toml:"post_apply_exec"`
 	PreApplyExec         *string           `toml:"pre_apply_exec"`
+	Clamshell            *bool             `toml:"clamshell"`
 	KeyOrder             int               `toml:"-"`
 }
 
@@ -346,10 +541,54 @@ func (e *PowerStateType) MarshalTOML() ([]byte, error) {
 	return []byte("\"" + e.Value() + "\""), nil
 }
 
+type PowerProfileType int
+
+const (
+	PowerSaverProfileType PowerProfileType = iota
+	BalancedProfileType
+	PerformanceProfileType
+)
+
+func (e PowerProfileType) Value() string {
+	switch e {
+	case PowerSaverProfileType:
+		return "power-saver"
+	case BalancedProfileType:
+		return "balanced"
+	case PerformanceProfileType:
+		return "performance"
+	}
+	return ""
+}
+
+var allPowerProfileTypes = []PowerProfileType{PowerSaverProfileType, BalancedProfileType, PerformanceProfileType}
+
+func (e *PowerProfileType) UnmarshalTOML(value any) error {
+	sValue, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("value %v is not a string type", value)
+	}
+	for _, enum := range allPowerProfileTypes {
+		if enum.Value() == sValue {
+			*e = enum
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid enum value, expecting one of %s",
+		utils.FormatEnumTypes(allPowerProfileTypes))
+}
+
+func (e *PowerProfileType) MarshalTOML() ([]byte, error) {
+	return []byte("\"" + e.Value() + "\""), nil
+}
+
 type ProfileCondition struct {
 	RequiredMonitors []*RequiredMonitor `toml:"required_monitors"`
 	PowerState       *PowerStateType    `toml:"power_state"`
 	LidState         *LidStateType      `toml:"lid_state"`
+	BatteryBelow     *int               `toml:"battery_below"`
+	BatteryAbove     *int               `toml:"battery_above"`
+	PowerProfile     *PowerProfileType  `toml:"power_profile"`
 }
 
 type RequiredMonitor struct {
@@ -431,6 +670,11 @@ func Load(configPath string) (*RawConfig, error) {
 	var config RawConfig
 	m, err := toml.DecodeFile(configPath, &config)
 	if err != nil {
+		var parseErr toml.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, fmt.Errorf("failed to decode TOML at %s:%d:%d: %w", absConfig,
+				parseErr.Position.Line, parseErr.Position.Col, err)
+		}
 		return nil, fmt.Errorf("failed to decode TOML: %w", err)
 	}
 	keys := []string{}
@@ -526,6 +770,13 @@ func (c *RawConfig) Validate() error {
 		return fmt.Errorf("lid events section validation failed: %w", err)
 	}
 
+	if c.BatteryEvents == nil {
+		c.BatteryEvents = &BatterySection{}
+	}
+	if err := c.BatteryEvents.Validate(); err != nil {
+		return fmt.Errorf("battery events section validation failed: %w", err)
+	}
+
 	if c.Notifications == nil {
 		c.Notifications = &Notifications{}
 	}
@@ -546,6 +797,27 @@ func (c *RawConfig) Validate() error {
 		}
 	}
 
+	if c.HyprIPC == nil {
+		c.HyprIPC = &HyprIPCSection{}
+	}
+	if err := c.HyprIPC.Validate(); err != nil {
+		return fmt.Errorf("hypr ipc section validation failed: %w", err)
+	}
+
+	if c.FlapDetection == nil {
+		c.FlapDetection = &FlapDetectionSection{}
+	}
+	if err := c.FlapDetection.Validate(); err != nil {
+		return fmt.Errorf("flap detection section validation failed: %w", err)
+	}
+
+	if c.LayoutChecks == nil {
+		c.LayoutChecks = &LayoutChecksSection{}
+	}
+	if err := c.LayoutChecks.Validate(); err != nil {
+		return fmt.Errorf("layout checks section validation failed: %w", err)
+	}
+
 	if c.TUISection == nil {
 		c.TUISection = &TUISection{}
 	}
@@ -583,30 +855,47 @@ func (t *TUISection) Validate(configDirPath string) error {
 			return fmt.Errorf("cant get absolute path to colors file %s: %w", *t.Colors.SourceFile, err)
 		}
 
-		t.Colors.SourceFile = utils.JustPtr(absConfigFile)
-
-		contents, err := os.ReadFile(*t.Colors.SourceFile)
+		colors, err := LoadTUIColors(absConfigFile)
 		if err != nil {
-			return fmt.Errorf("cant read colors file %s: %w", *t.Colors.SourceFile, err)
+			return err
 		}
-		logrus.Debugf("Config contents: %s", contents)
-
-		var config TUIColors
-		_, err = toml.DecodeFile(*t.Colors.SourceFile, &config)
-		if err != nil {
-			return fmt.Errorf("failed to decode TOML: %w", err)
-		}
-
-		config.SourceFileDir = utils.JustPtr(filepath.Dir(*t.Colors.SourceFile))
-		t.Colors = &config
+		t.Colors = colors
 	}
 	if err := t.Colors.Validate(); err != nil {
 		return fmt.Errorf("tui colors validation failed: %w", err)
 	}
 
+	if tuiKeysValidator != nil {
+		if err := tuiKeysValidator(t.Keys); err != nil {
+			return fmt.Errorf("tui keys validation failed: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// LoadTUIColors reads a theme file, the colors it does not set get the defaults, the source is
+// kept so the theme in use can be told apart
+func LoadTUIColors(path string) (*TUIColors, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cant read colors file %s: %w", path, err)
+	}
+	logrus.Debugf("Config contents: %s", contents)
+
+	var colors TUIColors
+	if _, err := toml.Decode(string(contents), &colors); err != nil {
+		return nil, fmt.Errorf("failed to decode TOML: %w", err)
+	}
+
+	colors.SourceFile = utils.JustPtr(path)
+	colors.SourceFileDir = utils.JustPtr(filepath.Dir(path))
+	if err := colors.Validate(); err != nil {
+		return nil, fmt.Errorf("tui colors validation failed: %w", err)
+	}
+	return &colors, nil
+}
+
 func (t *TUIColors) Validate() error {
 	// Pane borders
 	if t.ActivePaneColor == nil {
@@ -790,6 +1079,69 @@ func (h *HotReloadSection) Validate() error {
 	return nil
 }
 
+func (h *HyprIPCSection) Validate() error {
+	if h.ReconnectInitialBackoffMs == nil {
+		h.ReconnectInitialBackoffMs = utils.IntPtr(500)
+	}
+	if h.ReconnectMaxBackoffMs == nil {
+		h.ReconnectMaxBackoffMs = utils.IntPtr(30000)
+	}
+	if *h.ReconnectInitialBackoffMs < 1 {
+		return errors.New("reconnect_initial_backoff_ms needs to be >= 1")
+	}
+	if *h.ReconnectMaxBackoffMs < *h.ReconnectInitialBackoffMs {
+		return errors.New("reconnect_max_backoff_ms cant be lower than reconnect_initial_backoff_ms")
+	}
+	if h.ReevaluateOn == nil {
+		h.ReevaluateOn = slices.Clone(allHyprEventTriggers)
+	}
+	if h.SelfWriteGracePeriodMs == nil {
+		h.SelfWriteGracePeriodMs = utils.IntPtr(1000)
+	}
+	if *h.SelfWriteGracePeriodMs < 0 {
+		return errors.New("self_write_grace_period_ms cant be negative")
+	}
+	if h.ReconnectUnhealthyAfterMs == nil {
+		h.ReconnectUnhealthyAfterMs = utils.IntPtr(300000)
+	}
+	if *h.ReconnectUnhealthyAfterMs < *h.ReconnectMaxBackoffMs {
+		return errors.New("reconnect_unhealthy_after_ms cant be lower than reconnect_max_backoff_ms")
+	}
+	return nil
+}
+
+func (f *FlapDetectionSection) Validate() error {
+	if f.Enabled == nil {
+		f.Enabled = utils.BoolPtr(false)
+	}
+	if f.WindowMs == nil {
+		f.WindowMs = utils.IntPtr(10000)
+	}
+	if f.MaxTransitions == nil {
+		f.MaxTransitions = utils.IntPtr(8)
+	}
+	if f.CooldownMs == nil {
+		f.CooldownMs = utils.IntPtr(30000)
+	}
+	if *f.WindowMs < 1 {
+		return errors.New("window_ms needs to be >= 1")
+	}
+	if *f.MaxTransitions < 1 {
+		return errors.New("max_transitions needs to be >= 1")
+	}
+	if *f.CooldownMs < 0 {
+		return errors.New("cooldown_ms cant be negative")
+	}
+	return nil
+}
+
+func (l *LayoutChecksSection) Validate() error {
+	if l.Policy == nil {
+		l.Policy = utils.JustPtr(WarnLayoutCheckPolicy)
+	}
+	return nil
+}
+
 func (n *Notifications) Validate() error {
 	if n.Disabled == nil {
 		n.Disabled = utils.BoolPtr(false)
@@ -812,6 +1164,19 @@ func (g *GeneralSection) Validate() error {
 	if g.DebounceTimeMs == nil {
 		g.DebounceTimeMs = utils.IntPtr(3000)
 	}
+	if *g.DebounceTimeMs < 0 {
+		return errors.New("debounce_time_ms cant be negative")
+	}
+
+	// per-source debounce times fall back to the shared one
+	for _, debounce := range []**int{&g.MonitorDebounceTimeMs, &g.PowerDebounceTimeMs, &g.LidDebounceTimeMs} {
+		if *debounce == nil {
+			*debounce = utils.IntPtr(*g.DebounceTimeMs)
+		}
+		if **debounce < 0 {
+			return errors.New("per-source debounce times cant be negative")
+		}
+	}
 
 	return nil
 }
@@ -831,8 +1196,17 @@ func (s *ScoringSection) Validate() error {
 	if s.LidStateMatch == nil {
 		s.LidStateMatch = &defaultScore
 	}
+	if s.BatteryMatch == nil {
+		s.BatteryMatch = &defaultScore
+	}
+	if s.PowerProfileMatch == nil {
+		s.PowerProfileMatch = &defaultScore
+	}
 
-	fields := []int{*s.DescriptionMatch, *s.NameMatch, *s.PowerStateMatch, *s.LidStateMatch}
+	fields := []int{
+		*s.DescriptionMatch, *s.NameMatch, *s.PowerStateMatch, *s.LidStateMatch,
+		*s.BatteryMatch, *s.PowerProfileMatch,
+	}
 	for _, field := range fields {
 		if 1 > field {
 			return errors.New("scoring section validation failed, score needs to be > 1")
@@ -910,7 +1284,35 @@ func (pc *ProfileCondition) IsEmpty() bool {
 	if pc == nil {
 		return true
 	}
-	return len(pc.RequiredMonitors) == 0 && pc.PowerState == nil && pc.LidState == nil
+	return len(pc.RequiredMonitors) == 0 && pc.PowerState == nil && pc.LidState == nil &&
+		pc.BatteryBelow == nil && pc.BatteryAbove == nil && pc.PowerProfile == nil
+}
+
+// Summary is a one-line description of the conditions, empty when there are none
+func (pc *ProfileCondition) Summary() string {
+	if pc.IsEmpty() {
+		return ""
+	}
+	parts := []string{}
+	if count := len(pc.RequiredMonitors); count > 0 {
+		parts = append(parts, fmt.Sprintf("%d monitors", count))
+	}
+	if pc.PowerState != nil {
+		parts = append(parts, "power="+pc.PowerState.Value())
+	}
+	if pc.LidState != nil {
+		parts = append(parts, "lid="+pc.LidState.Value())
+	}
+	if pc.BatteryBelow != nil {
+		parts = append(parts, fmt.Sprintf("battery<%d", *pc.BatteryBelow))
+	}
+	if pc.BatteryAbove != nil {
+		parts = append(parts, fmt.Sprintf("battery>%d", *pc.BatteryAbove))
+	}
+	if pc.PowerProfile != nil {
+		parts = append(parts, "power_profile="+pc.PowerProfile.Value())
+	}
+	return strings.Join(parts, ", ")
 }
 
 func (pc *ProfileCondition) Validate() error {
@@ -928,6 +1330,15 @@ func (pc *ProfileCondition) Validate() error {
 		}
 	}
 
+	for _, threshold := range []*int{pc.BatteryBelow, pc.BatteryAbove} {
+		if threshold != nil && (*threshold < 0 || *threshold > 100) {
+			return fmt.Errorf("battery threshold %d needs to be within [0, 100]", *threshold)
+		}
+	}
+	if pc.BatteryBelow != nil && pc.BatteryAbove != nil && *pc.BatteryAbove >= *pc.BatteryBelow {
+		return errors.New("battery_above needs to be lower than battery_below")
+	}
+
 	return nil
 }
 
@@ -992,6 +1403,10 @@ func (rm *RequiredMonitor) HasDescription() bool {
 }
 
 func (ls *LidSection) Validate() error {
+	if err := validateBackend(&ls.Backend, &ls.SysfsPollIntervalMs); err != nil {
+		return err
+	}
+
 	if len(ls.DbusSignalMatchRules) == 0 {
 		ls.DbusSignalMatchRules = []*DbusSignalMatchRule{
 			{},
@@ -1039,7 +1454,100 @@ func (ls *LidSection) Validate() error {
 	return nil
 }
 
+func (bs *BatterySection) Validate() error {
+	if bs.HysteresisPercent == nil {
+		bs.HysteresisPercent = utils.IntPtr(2)
+	}
+	if *bs.HysteresisPercent < 0 || *bs.HysteresisPercent > 100 {
+		return errors.New("hysteresis_percent needs to be within [0, 100]")
+	}
+
+	if len(bs.DbusSignalMatchRules) == 0 {
+		// the display device aggregates all batteries, power-profiles-daemon exposes the active profile
+		bs.DbusSignalMatchRules = []*DbusSignalMatchRule{
+			{ObjectPath: utils.StringPtr("/org/freedesktop/UPower/devices/DisplayDevice")},
+			{ObjectPath: utils.StringPtr("/net/hadess/PowerProfiles")},
+		}
+	}
+
+	defaultInterface := "org.freedesktop.DBus.Properties"
+	defaultMember := "PropertiesChanged"
+	defaultObjectPath := "/org/freedesktop/UPower/devices/DisplayDevice"
+	for _, rule := range bs.DbusSignalMatchRules {
+		if err := rule.Validate(defaultInterface, defaultMember, defaultObjectPath); err != nil {
+			return fmt.Errorf("one of the dbus match rules is invalid: %w", err)
+		}
+	}
+
+	if bs.DbusSignalReceiveFilters == nil {
+		bs.DbusSignalReceiveFilters = []*DbusSignalReceiveFilter{
+			{Name: utils.StringPtr("org.freedesktop.DBus.Properties.PropertiesChanged"), Body: utils.StringPtr("Percentage")},
+			{Name: utils.StringPtr("org.freedesktop.DBus.Properties.PropertiesChanged"), Body: utils.StringPtr("ActiveProfile")},
+		}
+	}
+
+	for _, signalFilter := range bs.DbusSignalReceiveFilters {
+		if err := signalFilter.Validate(); err != nil {
+			return fmt.Errorf("one of the dbus receive filter is invalid: %w", err)
+		}
+	}
+
+	if bs.PercentageQueryObject == nil {
+		bs.PercentageQueryObject = &DbusQueryObject{}
+	}
+	if err := bs.PercentageQueryObject.Validate("org.freedesktop.UPower", "org.freedesktop.DBus.Properties.Get",
+		"/org/freedesktop/UPower/devices/DisplayDevice", "",
+		[]DbusQueryObjectArg{{Arg: "org.freedesktop.UPower.Device"}, {Arg: "Percentage"}}, ""); err != nil {
+		return fmt.Errorf("dbus query object for the battery percentage is invalid: %w", err)
+	}
+
+	if bs.PowerProfileQueryObject == nil {
+		bs.PowerProfileQueryObject = &DbusQueryObject{}
+	}
+	if err := bs.PowerProfileQueryObject.Validate("net.hadess.PowerProfiles", "org.freedesktop.DBus.Properties.Get",
+		"/net/hadess/PowerProfiles", "",
+		[]DbusQueryObjectArg{{Arg: "net.hadess.PowerProfiles"}, {Arg: "ActiveProfile"}}, ""); err != nil {
+		return fmt.Errorf("dbus query object for the power profile is invalid: %w", err)
+	}
+
+	return nil
+}
+
+// BatteryThresholds returns all distinct battery thresholds used in profile conditions
+func (c *RawConfig) BatteryThresholds() []int {
+	thresholds := []int{}
+	for _, profile := range c.Profiles {
+		if profile.Conditions == nil {
+			continue
+		}
+		for _, threshold := range []*int{profile.Conditions.BatteryBelow, profile.Conditions.BatteryAbove} {
+			if threshold != nil && !slices.Contains(thresholds, *threshold) {
+				thresholds = append(thresholds, *threshold)
+			}
+		}
+	}
+	slices.Sort(thresholds)
+	return thresholds
+}
+
+func validateBackend(backend **DetectionBackend, pollIntervalMs **int) error {
+	if *backend == nil {
+		*backend = utils.JustPtr(AutoDetectionBackend)
+	}
+	if *pollIntervalMs == nil {
+		*pollIntervalMs = utils.IntPtr(2000)
+	}
+	if **pollIntervalMs < 1 {
+		return errors.New("sysfs_poll_interval_ms needs to be positive")
+	}
+	return nil
+}
+
 func (ps *PowerSection) Validate() error {
+	if err := validateBackend(&ps.Backend, &ps.SysfsPollIntervalMs); err != nil {
+		return err
+	}
+
 	if len(ps.DbusSignalMatchRules) == 0 {
 		// listen to
 		// gdbus monitor -y -d org.freedesktop.UPower | grep -E "PropertiesChanged|Device(Added|Removed)"
@@ -2,6 +2,7 @@ package config_test
 
 import (
 	"bytes"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -296,6 +297,29 @@ func TestLoad(t *testing.T) {
 	}
 }
 
+func TestConfigReloadKeepsLastGood(t *testing.T) {
+	cfg := testutils.NewTestConfig(t).Get()
+	lastGood := cfg.Get()
+	configPath := lastGood.ConfigPath
+
+	// nolint:gosec
+	require.NoError(t, os.WriteFile(configPath, []byte("[general]\ndestination = \n"), 0o644))
+	err := cfg.Reload()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), configPath+":2:", "error should point at the offending line")
+	assert.Same(t, lastGood, cfg.Get())
+
+	// nolint:gosec
+	require.NoError(t, os.WriteFile(configPath, []byte("[general]\ndestination = \"/tmp/a\"\n"), 0o644))
+	validatorErr := errors.New("validator error")
+	err = cfg.Reload(func(*config.RawConfig) error { return validatorErr })
+	require.ErrorIs(t, err, validatorErr)
+	assert.Same(t, lastGood, cfg.Get())
+
+	require.NoError(t, cfg.Reload())
+	assert.Equal(t, "/tmp/a", *cfg.Get().General.Destination)
+}
+
 func TestGeneralSectionValidate(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -408,6 +432,134 @@ func TestScoringSectionValidate(t *testing.T) {
 	}
 }
 
+func TestGeneralSectionPerSourceDebounce(t *testing.T) {
+	general := &config.GeneralSection{
+		DebounceTimeMs:      utils.IntPtr(500),
+		PowerDebounceTimeMs: utils.IntPtr(50),
+	}
+	require.NoError(t, general.Validate())
+	assert.Equal(t, 500, *general.MonitorDebounceTimeMs, "should fall back to debounce_time_ms")
+	assert.Equal(t, 50, *general.PowerDebounceTimeMs)
+	assert.Equal(t, 500, *general.LidDebounceTimeMs, "should fall back to debounce_time_ms")
+
+	general = &config.GeneralSection{LidDebounceTimeMs: utils.IntPtr(-1)}
+	assert.Error(t, general.Validate())
+}
+
+func TestFlapDetectionSectionValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		section     *config.FlapDetectionSection
+		expectError bool
+	}{
+		{
+			name:    "nil values get defaults",
+			section: &config.FlapDetectionSection{},
+		},
+		{
+			name:        "zero window causes error",
+			section:     &config.FlapDetectionSection{WindowMs: utils.IntPtr(0)},
+			expectError: true,
+		},
+		{
+			name:        "zero max transitions causes error",
+			section:     &config.FlapDetectionSection{MaxTransitions: utils.IntPtr(0)},
+			expectError: true,
+		},
+		{
+			name:        "negative cooldown causes error",
+			section:     &config.FlapDetectionSection{CooldownMs: utils.IntPtr(-1)},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.section.Validate()
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.False(t, *tt.section.Enabled)
+			assert.Equal(t, 10000, *tt.section.WindowMs)
+			assert.Equal(t, 8, *tt.section.MaxTransitions)
+			assert.Equal(t, 30000, *tt.section.CooldownMs)
+		})
+	}
+}
+
+func TestHyprIPCSectionValidate(t *testing.T) {
+	tests := []struct {
+		name            string
+		section         *config.HyprIPCSection
+		expectError     bool
+		expectedInitial int
+		expectedMax     int
+	}{
+		{
+			name:            "nil values get defaults",
+			section:         &config.HyprIPCSection{},
+			expectedInitial: 500,
+			expectedMax:     30000,
+		},
+		{
+			name: "negative grace period causes error",
+			section: &config.HyprIPCSection{
+				SelfWriteGracePeriodMs: utils.IntPtr(-1),
+			},
+			expectError: true,
+		},
+		{
+			name: "existing values preserved",
+			section: &config.HyprIPCSection{
+				ReconnectInitialBackoffMs: utils.IntPtr(100),
+				ReconnectMaxBackoffMs:     utils.IntPtr(1000),
+			},
+			expectedInitial: 100,
+			expectedMax:     1000,
+		},
+		{
+			name: "zero initial backoff causes error",
+			section: &config.HyprIPCSection{
+				ReconnectInitialBackoffMs: utils.IntPtr(0),
+			},
+			expectError: true,
+		},
+		{
+			name: "unhealthy limit lower than max backoff causes error",
+			section: &config.HyprIPCSection{
+				ReconnectMaxBackoffMs:     utils.IntPtr(1000),
+				ReconnectUnhealthyAfterMs: utils.IntPtr(999),
+			},
+			expectError: true,
+		},
+		{
+			name: "max lower than initial causes error",
+			section: &config.HyprIPCSection{
+				ReconnectInitialBackoffMs: utils.IntPtr(1000),
+				ReconnectMaxBackoffMs:     utils.IntPtr(100),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.section.Validate()
+
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedInitial, *tt.section.ReconnectInitialBackoffMs)
+			assert.Equal(t, tt.expectedMax, *tt.section.ReconnectMaxBackoffMs)
+		})
+	}
+}
+
 func TestRequiredMonitorValidate(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -571,6 +723,257 @@ func TestEnumUnmarshalTOML(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("PowerProfileType", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			value       interface{}
+			expected    config.PowerProfileType
+			expectError bool
+		}{
+			{
+				name:     "power-saver",
+				value:    "power-saver",
+				expected: config.PowerSaverProfileType,
+			},
+			{
+				name:     "performance",
+				value:    "performance",
+				expected: config.PerformanceProfileType,
+			},
+			{
+				name:        "invalid string",
+				value:       "turbo",
+				expectError: true,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var profile config.PowerProfileType
+				err := profile.UnmarshalTOML(tt.value)
+
+				if tt.expectError {
+					assert.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, profile)
+			})
+		}
+	})
+
+	t.Run("DetectionBackend", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			value       interface{}
+			expected    config.DetectionBackend
+			expectError bool
+		}{
+			{
+				name:     "auto",
+				value:    "auto",
+				expected: config.AutoDetectionBackend,
+			},
+			{
+				name:     "sysfs",
+				value:    "sysfs",
+				expected: config.SysfsDetectionBackend,
+			},
+			{
+				name:        "invalid string",
+				value:       "acpid",
+				expectError: true,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var backend config.DetectionBackend
+				err := backend.UnmarshalTOML(tt.value)
+
+				if tt.expectError {
+					assert.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, backend)
+			})
+		}
+	})
+
+	t.Run("HyprEventTrigger", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			value       interface{}
+			expected    config.HyprEventTrigger
+			expectError bool
+		}{
+			{
+				name:     "monitoraddedv2",
+				value:    "monitoraddedv2",
+				expected: config.MonitorAddedTrigger,
+			},
+			{
+				name:     "configreloaded",
+				value:    "configreloaded",
+				expected: config.ConfigReloadedTrigger,
+			},
+			{
+				name:        "invalid string",
+				value:       "workspace",
+				expectError: true,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var trigger config.HyprEventTrigger
+				err := trigger.UnmarshalTOML(tt.value)
+
+				if tt.expectError {
+					assert.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, trigger)
+			})
+		}
+	})
+
+	t.Run("LayoutCheckPolicy", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			value       interface{}
+			expected    config.LayoutCheckPolicy
+			expectError bool
+		}{
+			{
+				name:     "warn",
+				value:    "warn",
+				expected: config.WarnLayoutCheckPolicy,
+			},
+			{
+				name:     "block",
+				value:    "block",
+				expected: config.BlockLayoutCheckPolicy,
+			},
+			{
+				name:     "off",
+				value:    "off",
+				expected: config.OffLayoutCheckPolicy,
+			},
+			{
+				name:        "invalid string",
+				value:       "error",
+				expectError: true,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var policy config.LayoutCheckPolicy
+				err := policy.UnmarshalTOML(tt.value)
+
+				if tt.expectError {
+					assert.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, policy)
+			})
+		}
+	})
+}
+
+func TestBatterySectionValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		section     *config.BatterySection
+		expectError bool
+	}{
+		{
+			name:    "nil values get defaults",
+			section: &config.BatterySection{},
+		},
+		{
+			name:        "negative hysteresis causes error",
+			section:     &config.BatterySection{HysteresisPercent: utils.IntPtr(-1)},
+			expectError: true,
+		},
+		{
+			name:        "hysteresis above 100 causes error",
+			section:     &config.BatterySection{HysteresisPercent: utils.IntPtr(101)},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.section.Validate()
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 2, *tt.section.HysteresisPercent)
+			assert.Len(t, tt.section.DbusSignalMatchRules, 2)
+			assert.Len(t, tt.section.DbusSignalReceiveFilters, 2)
+			require.NotNil(t, tt.section.PercentageQueryObject)
+			assert.Equal(t, "org.freedesktop.UPower", tt.section.PercentageQueryObject.Destination)
+			require.NotNil(t, tt.section.PowerProfileQueryObject)
+			assert.Equal(t, "net.hadess.PowerProfiles", tt.section.PowerProfileQueryObject.Destination)
+		})
+	}
+}
+
+func TestProfileConditionBatteryValidate(t *testing.T) {
+	monitors := []*config.RequiredMonitor{{Name: utils.StringPtr("eDP-1")}}
+	tests := []struct {
+		name        string
+		condition   *config.ProfileCondition
+		expectError bool
+	}{
+		{
+			name:      "valid range",
+			condition: &config.ProfileCondition{RequiredMonitors: monitors, BatteryAbove: utils.IntPtr(20), BatteryBelow: utils.IntPtr(80)},
+		},
+		{
+			name:        "threshold above 100",
+			condition:   &config.ProfileCondition{RequiredMonitors: monitors, BatteryBelow: utils.IntPtr(120)},
+			expectError: true,
+		},
+		{
+			name:        "empty range",
+			condition:   &config.ProfileCondition{RequiredMonitors: monitors, BatteryAbove: utils.IntPtr(50), BatteryBelow: utils.IntPtr(50)},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.condition.Validate()
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDetectionBackendDefaults(t *testing.T) {
+	power := &config.PowerSection{}
+	require.NoError(t, power.Validate())
+	assert.Equal(t, config.AutoDetectionBackend, *power.Backend)
+	assert.Equal(t, 2000, *power.SysfsPollIntervalMs)
+
+	lid := &config.LidSection{Backend: utils.JustPtr(config.SysfsDetectionBackend)}
+	require.NoError(t, lid.Validate())
+	assert.Equal(t, config.SysfsDetectionBackend, *lid.Backend)
+
+	lid = &config.LidSection{SysfsPollIntervalMs: utils.IntPtr(0)}
+	assert.Error(t, lid.Validate())
 }
 
 func TestPowerSectionValidate(t *testing.T) {
@@ -1092,3 +1495,20 @@ func Test__ReadWriteSelf(t *testing.T) {
 
 	testutils.AssertFixture(t, cfgFile, "testdata/fixtures/minimal.toml", *regenerate)
 }
+
+func TestTUISectionValidateKeys(t *testing.T) {
+	t.Cleanup(func() { config.RegisterTUIKeysValidator(nil) })
+
+	section := &config.TUISection{Keys: map[string][]string{"teleport": {"t"}}}
+	require.NoError(t, section.Validate(t.TempDir()), "keys are not checked without a validator")
+
+	var validated map[string][]string
+	config.RegisterTUIKeysValidator(func(keys map[string][]string) error {
+		validated = keys
+		return errors.New("unknown tui key action: teleport")
+	})
+	err := section.Validate(t.TempDir())
+	require.Error(t, err)
+	assert.Equal(t, "tui keys validation failed: unknown tui key action: teleport", err.Error())
+	assert.Equal(t, section.Keys, validated)
+}
@@ -0,0 +1,668 @@
+// Package configedit edits the configuration file in place, tables are located by their headers
+// and only the touched lines are rewritten so comments, ordering and formatting are preserved
+package configedit
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const profilesKey = "profiles"
+
+var (
+	bareKeyRegex   = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	keyValueRegex  = regexp.MustCompile(`^\s*([A-Za-z0-9_-]+|"[^"]*"|'[^']*')\s*[.=]`)
+	ErrNotFound    = errors.New("profile not found")
+	ErrExists      = errors.New("profile already exists")
+	ErrUnsupported = errors.New("profile is not defined with [profiles.<name>] tables, edit it by hand")
+	ErrInline      = errors.New("table is defined inline, edit it by hand")
+)
+
+// Document is the configuration file split into lines
+type Document struct {
+	lines []string
+}
+
+// table is a [header] or [[header]] with the lines up to the next table, comment lines right
+// above the header belong to the table
+type table struct {
+	path   []string
+	tokens []string
+	array  bool
+	header int
+	start  int
+	end    int
+}
+
+func Parse(contents []byte) *Document {
+	text := strings.TrimSuffix(string(contents), "\n")
+	if text == "" {
+		return &Document{lines: []string{}}
+	}
+	return &Document{lines: strings.Split(text, "\n")}
+}
+
+func (d *Document) Bytes() []byte {
+	if len(d.lines) == 0 {
+		return []byte{}
+	}
+	return []byte(strings.Join(d.lines, "\n") + "\n")
+}
+
+// ProfileNames returns the profiles with a table in the order they are first defined
+func (d *Document) ProfileNames() []string {
+	names := []string{}
+	for _, t := range d.tables() {
+		if len(t.path) >= 2 && t.path[0] == profilesKey && !slices.Contains(names, t.path[1]) {
+			names = append(names, t.path[1])
+		}
+	}
+	return names
+}
+
+// Profile returns the lines of every table of the profile, including their comments
+func (d *Document) Profile(name string) (string, error) {
+	tables, err := d.profileTables(name)
+	if err != nil {
+		return "", err
+	}
+	lines := []string{}
+	for _, t := range tables {
+		lines = append(lines, d.lines[t.start:t.end]...)
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n") + "\n", nil
+}
+
+// RenameProfile rewrites the headers of the profile tables, the position in the file is kept
+func (d *Document) RenameProfile(from, to string) error {
+	tables, err := d.profileTables(from)
+	if err != nil {
+		return err
+	}
+	if err := d.checkNewName(to); err != nil {
+		return err
+	}
+	for _, t := range tables {
+		d.lines[t.header] = renameHeader(d.lines[t.header], t, to)
+	}
+	return nil
+}
+
+// DuplicateProfile copies the profile tables under a new name right after the last table of the
+// copied profile, so the copy wins ties against it
+func (d *Document) DuplicateProfile(from, to string) error {
+	tables, err := d.profileTables(from)
+	if err != nil {
+		return err
+	}
+	if err := d.checkNewName(to); err != nil {
+		return err
+	}
+
+	last := tables[len(tables)-1]
+	copied := []string{}
+	if last.end > 0 && strings.TrimSpace(d.lines[last.end-1]) != "" {
+		copied = append(copied, "")
+	}
+	for _, t := range tables {
+		for i := t.start; i < t.end; i++ {
+			line := d.lines[i]
+			if i == t.header {
+				line = renameHeader(line, t, to)
+			}
+			copied = append(copied, line)
+		}
+	}
+	copied = trimTrailingBlank(copied)
+	if last.end < len(d.lines) {
+		copied = append(copied, "")
+	}
+	d.lines = slices.Insert(d.lines, last.end, copied...)
+	return nil
+}
+
+// DeleteProfile removes every table of the profile together with its comments
+func (d *Document) DeleteProfile(name string) error {
+	tables, err := d.profileTables(name)
+	if err != nil {
+		return err
+	}
+	for i := len(tables) - 1; i >= 0; i-- {
+		d.lines = slices.Delete(d.lines, tables[i].start, tables[i].end)
+	}
+	return nil
+}
+
+// SetProfileValue sets key in the profile table at subtable (e.g. conditions), the table is
+// created after the profile's main table when missing, a nil value removes the key
+func (d *Document) SetProfileValue(name string, subtable []string, key string, value any) error {
+	tables, err := d.profileTables(name)
+	if err != nil {
+		return err
+	}
+	path := append([]string{profilesKey, name}, subtable...)
+
+	var target, main *table
+	for _, t := range tables {
+		if slices.Equal(t.path, path) && !t.array {
+			target = t
+		}
+		if len(t.path) == 2 && !t.array {
+			main = t
+		}
+	}
+	if main == nil {
+		return ErrUnsupported
+	}
+	if target == nil && len(subtable) > 0 && d.definesKey(main, subtable[0]) {
+		return fmt.Errorf("%s is defined inline in [%s]: %w", subtable[0], formatPath(main.path), ErrUnsupported)
+	}
+
+	if value == nil {
+		if target == nil {
+			return nil
+		}
+		if line, ok := d.findKey(target, key); ok {
+			d.lines = slices.Delete(d.lines, line, line+1)
+		}
+		return nil
+	}
+
+	literal, err := formatValue(value)
+	if err != nil {
+		return err
+	}
+	assignment := formatKey(key) + " = " + literal
+
+	if target == nil {
+		position := d.lastContentLine(main) + 1
+		d.lines = slices.Insert(d.lines, position, "", "["+formatPath(path)+"]", assignment)
+		return nil
+	}
+	if line, ok := d.findKey(target, key); ok {
+		d.lines[line] = replaceValue(d.lines[line], literal)
+		return nil
+	}
+	d.lines = slices.Insert(d.lines, d.lastContentLine(target)+1, assignment)
+	return nil
+}
+
+// SetValue sets key in the table at path (e.g. tui, colors) outside of the profiles, the table is
+// appended to the end of the file when missing, a nil value removes the key
+func (d *Document) SetValue(path []string, key string, value any) error {
+	var target *table
+	for _, t := range d.tables() {
+		if t.array {
+			continue
+		}
+		if slices.Equal(t.path, path) {
+			target = t
+			continue
+		}
+		if len(t.path) < len(path) && slices.Equal(t.path, path[:len(t.path)]) &&
+			d.definesKey(t, path[len(t.path)]) {
+			return fmt.Errorf("%s is defined in [%s]: %w", path[len(t.path)], formatPath(t.path), ErrInline)
+		}
+	}
+
+	if value == nil {
+		if target == nil {
+			return nil
+		}
+		if line, ok := d.findKey(target, key); ok {
+			d.lines = slices.Delete(d.lines, line, line+1)
+		}
+		return nil
+	}
+
+	literal, err := formatValue(value)
+	if err != nil {
+		return err
+	}
+	assignment := formatKey(key) + " = " + literal
+
+	if target == nil {
+		d.lines = trimTrailingBlank(d.lines)
+		if len(d.lines) > 0 {
+			d.lines = append(d.lines, "")
+		}
+		d.lines = append(d.lines, "["+formatPath(path)+"]", assignment)
+		return nil
+	}
+	if line, ok := d.findKey(target, key); ok {
+		d.lines[line] = replaceValue(d.lines[line], literal)
+		return nil
+	}
+	d.lines = slices.Insert(d.lines, d.lastContentLine(target)+1, assignment)
+	return nil
+}
+
+// Field is a key = value line of a table written by SetProfileArray
+type Field struct {
+	Key   string
+	Value any
+}
+
+// SetProfileArray replaces the [[array]] tables of the profile at path (e.g. conditions,
+// required_monitors) with one table per entry, the comments above the first table are kept and
+// the new tables are written where the old ones were, or after their parent table when missing
+func (d *Document) SetProfileArray(name string, path []string, entries [][]Field) error {
+	tables, err := d.profileTables(name)
+	if err != nil {
+		return err
+	}
+	full := append([]string{profilesKey, name}, path...)
+	parentPath := full[:len(full)-1]
+
+	var parent, main *table
+	arrays := []*table{}
+	for _, t := range tables {
+		if slices.Equal(t.path, full) && t.array {
+			arrays = append(arrays, t)
+		}
+		if slices.Equal(t.path, parentPath) && !t.array {
+			parent = t
+		}
+		if len(t.path) == 2 && !t.array {
+			main = t
+		}
+	}
+	if main == nil {
+		return ErrUnsupported
+	}
+	if parent == nil && len(path) > 1 && d.definesKey(main, path[0]) {
+		return fmt.Errorf("%s is defined inline in [%s]: %w", path[0], formatPath(main.path), ErrUnsupported)
+	}
+	if parent != nil && d.definesKey(parent, path[len(path)-1]) {
+		return fmt.Errorf("%s is defined inline in [%s]: %w", path[len(path)-1], formatPath(parent.path),
+			ErrUnsupported)
+	}
+
+	lines := []string{}
+	for i, entry := range entries {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "[["+formatPath(full)+"]]")
+		for _, field := range entry {
+			literal, err := formatValue(field.Value)
+			if err != nil {
+				return err
+			}
+			lines = append(lines, formatKey(field.Key)+" = "+literal)
+		}
+	}
+
+	if len(arrays) == 0 {
+		if len(lines) == 0 {
+			return nil
+		}
+		anchor := main
+		if parent != nil {
+			anchor = parent
+		}
+		d.lines = slices.Insert(d.lines, d.lastContentLine(anchor)+1, append([]string{""}, lines...)...)
+		return nil
+	}
+
+	first, last := arrays[0], arrays[len(arrays)-1]
+	for _, t := range d.tables() {
+		if t.header > first.header && t.header < last.header && (!t.array || !slices.Equal(t.path, full)) {
+			return fmt.Errorf("[[%s]] tables are split by [%s]: %w", formatPath(full), formatPath(t.path),
+				ErrUnsupported)
+		}
+	}
+	start := first.header
+	if len(lines) == 0 {
+		start = first.start
+	}
+	d.lines = slices.Delete(d.lines, start, d.lastContentLine(last)+1)
+	if len(lines) > 0 {
+		d.lines = slices.Insert(d.lines, start, lines...)
+		return nil
+	}
+	// drop the blank line that separated the removed tables from the previous one
+	if start > 0 && strings.TrimSpace(d.lines[start-1]) == "" &&
+		(start == len(d.lines) || strings.TrimSpace(d.lines[start]) == "") {
+		d.lines = slices.Delete(d.lines, start-1, start)
+	}
+	return nil
+}
+
+func (d *Document) checkNewName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("profile name cant be empty")
+	}
+	if slices.Contains(d.ProfileNames(), name) {
+		return fmt.Errorf("%s: %w", name, ErrExists)
+	}
+	return nil
+}
+
+func (d *Document) profileTables(name string) ([]*table, error) {
+	tables := []*table{}
+	for _, t := range d.tables() {
+		if len(t.path) >= 2 && t.path[0] == profilesKey && t.path[1] == name {
+			tables = append(tables, t)
+		}
+	}
+	if len(tables) == 0 {
+		if d.definedInline(name) {
+			return nil, fmt.Errorf("%s: %w", name, ErrUnsupported)
+		}
+		return nil, fmt.Errorf("%s: %w", name, ErrNotFound)
+	}
+	return tables, nil
+}
+
+// definedInline reports profiles set with dotted keys or inline tables inside [profiles]
+func (d *Document) definedInline(name string) bool {
+	for _, t := range d.tables() {
+		if len(t.path) == 1 && t.path[0] == profilesKey && d.definesKey(t, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Document) definesKey(t *table, key string) bool {
+	_, ok := d.findKeyPrefix(t, key)
+	return ok
+}
+
+// findKey returns the line of a key = value assignment in the table
+func (d *Document) findKey(t *table, key string) (int, bool) {
+	line, ok := d.findKeyPrefix(t, key)
+	if !ok {
+		return 0, false
+	}
+	rest := strings.TrimPrefix(strings.TrimSpace(d.lines[line]), rawKeyOf(d.lines[line]))
+	if strings.HasPrefix(strings.TrimSpace(rest), ".") {
+		return 0, false
+	}
+	return line, true
+}
+
+// findKeyPrefix returns the line of an assignment to key or to a dotted key starting with key
+func (d *Document) findKeyPrefix(t *table, key string) (int, bool) {
+	multiline := ""
+	for i := t.header + 1; i < t.end; i++ {
+		line := d.lines[i]
+		wasMultiline := multiline != ""
+		multiline = togglesMultiline(line, multiline)
+		if wasMultiline {
+			continue
+		}
+		if unquoteKey(rawKeyOf(line)) == key {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// lastContentLine is the last line of the table that is not blank or a comment
+func (d *Document) lastContentLine(t *table) int {
+	last := t.header
+	for i := t.header + 1; i < t.end; i++ {
+		trimmed := strings.TrimSpace(d.lines[i])
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			last = i
+		}
+	}
+	return last
+}
+
+func (d *Document) tables() []*table {
+	tables := []*table{}
+	multiline := ""
+	for i, line := range d.lines {
+		wasMultiline := multiline != ""
+		multiline = togglesMultiline(line, multiline)
+		if wasMultiline {
+			continue
+		}
+		path, tokens, array, ok := parseHeader(line)
+		if !ok {
+			continue
+		}
+		start := i
+		lowest := 0
+		if len(tables) > 0 {
+			lowest = tables[len(tables)-1].header + 1
+		}
+		for start > lowest && strings.HasPrefix(strings.TrimSpace(d.lines[start-1]), "#") {
+			start--
+		}
+		if len(tables) > 0 {
+			tables[len(tables)-1].end = start
+		}
+		tables = append(tables, &table{path: path, tokens: tokens, array: array, header: i, start: start, end: len(d.lines)})
+	}
+	return tables
+}
+
+// togglesMultiline tracks the delimiter of the multi-line string the line leaves open,
+// the other delimiter is plain text inside the string, e.g. a literal string delimiter in a basic one
+func togglesMultiline(line string, open string) string {
+	for {
+		if open != "" {
+			end := strings.Index(line, open)
+			if end == -1 {
+				return open
+			}
+			line, open = line[end+len(open):], ""
+			continue
+		}
+
+		basic, literal := strings.Index(line, `"""`), strings.Index(line, `'''`)
+		switch {
+		case basic == -1 && literal == -1:
+			return ""
+		case literal == -1 || (basic != -1 && basic < literal):
+			line, open = line[basic+len(`"""`):], `"""`
+		default:
+			line, open = line[literal+len(`'''`):], `'''`
+		}
+	}
+}
+
+func rawKeyOf(line string) string {
+	groups := keyValueRegex.FindStringSubmatch(line)
+	if groups == nil {
+		return ""
+	}
+	return groups[1]
+}
+
+// parseHeader splits a [a.b."c d"] or [[a.b]] header into its unquoted keys
+func parseHeader(line string) ([]string, []string, bool, bool) {
+	trimmed := strings.TrimSpace(line)
+	array := strings.HasPrefix(trimmed, "[[")
+	open, closing := "[", "]"
+	if array {
+		open, closing = "[[", "]]"
+	}
+	if !strings.HasPrefix(trimmed, open) {
+		return nil, nil, false, false
+	}
+	rest := trimmed[len(open):]
+	tokens := []string{}
+	for {
+		rest = strings.TrimLeft(rest, " \t")
+		token, remaining, ok := cutKey(rest)
+		if !ok {
+			return nil, nil, false, false
+		}
+		tokens = append(tokens, token)
+		rest = strings.TrimLeft(remaining, " \t")
+		if strings.HasPrefix(rest, ".") {
+			rest = rest[1:]
+			continue
+		}
+		if !strings.HasPrefix(rest, closing) {
+			return nil, nil, false, false
+		}
+		after := strings.TrimSpace(rest[len(closing):])
+		if after != "" && !strings.HasPrefix(after, "#") {
+			return nil, nil, false, false
+		}
+		break
+	}
+	path := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		path = append(path, unquoteKey(token))
+	}
+	return path, tokens, array, true
+}
+
+func cutKey(s string) (string, string, bool) {
+	if s == "" {
+		return "", "", false
+	}
+	switch s[0] {
+	case '"':
+		for i := 1; i < len(s); i++ {
+			if s[i] == '\\' {
+				i++
+				continue
+			}
+			if s[i] == '"' {
+				return s[:i+1], s[i+1:], true
+			}
+		}
+		return "", "", false
+	case '\'':
+		end := strings.IndexByte(s[1:], '\'')
+		if end == -1 {
+			return "", "", false
+		}
+		return s[:end+2], s[end+2:], true
+	}
+	end := 0
+	for end < len(s) && (bareKeyRegex.MatchString(s[end : end+1])) {
+		end++
+	}
+	if end == 0 {
+		return "", "", false
+	}
+	return s[:end], s[end:], true
+}
+
+func unquoteKey(token string) string {
+	switch {
+	case strings.HasPrefix(token, `"`):
+		if unquoted, err := strconv.Unquote(token); err == nil {
+			return unquoted
+		}
+		return strings.Trim(token, `"`)
+	case strings.HasPrefix(token, `'`):
+		return strings.Trim(token, `'`)
+	}
+	return token
+}
+
+// renameHeader replaces the profile name in the header, the rest of the line is kept as is
+func renameHeader(line string, t *table, name string) string {
+	tokens := slices.Clone(t.tokens)
+	tokens[1] = formatKey(name)
+	open, closing := "[", "]"
+	if t.array {
+		open, closing = "[[", "]]"
+	}
+	indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+	trimmed := strings.TrimSpace(line)
+	comment := ""
+	if idx := strings.LastIndex(trimmed, closing); idx != -1 {
+		comment = trimmed[idx+len(closing):]
+	}
+	return indent + open + strings.Join(tokens, ".") + closing + comment
+}
+
+// replaceValue swaps the value of a key = value line keeping the key formatting and the comment
+func replaceValue(line, literal string) string {
+	eq := strings.Index(line, "=")
+	comment := ""
+	if idx := commentStart(line[eq+1:]); idx != -1 {
+		comment = " " + strings.TrimSpace(line[eq+1+idx:])
+	}
+	return strings.TrimRight(line[:eq+1], " ") + " " + literal + comment
+}
+
+// commentStart finds a # outside of strings
+func commentStart(value string) int {
+	var quote byte
+	for i := 0; i < len(value); i++ {
+		switch {
+		case quote != 0 && value[i] == '\\' && quote == '"':
+			i++
+		case quote != 0 && value[i] == quote:
+			quote = 0
+		case quote == 0 && (value[i] == '"' || value[i] == '\''):
+			quote = value[i]
+		case quote == 0 && value[i] == '#':
+			return i
+		}
+	}
+	return -1
+}
+
+func formatKey(key string) string {
+	if bareKeyRegex.MatchString(key) {
+		return key
+	}
+	return formatString(key)
+}
+
+func formatPath(path []string) string {
+	formatted := make([]string, 0, len(path))
+	for _, key := range path {
+		formatted = append(formatted, formatKey(key))
+	}
+	return strings.Join(formatted, ".")
+}
+
+func formatValue(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return formatString(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return "", fmt.Errorf("unsupported value type %T", value)
+}
+
+// formatString writes a TOML basic string
+func formatString(s string) string {
+	var out strings.Builder
+	out.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			out.WriteByte('\\')
+			out.WriteRune(r)
+		case r == '\n':
+			out.WriteString(`\n`)
+		case r == '\t':
+			out.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&out, `\u%04X`, r)
+		default:
+			out.WriteRune(r)
+		}
+	}
+	out.WriteByte('"')
+	return out.String()
+}
+
+func trimTrailingBlank(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,283 @@
+package configedit_test
+
+import (
+	"testing"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/configedit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sample = `[general]
+destination = "$HOME/.config/hypr/monitors.conf"
+
+# laptop only
+[profiles.laptop]
+config_file = "hyprconfigs/laptop.conf" # static
+post_apply_exec = """
+[profiles.fake]
+notify-send laptop
+"""
+
+[profiles.laptop.conditions]
+power_state = "BAT"  # on the go
+
+[[profiles.laptop.conditions.required_monitors]]
+name = "eDP-1"
+
+# docked setup
+[profiles."desk setup"]
+config_file = "hyprconfigs/desk.go.tmpl"
+config_file_type = "template"
+
+[[profiles."desk setup".conditions.required_monitors]]
+description = "DELL"
+
+[fallback_profile]
+config_file = "hyprconfigs/fallback.conf"
+`
+
+func TestDocument_ProfileNames(t *testing.T) {
+	doc := configedit.Parse([]byte(sample))
+	assert.Equal(t, []string{"laptop", "desk setup"}, doc.ProfileNames())
+	assert.Equal(t, sample, string(doc.Bytes()))
+}
+
+func TestDocument_Profile(t *testing.T) {
+	doc := configedit.Parse([]byte(sample))
+	profile, err := doc.Profile("desk setup")
+	require.NoError(t, err)
+	assert.Equal(t, `# docked setup
+[profiles."desk setup"]
+config_file = "hyprconfigs/desk.go.tmpl"
+config_file_type = "template"
+
+[[profiles."desk setup".conditions.required_monitors]]
+description = "DELL"
+`, profile)
+
+	_, err = doc.Profile("missing")
+	assert.ErrorIs(t, err, configedit.ErrNotFound)
+}
+
+func TestDocument_RenameProfile(t *testing.T) {
+	doc := configedit.Parse([]byte(sample))
+	require.NoError(t, doc.RenameProfile("laptop", "mobile"))
+	require.NoError(t, doc.RenameProfile("desk setup", "desk"))
+
+	assert.Equal(t, []string{"mobile", "desk"}, doc.ProfileNames())
+	assert.Contains(t, string(doc.Bytes()), "# laptop only\n[profiles.mobile]\n")
+	assert.Contains(t, string(doc.Bytes()), "[[profiles.mobile.conditions.required_monitors]]\n")
+	assert.Contains(t, string(doc.Bytes()), "[[profiles.desk.conditions.required_monitors]]\n")
+	assert.Contains(t, string(doc.Bytes()), "[profiles.fake]\nnotify-send laptop\n")
+
+	assert.ErrorIs(t, doc.RenameProfile("mobile", "desk"), configedit.ErrExists)
+	assert.ErrorIs(t, doc.RenameProfile("laptop", "other"), configedit.ErrNotFound)
+}
+
+func TestDocument_DuplicateProfile(t *testing.T) {
+	doc := configedit.Parse([]byte(sample))
+	require.NoError(t, doc.DuplicateProfile("desk setup", "desk copy"))
+
+	assert.Equal(t, []string{"laptop", "desk setup", "desk copy"}, doc.ProfileNames())
+	copied, err := doc.Profile("desk copy")
+	require.NoError(t, err)
+	assert.Equal(t, `# docked setup
+[profiles."desk copy"]
+config_file = "hyprconfigs/desk.go.tmpl"
+config_file_type = "template"
+
+[[profiles."desk copy".conditions.required_monitors]]
+description = "DELL"
+`, copied)
+	assert.Contains(t, string(doc.Bytes()), "description = \"DELL\"\n\n[fallback_profile]\n")
+}
+
+func TestDocument_DeleteProfile(t *testing.T) {
+	doc := configedit.Parse([]byte(sample))
+	require.NoError(t, doc.DeleteProfile("laptop"))
+
+	assert.Equal(t, []string{"desk setup"}, doc.ProfileNames())
+	assert.Equal(t, `[general]
+destination = "$HOME/.config/hypr/monitors.conf"
+
+# docked setup
+[profiles."desk setup"]
+config_file = "hyprconfigs/desk.go.tmpl"
+config_file_type = "template"
+
+[[profiles."desk setup".conditions.required_monitors]]
+description = "DELL"
+
+[fallback_profile]
+config_file = "hyprconfigs/fallback.conf"
+`, string(doc.Bytes()))
+}
+
+const mixedDelimiters = `[profiles.laptop]
+config_file = "hyprconfigs/laptop.conf"
+pre_apply_exec = """
+echo '''
+[profiles.fake]
+"""
+post_apply_exec = '''
+notify-send """done"""
+[profiles.other]
+'''
+
+[profiles.desk]
+config_file = "hyprconfigs/desk.conf"
+`
+
+func TestDocument_MixedMultilineDelimiters(t *testing.T) {
+	doc := configedit.Parse([]byte(mixedDelimiters))
+	assert.Equal(t, []string{"laptop", "desk"}, doc.ProfileNames())
+
+	require.NoError(t, doc.RenameProfile("desk", "docked"))
+	assert.Contains(t, string(doc.Bytes()), "echo '''\n[profiles.fake]\n")
+	assert.Contains(t, string(doc.Bytes()), "notify-send \"\"\"done\"\"\"\n[profiles.other]\n")
+	assert.Contains(t, string(doc.Bytes()), "\n[profiles.docked]\n")
+
+	require.NoError(t, doc.DeleteProfile("laptop"))
+	assert.Equal(t, `[profiles.docked]
+config_file = "hyprconfigs/desk.conf"
+`, string(doc.Bytes()))
+}
+
+func TestDocument_SetProfileValue(t *testing.T) {
+	doc := configedit.Parse([]byte(sample))
+
+	require.NoError(t, doc.SetProfileValue("laptop", []string{"conditions"}, "power_state", "AC"))
+	require.NoError(t, doc.SetProfileValue("laptop", []string{"conditions"}, "battery_below", 20))
+	require.NoError(t, doc.SetProfileValue("desk setup", []string{"conditions"}, "lid_state", "Closed"))
+	require.NoError(t, doc.SetProfileValue("desk setup", nil, "config_file", "hyprconfigs/other.go.tmpl"))
+	assert.Contains(t, string(doc.Bytes()), `[profiles.laptop.conditions]
+power_state = "AC" # on the go
+battery_below = 20
+
+[[profiles.laptop.conditions.required_monitors]]`)
+	assert.Contains(t, string(doc.Bytes()), `[profiles."desk setup"]
+config_file = "hyprconfigs/other.go.tmpl"
+config_file_type = "template"
+
+[profiles."desk setup".conditions]
+lid_state = "Closed"
+
+[[profiles."desk setup".conditions.required_monitors]]`)
+
+	require.NoError(t, doc.SetProfileValue("laptop", []string{"conditions"}, "power_state", nil))
+	require.NoError(t, doc.SetProfileValue("laptop", []string{"conditions"}, "power_profile", nil))
+	assert.Contains(t, string(doc.Bytes()), "[profiles.laptop.conditions]\nbattery_below = 20\n")
+}
+
+func TestDocument_Unsupported(t *testing.T) {
+	doc := configedit.Parse([]byte(`[profiles]
+laptop.config_file = "laptop.conf"
+
+[profiles.desk]
+config_file = "desk.conf"
+conditions.power_state = "AC"
+`))
+	assert.ErrorIs(t, doc.RenameProfile("laptop", "other"), configedit.ErrUnsupported)
+	assert.ErrorIs(t, doc.SetProfileValue("desk", []string{"conditions"}, "lid_state", "Closed"),
+		configedit.ErrUnsupported)
+}
+
+func TestDocument_SetValue(t *testing.T) {
+	doc := configedit.Parse([]byte(sample))
+	require.NoError(t, doc.SetValue([]string{"tui", "colors"}, "source", "themes/nord.toml"))
+	assert.Equal(t, sample+`
+[tui.colors]
+source = "themes/nord.toml"
+`, string(doc.Bytes()))
+
+	require.NoError(t, doc.SetValue([]string{"tui", "colors"}, "source", "themes/dracula.toml"))
+	require.NoError(t, doc.SetValue([]string{"general"}, "debounce_time_ms", 1500))
+	assert.Contains(t, string(doc.Bytes()), `[general]
+destination = "$HOME/.config/hypr/monitors.conf"
+debounce_time_ms = 1500
+`)
+	assert.Contains(t, string(doc.Bytes()), "[tui.colors]\nsource = \"themes/dracula.toml\"\n")
+
+	require.NoError(t, doc.SetValue([]string{"tui", "colors"}, "source", nil))
+	assert.Contains(t, string(doc.Bytes()), "[tui.colors]\n")
+	assert.NotContains(t, string(doc.Bytes()), "source")
+
+	inline := configedit.Parse([]byte(`[tui]
+colors = { source = "themes/nord.toml" }
+`))
+	assert.ErrorIs(t, inline.SetValue([]string{"tui", "colors"}, "source", "themes/dracula.toml"),
+		configedit.ErrInline)
+}
+
+func TestDocument_SetProfileArray(t *testing.T) {
+	doc := configedit.Parse([]byte(sample))
+	requiredMonitors := []string{"conditions", "required_monitors"}
+
+	require.NoError(t, doc.SetProfileArray("laptop", requiredMonitors, [][]configedit.Field{
+		{{Key: "name", Value: "eDP-1"}, {Key: "monitor_tag", Value: "laptop"}},
+		{{Key: "description", Value: "^DELL.*"}, {Key: "match_description_using_regex", Value: true}},
+	}))
+	assert.Contains(t, string(doc.Bytes()), `power_state = "BAT"  # on the go
+
+[[profiles.laptop.conditions.required_monitors]]
+name = "eDP-1"
+monitor_tag = "laptop"
+
+[[profiles.laptop.conditions.required_monitors]]
+description = "^DELL.*"
+match_description_using_regex = true
+
+# docked setup
+`)
+
+	require.NoError(t, doc.SetProfileArray("laptop", requiredMonitors, nil))
+	assert.Contains(t, string(doc.Bytes()), "power_state = \"BAT\"  # on the go\n\n# docked setup\n")
+
+	require.NoError(t, doc.SetProfileArray("laptop", requiredMonitors, [][]configedit.Field{
+		{{Key: "name", Value: "eDP-1"}},
+	}))
+	assert.Contains(t, string(doc.Bytes()), `power_state = "BAT"  # on the go
+
+[[profiles.laptop.conditions.required_monitors]]
+name = "eDP-1"
+
+# docked setup
+`)
+
+	require.NoError(t, doc.SetProfileArray("desk setup", requiredMonitors, [][]configedit.Field{
+		{{Key: "description", Value: "DELL U2723QE"}},
+	}))
+	assert.Contains(t, string(doc.Bytes()), `[[profiles."desk setup".conditions.required_monitors]]
+description = "DELL U2723QE"
+
+[fallback_profile]
+`)
+
+	missing := configedit.Parse([]byte(`[profiles.desk]
+config_file = "desk.conf"
+
+[profiles.desk.conditions]
+power_state = "AC"
+`))
+	require.NoError(t, missing.SetProfileArray("desk", requiredMonitors, [][]configedit.Field{
+		{{Key: "name", Value: "DP-1"}},
+	}))
+	assert.Equal(t, `[profiles.desk]
+config_file = "desk.conf"
+
+[profiles.desk.conditions]
+power_state = "AC"
+
+[[profiles.desk.conditions.required_monitors]]
+name = "DP-1"
+`, string(missing.Bytes()))
+
+	inline := configedit.Parse([]byte(`[profiles.desk]
+config_file = "desk.conf"
+
+[profiles.desk.conditions]
+required_monitors = [{ name = "DP-1" }]
+`))
+	assert.ErrorIs(t, inline.SetProfileArray("desk", requiredMonitors, nil), configedit.ErrUnsupported)
+}
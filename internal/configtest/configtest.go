@@ -0,0 +1,296 @@
+// Package configtest runs user-defined test cases against a configuration, each case
+// describes the connected monitors and power/lid state and the expected profile and output
+package configtest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/aymanbagabas/go-udiff"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/generators"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/hypr"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/matchers"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/power"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+)
+
+// DefaultFileName is looked up next to the configuration file when no suite is passed
+const DefaultFileName = "tests.toml"
+
+// ErrCasesFailed is returned by Run when at least one case did not pass
+var ErrCasesFailed = errors.New("test cases failed")
+
+// Suite is the list of test cases, paths in cases are relative to the suite file
+type Suite struct {
+	Cases []*Case `toml:"case"`
+	dir   string
+}
+
+// Case is one input world and what the configuration is expected to do with it
+type Case struct {
+	Name                   string                   `toml:"name"`
+	Monitors               []*Monitor               `toml:"monitors"`
+	MonitorsFile           string                   `toml:"monitors_file"`
+	PowerState             *config.PowerStateType   `toml:"power_state"`
+	LidState               *config.LidStateType     `toml:"lid_state"`
+	BatteryPercentage      *float64                 `toml:"battery_percentage"`
+	PowerProfile           *config.PowerProfileType `toml:"power_profile"`
+	ExpectedProfile        *string                  `toml:"expected_profile"`
+	ExpectedOutputFile     string                   `toml:"expected_output_file"`
+	ExpectedOutputContains []string                 `toml:"expected_output_contains"`
+
+	monitors hypr.MonitorSpecs
+}
+
+// Monitor is the inline form of a hyprctl monitors entry
+type Monitor struct {
+	ID             *int     `toml:"id"`
+	Name           string   `toml:"name"`
+	Description    string   `toml:"description"`
+	Disabled       bool     `toml:"disabled"`
+	Width          int      `toml:"width"`
+	Height         int      `toml:"height"`
+	RefreshRate    float64  `toml:"refresh_rate"`
+	Transform      int      `toml:"transform"`
+	Vrr            bool     `toml:"vrr"`
+	Scale          float64  `toml:"scale"`
+	X              int      `toml:"x"`
+	Y              int      `toml:"y"`
+	AvailableModes []string `toml:"available_modes"`
+	Mirror         string   `toml:"mirror_of"`
+	CurrentFormat  string   `toml:"current_format"`
+}
+
+func (m *Monitor) toSpec(index int) *hypr.MonitorSpec {
+	id := m.ID
+	if id == nil {
+		id = utils.IntPtr(index)
+	}
+	scale := m.Scale
+	if scale == 0 {
+		scale = 1
+	}
+	return &hypr.MonitorSpec{
+		ID: id, Name: m.Name, Description: m.Description, Disabled: m.Disabled,
+		Width: m.Width, Height: m.Height, RefreshRate: m.RefreshRate, Transform: m.Transform,
+		Vrr: m.Vrr, Scale: scale, X: m.X, Y: m.Y, AvailableModes: m.AvailableModes,
+		Mirror: m.Mirror, CurrentFormat: m.CurrentFormat,
+	}
+}
+
+// LoadSuite reads and validates a test suite file
+func LoadSuite(path string) (*Suite, error) {
+	suite := &Suite{dir: filepath.Dir(path)}
+	if _, err := toml.DecodeFile(path, suite); err != nil {
+		return nil, fmt.Errorf("cant parse test suite %s: %w", path, err)
+	}
+	if err := suite.Validate(); err != nil {
+		return nil, fmt.Errorf("test suite %s is invalid: %w", path, err)
+	}
+	return suite, nil
+}
+
+func (s *Suite) Validate() error {
+	if len(s.Cases) == 0 {
+		return errors.New("at least one [[case]] is required")
+	}
+	names := map[string]bool{}
+	for i, c := range s.Cases {
+		if c.Name == "" {
+			return fmt.Errorf("case %d: name is required", i+1)
+		}
+		if names[c.Name] {
+			return fmt.Errorf("case %s: duplicate name", c.Name)
+		}
+		names[c.Name] = true
+		if err := c.Validate(s.dir); err != nil {
+			return fmt.Errorf("case %s: %w", c.Name, err)
+		}
+	}
+	return nil
+}
+
+func (c *Case) Validate(dir string) error {
+	if c.ExpectedProfile == nil {
+		return errors.New("expected_profile is required, use an empty string when no profile should match")
+	}
+	if len(c.Monitors) > 0 && c.MonitorsFile != "" {
+		return errors.New("only one of monitors and monitors_file can be set")
+	}
+	if c.PowerProfile != nil && c.BatteryPercentage == nil {
+		return errors.New("power_profile requires battery_percentage")
+	}
+	if c.BatteryPercentage != nil && (*c.BatteryPercentage < 0 || *c.BatteryPercentage > 100) {
+		return fmt.Errorf("battery_percentage %v needs to be within [0, 100]", *c.BatteryPercentage)
+	}
+
+	if c.MonitorsFile != "" {
+		//nolint:gosec
+		contents, err := os.ReadFile(resolve(dir, c.MonitorsFile))
+		if err != nil {
+			return fmt.Errorf("cant read monitors file: %w", err)
+		}
+		if err := utils.UnmarshalResponse(contents, &c.monitors); err != nil {
+			return fmt.Errorf("cant parse monitors file: %w", err)
+		}
+	}
+	for i, monitor := range c.Monitors {
+		c.monitors = append(c.monitors, monitor.toSpec(i))
+	}
+	if err := c.monitors.Validate(); err != nil {
+		return fmt.Errorf("invalid monitors: %w", err)
+	}
+	return nil
+}
+
+func (c *Case) powerState() power.PowerState {
+	if c.PowerState != nil && *c.PowerState == config.BAT {
+		return power.BatteryPowerState
+	}
+	return power.ACPowerState
+}
+
+func (c *Case) lidState() power.LidState {
+	if c.LidState == nil {
+		return power.UnknownLidState
+	}
+	switch *c.LidState {
+	case config.OpenedLidStateType:
+		return power.OpenedLidState
+	case config.ClosedLidStateType:
+		return power.ClosedLidState
+	default:
+		return power.UnknownLidState
+	}
+}
+
+func (c *Case) batteryState() power.BatteryState {
+	if c.BatteryPercentage == nil {
+		return power.BatteryState{}
+	}
+	profile := power.UnknownPowerProfile
+	if c.PowerProfile != nil {
+		profile, _ = power.ParsePowerProfile(c.PowerProfile.Value())
+	}
+	return power.NewBatteryState(*c.BatteryPercentage, profile)
+}
+
+func resolve(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// Runner evaluates cases through the matcher and generator without touching the destination
+type Runner struct {
+	cfg       *config.Config
+	matcher   *matchers.Matcher
+	generator *generators.ConfigGenerator
+	update    bool
+}
+
+// NewRunner creates a runner, with update the expected output files are rewritten instead of compared
+func NewRunner(cfg *config.Config, update bool) (*Runner, error) {
+	generator, err := generators.NewConfigGenerator(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("cant create config generator: %w", err)
+	}
+	return &Runner{cfg: cfg, matcher: matchers.NewMatcher(), generator: generator, update: update}, nil
+}
+
+// Run evaluates every case and writes a report to out, ErrCasesFailed is returned if any case failed
+func (r *Runner) Run(suite *Suite, out io.Writer) error {
+	failed := 0
+	for _, c := range suite.Cases {
+		failures := r.runCase(suite.dir, c)
+		if len(failures) == 0 {
+			_, _ = fmt.Fprintf(out, "--- PASS: %s\n", c.Name)
+			continue
+		}
+		failed++
+		_, _ = fmt.Fprintf(out, "--- FAIL: %s\n", c.Name)
+		for _, failure := range failures {
+			_, _ = fmt.Fprintln(out, indent(failure))
+		}
+	}
+
+	if failed > 0 {
+		_, _ = fmt.Fprintf(out, "FAIL: %d of %d cases failed\n", failed, len(suite.Cases))
+		return fmt.Errorf("%w: %d of %d", ErrCasesFailed, failed, len(suite.Cases))
+	}
+	_, _ = fmt.Fprintf(out, "PASS: %d cases\n", len(suite.Cases))
+	return nil
+}
+
+func (r *Runner) runCase(dir string, c *Case) []string {
+	cfg := r.cfg.Get()
+	powerState, lidState, batteryState := c.powerState(), c.lidState(), c.batteryState()
+
+	found, matched, err := r.matcher.Match(cfg, c.monitors, powerState, lidState, batteryState)
+	if err != nil {
+		return []string{fmt.Sprintf("cant match a profile: %v", err)}
+	}
+	actualProfile := ""
+	if found {
+		actualProfile = matched.Profile.Name
+	}
+	if actualProfile != *c.ExpectedProfile {
+		return []string{fmt.Sprintf("expected profile %q, got %q", *c.ExpectedProfile, actualProfile)}
+	}
+
+	if !found || (c.ExpectedOutputFile == "" && len(c.ExpectedOutputContains) == 0) {
+		return nil
+	}
+
+	rendered, err := r.generator.Render(cfg, matched, c.monitors, powerState, lidState, batteryState)
+	if err != nil {
+		return []string{fmt.Sprintf("cant render profile %s: %v", actualProfile, err)}
+	}
+
+	failures := []string{}
+	if c.ExpectedOutputFile != "" {
+		if failure := r.compareGolden(resolve(dir, c.ExpectedOutputFile), rendered); failure != "" {
+			failures = append(failures, failure)
+		}
+	}
+	for _, substring := range c.ExpectedOutputContains {
+		if !strings.Contains(string(rendered), substring) {
+			failures = append(failures, fmt.Sprintf("expected the output to contain %q", substring))
+		}
+	}
+	return failures
+}
+
+func (r *Runner) compareGolden(path string, rendered []byte) string {
+	if r.update {
+		if err := utils.WriteAtomic(path, rendered); err != nil {
+			return fmt.Sprintf("cant update %s: %v", path, err)
+		}
+		return ""
+	}
+
+	//nolint:gosec
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Sprintf("cant read expected output, run with --update to create it: %v", err)
+	}
+	if string(expected) == string(rendered) {
+		return ""
+	}
+	return "output differs from " + path + ":\n" + udiff.Unified("expected", "actual", string(expected), string(rendered))
+}
+
+func indent(text string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = "    " + line
+	}
+	return strings.Join(lines, "\n")
+}
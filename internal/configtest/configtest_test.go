@@ -0,0 +1,210 @@
+package configtest_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/configtest"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/testutils"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const template = `{{- range .Monitors}}
+monitor={{.Name}},preferred,auto,1
+{{- end}}
+{{- if isOnBattery}}
+misc:vfr=true
+{{- end}}
+`
+
+func testConfig(t *testing.T) *config.Config {
+	templateFile := filepath.Join(t.TempDir(), "docked.go.tmpl")
+	// nolint:gosec
+	require.NoError(t, os.WriteFile(templateFile, []byte(template), 0o644))
+
+	return testutils.NewTestConfig(t).WithProfiles(map[string]*config.Profile{
+		"laptop": {
+			Name: "laptop",
+			Conditions: &config.ProfileCondition{
+				RequiredMonitors: []*config.RequiredMonitor{{Name: utils.StringPtr("eDP-1")}},
+			},
+		},
+		"docked": {
+			Name:       "docked",
+			ConfigType: utils.JustPtr(config.Template),
+			Conditions: &config.ProfileCondition{
+				RequiredMonitors: []*config.RequiredMonitor{
+					{Name: utils.StringPtr("eDP-1")},
+					{Description: utils.StringPtr("LG Electronics LG SDQHD")},
+				},
+			},
+		},
+	}).FillProfileConfigFile("docked", templateFile).Get()
+}
+
+func writeSuite(t *testing.T, dir, contents string) string {
+	path := filepath.Join(dir, configtest.DefaultFileName)
+	// nolint:gosec
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+	return path
+}
+
+const suite = `
+[[case]]
+name = "laptop only"
+expected_profile = "laptop"
+[[case.monitors]]
+name = "eDP-1"
+description = "BOE NE135A1M-NY1"
+
+[[case]]
+name = "docked on battery"
+power_state = "BAT"
+expected_profile = "docked"
+expected_output_file = "docked.conf"
+expected_output_contains = ["monitor=DP-1", "misc:vfr=true"]
+[[case.monitors]]
+name = "eDP-1"
+description = "BOE NE135A1M-NY1"
+[[case.monitors]]
+name = "DP-1"
+description = "LG Electronics LG SDQHD"
+
+[[case]]
+name = "nothing matches"
+expected_profile = ""
+[[case.monitors]]
+name = "HDMI-A-1"
+description = "Unknown"
+`
+
+func TestRunner_Run(t *testing.T) {
+	dir := t.TempDir()
+	path := writeSuite(t, dir, suite)
+	// nolint:gosec
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "docked.conf"),
+		[]byte("\nmonitor=eDP-1,preferred,auto,1\nmonitor=DP-1,preferred,auto,1\nmisc:vfr=true\n"), 0o644))
+
+	loaded, err := configtest.LoadSuite(path)
+	require.NoError(t, err)
+	runner, err := configtest.NewRunner(testConfig(t), false)
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, runner.Run(loaded, out), out.String())
+	assert.Equal(t, "--- PASS: laptop only\n--- PASS: docked on battery\n--- PASS: nothing matches\nPASS: 3 cases\n",
+		out.String())
+}
+
+func TestRunner_Run_Failures(t *testing.T) {
+	dir := t.TempDir()
+	path := writeSuite(t, dir, `
+[[case]]
+name = "wrong profile"
+expected_profile = "docked"
+[[case.monitors]]
+name = "eDP-1"
+
+[[case]]
+name = "wrong output"
+expected_profile = "docked"
+expected_output_file = "docked.conf"
+expected_output_contains = ["misc:vfr=true"]
+[[case.monitors]]
+name = "eDP-1"
+[[case.monitors]]
+name = "DP-1"
+description = "LG Electronics LG SDQHD"
+`)
+	// nolint:gosec
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "docked.conf"), []byte("\nmonitor=eDP-1,preferred,auto,1\n"), 0o644))
+
+	loaded, err := configtest.LoadSuite(path)
+	require.NoError(t, err)
+	runner, err := configtest.NewRunner(testConfig(t), false)
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	err = runner.Run(loaded, out)
+	require.ErrorIs(t, err, configtest.ErrCasesFailed)
+	assert.Contains(t, out.String(), "--- FAIL: wrong profile\n    expected profile \"docked\", got \"laptop\"")
+	assert.Contains(t, out.String(), "--- FAIL: wrong output\n    output differs from")
+	assert.Contains(t, out.String(), "+monitor=DP-1,preferred,auto,1")
+	assert.Contains(t, out.String(), "expected the output to contain \"misc:vfr=true\"")
+	assert.Contains(t, out.String(), "FAIL: 2 of 2 cases failed")
+}
+
+func TestRunner_Run_Update(t *testing.T) {
+	dir := t.TempDir()
+	path := writeSuite(t, dir, `
+[[case]]
+name = "docked"
+expected_profile = "docked"
+expected_output_file = "golden/docked.conf"
+[[case.monitors]]
+name = "eDP-1"
+[[case.monitors]]
+name = "DP-1"
+description = "LG Electronics LG SDQHD"
+`)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "golden"), 0o750))
+
+	loaded, err := configtest.LoadSuite(path)
+	require.NoError(t, err)
+	runner, err := configtest.NewRunner(testConfig(t), true)
+	require.NoError(t, err)
+	require.NoError(t, runner.Run(loaded, &bytes.Buffer{}))
+
+	//nolint:gosec
+	contents, err := os.ReadFile(filepath.Join(dir, "golden", "docked.conf"))
+	require.NoError(t, err)
+	assert.Equal(t, "\nmonitor=eDP-1,preferred,auto,1\nmonitor=DP-1,preferred,auto,1\n", string(contents))
+}
+
+func TestLoadSuite_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		suite  string
+		errMsg string
+	}{
+		{name: "empty", suite: ``, errMsg: "at least one [[case]] is required"},
+		{
+			name:   "missing expected profile",
+			suite:  "[[case]]\nname = \"a\"\n[[case.monitors]]\nname = \"eDP-1\"\n",
+			errMsg: "expected_profile is required",
+		},
+		{
+			name:   "duplicate names",
+			suite:  "[[case]]\nname = \"a\"\nexpected_profile = \"\"\n[[case.monitors]]\nname = \"eDP-1\"\n[[case]]\nname = \"a\"\nexpected_profile = \"\"\n",
+			errMsg: "duplicate name",
+		},
+		{
+			name:   "no monitors",
+			suite:  "[[case]]\nname = \"a\"\nexpected_profile = \"\"\n",
+			errMsg: "no monitors detected",
+		},
+		{
+			name:   "both monitors and a file",
+			suite:  "[[case]]\nname = \"a\"\nexpected_profile = \"\"\nmonitors_file = \"m.json\"\n[[case.monitors]]\nname = \"eDP-1\"\n",
+			errMsg: "only one of monitors and monitors_file",
+		},
+		{
+			name:   "invalid power state",
+			suite:  "[[case]]\nname = \"a\"\nexpected_profile = \"\"\npower_state = \"DC\"\n",
+			errMsg: "cant parse test suite",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := configtest.LoadSuite(writeSuite(t, t.TempDir(), tt.suite))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
@@ -0,0 +1,395 @@
+// Package doctor diagnoses the environment the daemon runs in, every check reports
+// whether it passed together with a remediation hint when it did not
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/generators"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	instanceSignatureEnv = "HYPRLAND_INSTANCE_SIGNATURE"
+	queryTimeout         = 5 * time.Second
+)
+
+// ErrChecksFailed is returned by Run when at least one check failed
+var ErrChecksFailed = errors.New("doctor checks failed")
+
+type Status int
+
+const (
+	PassStatus Status = iota
+	WarnStatus
+	FailStatus
+	SkipStatus
+)
+
+func (s Status) String() string {
+	switch s {
+	case PassStatus:
+		return "PASS"
+	case WarnStatus:
+		return "WARN"
+	case FailStatus:
+		return "FAIL"
+	case SkipStatus:
+		return "SKIP"
+	}
+	return "UNKNOWN"
+}
+
+// Result is the outcome of a single check
+type Result struct {
+	Name        string
+	Status      Status
+	Detail      string
+	Remediation string
+}
+
+// Options select the files and the bus the checks are run against
+type Options struct {
+	ConfigPath          string
+	HyprlandConfigPath  string
+	ConnectToSessionBus bool
+}
+
+type Doctor struct {
+	opts         Options
+	getenv       func(string) string
+	connectBus   func(session bool) (*dbus.Conn, error)
+	getPowerLine func() (*string, error)
+	runCommand   func(ctx context.Context, name string, args ...string) (string, error)
+}
+
+func NewDoctor(opts Options) *Doctor {
+	return &Doctor{
+		opts:         opts,
+		getenv:       os.Getenv,
+		connectBus:   connectBus,
+		getPowerLine: utils.GetPowerLine,
+		runCommand:   utils.RunCommand,
+	}
+}
+
+func connectBus(session bool) (*dbus.Conn, error) {
+	if session {
+		return dbus.ConnectSessionBus()
+	}
+	return dbus.ConnectSystemBus()
+}
+
+// Run executes every check, checks that depend on a valid config are skipped without one
+func (d *Doctor) Run(ctx context.Context, out io.Writer) error {
+	results := []Result{}
+	cfg, result := d.checkConfig()
+	results = append(results, result)
+
+	if cfg != nil {
+		raw := cfg.Get()
+		results = append(results, d.checkDestination(raw))
+		results = append(results, d.checkDestinationSourced(raw))
+	} else {
+		results = append(results,
+			skipped("destination", "the config is not valid"),
+			skipped("destination sourced", "the config is not valid"))
+	}
+
+	results = append(results, d.checkDbus(ctx, cfg)...)
+	results = append(results, d.checkHyprland(ctx)...)
+
+	return Print(out, results)
+}
+
+// Print writes the results with their remediation, it returns ErrChecksFailed when any check failed
+func Print(out io.Writer, results []Result) error {
+	counts := map[Status]int{}
+	for _, result := range results {
+		counts[result.Status]++
+		_, _ = fmt.Fprintf(out, "[%s] %s: %s\n", result.Status, result.Name, result.Detail)
+		if result.Remediation != "" && (result.Status == FailStatus || result.Status == WarnStatus) {
+			for _, line := range strings.Split(result.Remediation, "\n") {
+				_, _ = fmt.Fprintf(out, "       %s\n", line)
+			}
+		}
+	}
+	_, _ = fmt.Fprintf(out, "\n%d passed, %d warnings, %d failed, %d skipped\n",
+		counts[PassStatus], counts[WarnStatus], counts[FailStatus], counts[SkipStatus])
+	if counts[FailStatus] > 0 {
+		return ErrChecksFailed
+	}
+	return nil
+}
+
+func skipped(name, reason string) Result {
+	return Result{Name: name, Status: SkipStatus, Detail: reason}
+}
+
+func (d *Doctor) checkConfig() (*config.Config, Result) {
+	name := "config"
+	cfg, err := config.NewConfig(d.opts.ConfigPath)
+	if err != nil {
+		return nil, Result{
+			Name: name, Status: FailStatus, Detail: err.Error(),
+			Remediation: "Run `hyprdynamicmonitors validate` and fix the reported errors",
+		}
+	}
+	if _, err := generators.NewConfigGenerator(cfg); err != nil {
+		return nil, Result{
+			Name: name, Status: FailStatus, Detail: err.Error(),
+			Remediation: "Fix the template syntax, `hyprdynamicmonitors validate` renders every template",
+		}
+	}
+	raw := cfg.Get()
+	return cfg, Result{
+		Name: name, Status: PassStatus,
+		Detail: fmt.Sprintf("%s is valid, %d profiles", raw.ConfigPath, len(raw.Profiles)),
+	}
+}
+
+// checkDestination verifies the destination can be written and is not a symlink to a removed file
+func (d *Doctor) checkDestination(cfg *config.RawConfig) Result {
+	name := "destination"
+	destination := *cfg.General.Destination
+	dir := parentDir(destination)
+
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return Result{
+			Name: name, Status: FailStatus, Detail: fmt.Sprintf("directory %s does not exist", dir),
+			Remediation: "Create it: mkdir -p " + dir,
+		}
+	}
+	if !writable(dir) {
+		return Result{
+			Name: name, Status: FailStatus, Detail: fmt.Sprintf("directory %s is not writable", dir),
+			Remediation: "The destination is replaced atomically, the directory needs to be writable: chmod u+w " + dir,
+		}
+	}
+
+	linkInfo, err := os.Lstat(destination)
+	if errors.Is(err, os.ErrNotExist) {
+		return Result{
+			Name: name, Status: PassStatus,
+			Detail: destination + " does not exist yet, it is created when a profile is applied",
+		}
+	}
+	if err != nil {
+		return Result{Name: name, Status: FailStatus, Detail: err.Error()}
+	}
+
+	if linkInfo.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(destination)
+		if err != nil {
+			return Result{Name: name, Status: FailStatus, Detail: err.Error()}
+		}
+		if _, err := os.Stat(destination); err != nil {
+			return Result{
+				Name: name, Status: FailStatus,
+				Detail:      fmt.Sprintf("%s is a stale symlink to %s", destination, target),
+				Remediation: "Remove it, the daemon links or renders the matching profile again: rm " + destination,
+			}
+		}
+		if !isProfileFile(cfg, target) {
+			return Result{
+				Name: name, Status: WarnStatus,
+				Detail:      fmt.Sprintf("%s links to %s which is not a profile config file", destination, target),
+				Remediation: "The link was not created by the current config, it is replaced when a profile is applied",
+			}
+		}
+		return Result{Name: name, Status: PassStatus, Detail: fmt.Sprintf("%s links to %s", destination, target)}
+	}
+
+	if !linkInfo.Mode().IsRegular() {
+		return Result{
+			Name: name, Status: FailStatus, Detail: destination + " is not a regular file",
+			Remediation: "Point general.destination to a file",
+		}
+	}
+	if !readable(destination) {
+		return Result{
+			Name: name, Status: FailStatus, Detail: destination + " is not readable",
+			Remediation: "Fix the permissions: chmod u+rw " + destination,
+		}
+	}
+	if !writable(destination) {
+		return Result{
+			Name: name, Status: FailStatus, Detail: destination + " is not writable",
+			Remediation: "Fix the permissions: chmod u+rw " + destination,
+		}
+	}
+	return Result{Name: name, Status: PassStatus, Detail: destination + " is writable"}
+}
+
+func isProfileFile(cfg *config.RawConfig, path string) bool {
+	for _, profile := range cfg.Profiles {
+		if profile.ConfigFile == path {
+			return true
+		}
+	}
+	return cfg.FallbackProfile != nil && cfg.FallbackProfile.ConfigFile == path
+}
+
+// checkDestinationSourced looks for the destination in the source= lines of hyprland.conf
+func (d *Doctor) checkDestinationSourced(cfg *config.RawConfig) Result {
+	name := "destination sourced"
+	destination := *cfg.General.Destination
+	hyprlandConfig := d.hyprlandConfigPath()
+
+	if !readable(hyprlandConfig) {
+		return Result{
+			Name: name, Status: WarnStatus, Detail: "cant read " + hyprlandConfig,
+			Remediation: "Pass the Hyprland config with --hyprland-config",
+		}
+	}
+
+	sourced, err := isSourced(hyprlandConfig, destination, d.getenv)
+	if err != nil {
+		return Result{Name: name, Status: FailStatus, Detail: err.Error()}
+	}
+	if !sourced {
+		return Result{
+			Name: name, Status: FailStatus,
+			Detail:      fmt.Sprintf("%s is not sourced from %s", destination, hyprlandConfig),
+			Remediation: fmt.Sprintf("Add `source = %s` to %s, otherwise the generated config is never loaded", destination, hyprlandConfig),
+		}
+	}
+	return Result{Name: name, Status: PassStatus, Detail: fmt.Sprintf("%s is sourced from %s", destination, hyprlandConfig)}
+}
+
+func (d *Doctor) hyprlandConfigPath() string {
+	if d.opts.HyprlandConfigPath != "" {
+		return expandPath(d.opts.HyprlandConfigPath, "", d.getenv)
+	}
+	if configHome := d.getenv("XDG_CONFIG_HOME"); configHome != "" {
+		return configHome + "/hypr/hyprland.conf"
+	}
+	return d.getenv("HOME") + "/.config/hypr/hyprland.conf"
+}
+
+// checkDbus connects to both buses, only the one the daemon uses is required, and queries
+// the UPower objects the power and lid detection read from
+func (d *Doctor) checkDbus(ctx context.Context, cfg *config.Config) []Result {
+	results := []Result{}
+	var conn *dbus.Conn
+	for _, session := range []bool{false, true} {
+		name := "D-Bus system bus"
+		flag := "without --connect-to-session-bus"
+		if session {
+			name = "D-Bus session bus"
+			flag = "with --connect-to-session-bus"
+		}
+		inUse := session == d.opts.ConnectToSessionBus
+		busConn, err := d.connectBus(session)
+		if err != nil {
+			status := WarnStatus
+			if inUse {
+				status = FailStatus
+			}
+			results = append(results, Result{
+				Name: name, Status: status, Detail: err.Error(),
+				Remediation: "The daemon uses this bus when started " + flag + ", check that dbus is running",
+			})
+			continue
+		}
+		if inUse {
+			conn = busConn
+		} else {
+			_ = busConn.Close()
+		}
+		results = append(results, Result{Name: name, Status: PassStatus, Detail: "reachable"})
+	}
+	if conn != nil {
+		defer func() { _ = conn.Close() }()
+	}
+
+	if cfg == nil {
+		return append(results,
+			skipped("UPower power line", "the config is not valid"),
+			skipped("lid object", "the config is not valid"))
+	}
+	raw := cfg.Get()
+
+	if *raw.PowerEvents.Backend == config.SysfsDetectionBackend {
+		results = append(results, skipped("UPower power line", "power_events.backend is sysfs"))
+	} else {
+		results = append(results, d.checkPowerLine(ctx, conn, raw.PowerEvents.DbusQueryObject))
+	}
+
+	if *raw.LidEvents.Backend == config.SysfsDetectionBackend {
+		results = append(results, skipped("lid object", "lid_events.backend is sysfs"))
+	} else {
+		results = append(results, d.checkLidObject(ctx, conn, raw.LidEvents.DbusQueryObject))
+	}
+	return results
+}
+
+func (d *Doctor) checkPowerLine(ctx context.Context, conn *dbus.Conn, query *config.DbusQueryObject) Result {
+	name := "UPower power line"
+	line, err := d.getPowerLine()
+	if err != nil {
+		return Result{
+			Name: name, Status: FailStatus, Detail: err.Error(),
+			Remediation: "Install UPower and start it: systemctl enable --now upower.service\n" +
+				"Alternatively set power_events.backend = \"sysfs\" or run with --disable-power-events",
+		}
+	}
+	if *line != query.Path {
+		return Result{
+			Name: name, Status: WarnStatus,
+			Detail:      fmt.Sprintf("UPower reports %s but the config queries %s", *line, query.Path),
+			Remediation: "Remove power_events.dbus_query_object.path to use the detected power line",
+		}
+	}
+	if conn == nil {
+		return skipped(name, "the bus is not reachable")
+	}
+	value, err := call(ctx, conn, query)
+	if err != nil {
+		return Result{
+			Name: name, Status: FailStatus, Detail: err.Error(),
+			Remediation: "Check that UPower is running on this bus: systemctl status upower.service",
+		}
+	}
+	return Result{Name: name, Status: PassStatus, Detail: fmt.Sprintf("%s reports %s", *line, value)}
+}
+
+func (d *Doctor) checkLidObject(ctx context.Context, conn *dbus.Conn, query *config.DbusQueryObject) Result {
+	name := "lid object"
+	if conn == nil {
+		return skipped(name, "the bus is not reachable")
+	}
+	value, err := call(ctx, conn, query)
+	if err != nil {
+		return Result{
+			Name: name, Status: WarnStatus, Detail: err.Error(),
+			Remediation: "Lid events are only read with --enable-lid-events, set lid_events.backend = \"sysfs\" " +
+				"to read /proc/acpi/button/lid instead",
+		}
+	}
+	return Result{
+		Name: name, Status: PassStatus,
+		Detail: fmt.Sprintf("%s %s reports %s", query.Destination, query.Path, value),
+	}
+}
+
+func call(ctx context.Context, conn *dbus.Conn, query *config.DbusQueryObject) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+	var value dbus.Variant
+	err := conn.Object(query.Destination, dbus.ObjectPath(query.Path)).
+		CallWithContext(ctx, query.Method, 0, query.CollectArgs()...).Store(&value)
+	if err != nil {
+		return "", fmt.Errorf("cant call %s on %s %s: %w", query.Method, query.Destination, query.Path, err)
+	}
+	return value.String(), nil
+}
@@ -0,0 +1,269 @@
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func env(values map[string]string) func(string) string {
+	return func(key string) string {
+		return values[key]
+	}
+}
+
+func writeFile(t *testing.T, path, contents string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	// nolint:gosec
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+}
+
+func rawConfig(destination string, profileFiles ...string) *config.RawConfig {
+	profiles := map[string]*config.Profile{}
+	for _, file := range profileFiles {
+		profiles[filepath.Base(file)] = &config.Profile{ConfigFile: file}
+	}
+	return &config.RawConfig{
+		General:  &config.GeneralSection{Destination: utils.StringPtr(destination)},
+		Profiles: profiles,
+	}
+}
+
+func TestPrint(t *testing.T) {
+	var out bytes.Buffer
+	err := Print(&out, []Result{
+		{Name: "config", Status: PassStatus, Detail: "valid", Remediation: "not printed"},
+		{Name: "lid object", Status: WarnStatus, Detail: "no lid", Remediation: "first\nsecond"},
+		{Name: "sockets", Status: FailStatus, Detail: "not found", Remediation: "start Hyprland"},
+		{Name: "systemd", Status: SkipStatus, Detail: "no systemctl"},
+	})
+
+	assert.ErrorIs(t, err, ErrChecksFailed)
+	assert.Equal(t, `[PASS] config: valid
+[WARN] lid object: no lid
+       first
+       second
+[FAIL] sockets: not found
+       start Hyprland
+[SKIP] systemd: no systemctl
+
+1 passed, 1 warnings, 1 failed, 1 skipped
+`, out.String())
+
+	out.Reset()
+	assert.NoError(t, Print(&out, []Result{{Name: "lid object", Status: WarnStatus}}))
+}
+
+func TestCheckDestination(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(t *testing.T, dir string) *config.RawConfig
+		status   Status
+		contains string
+	}{
+		{
+			name: "missing destination is created later",
+			setup: func(t *testing.T, dir string) *config.RawConfig {
+				return rawConfig(filepath.Join(dir, "monitors.conf"))
+			},
+			status:   PassStatus,
+			contains: "does not exist yet",
+		},
+		{
+			name: "missing directory",
+			setup: func(t *testing.T, dir string) *config.RawConfig {
+				return rawConfig(filepath.Join(dir, "missing", "monitors.conf"))
+			},
+			status:   FailStatus,
+			contains: "does not exist",
+		},
+		{
+			name: "rendered file",
+			setup: func(t *testing.T, dir string) *config.RawConfig {
+				writeFile(t, filepath.Join(dir, "monitors.conf"), "monitor=,preferred,auto,1")
+				return rawConfig(filepath.Join(dir, "monitors.conf"))
+			},
+			status: PassStatus,
+		},
+		{
+			name: "link to a profile",
+			setup: func(t *testing.T, dir string) *config.RawConfig {
+				profile := filepath.Join(dir, "laptop.conf")
+				writeFile(t, profile, "monitor=,preferred,auto,1")
+				require.NoError(t, os.Symlink(profile, filepath.Join(dir, "monitors.conf")))
+				return rawConfig(filepath.Join(dir, "monitors.conf"), profile)
+			},
+			status:   PassStatus,
+			contains: "links to",
+		},
+		{
+			name: "link to a file that is not a profile",
+			setup: func(t *testing.T, dir string) *config.RawConfig {
+				other := filepath.Join(dir, "other.conf")
+				writeFile(t, other, "monitor=,preferred,auto,1")
+				require.NoError(t, os.Symlink(other, filepath.Join(dir, "monitors.conf")))
+				return rawConfig(filepath.Join(dir, "monitors.conf"))
+			},
+			status:   WarnStatus,
+			contains: "is not a profile config file",
+		},
+		{
+			name: "stale symlink",
+			setup: func(t *testing.T, dir string) *config.RawConfig {
+				require.NoError(t, os.Symlink(filepath.Join(dir, "removed.conf"), filepath.Join(dir, "monitors.conf")))
+				return rawConfig(filepath.Join(dir, "monitors.conf"))
+			},
+			status:   FailStatus,
+			contains: "is a stale symlink",
+		},
+		{
+			name: "destination is a directory",
+			setup: func(t *testing.T, dir string) *config.RawConfig {
+				require.NoError(t, os.Mkdir(filepath.Join(dir, "monitors.conf"), 0o750))
+				return rawConfig(filepath.Join(dir, "monitors.conf"))
+			},
+			status:   FailStatus,
+			contains: "is not a regular file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDoctor(Options{})
+			result := d.checkDestination(tt.setup(t, t.TempDir()))
+			assert.Equal(t, tt.status, result.Status, result.Detail)
+			assert.Contains(t, result.Detail, tt.contains)
+		})
+	}
+}
+
+func TestCheckDestinationSourced(t *testing.T) {
+	tests := []struct {
+		name   string
+		setup  func(t *testing.T, home string)
+		status Status
+	}{
+		{
+			name: "sourced with a home relative path",
+			setup: func(t *testing.T, home string) {
+				writeFile(t, filepath.Join(home, ".config/hypr/hyprland.conf"),
+					"# source = nothing\nsource = ~/.config/hypr/monitors.conf # generated\n")
+			},
+			status: PassStatus,
+		},
+		{
+			name: "sourced through a nested glob and a relative path",
+			setup: func(t *testing.T, home string) {
+				writeFile(t, filepath.Join(home, ".config/hypr/hyprland.conf"), "source = $HOME/.config/hypr/conf.d/*.conf\n")
+				writeFile(t, filepath.Join(home, ".config/hypr/conf.d/10-input.conf"), "input {\n}\n")
+				writeFile(t, filepath.Join(home, ".config/hypr/conf.d/20-display.conf"), "source = ../monitors.conf\n")
+			},
+			status: PassStatus,
+		},
+		{
+			name: "source loops are not followed forever",
+			setup: func(t *testing.T, home string) {
+				writeFile(t, filepath.Join(home, ".config/hypr/hyprland.conf"), "source = other.conf\n")
+				writeFile(t, filepath.Join(home, ".config/hypr/other.conf"), "source = hyprland.conf\n")
+			},
+			status: FailStatus,
+		},
+		{
+			name: "missing hyprland.conf",
+			setup: func(t *testing.T, home string) {
+			},
+			status: WarnStatus,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			tt.setup(t, home)
+			d := NewDoctor(Options{})
+			d.getenv = env(map[string]string{"HOME": home})
+
+			result := d.checkDestinationSourced(rawConfig(filepath.Join(home, ".config/hypr/monitors.conf")))
+			assert.Equal(t, tt.status, result.Status, result.Detail)
+		})
+	}
+}
+
+func TestCheckDestinationSourced_HyprlandConfigFlag(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "custom.conf"), "source="+filepath.Join(dir, "monitors.conf"))
+
+	d := NewDoctor(Options{HyprlandConfigPath: filepath.Join(dir, "custom.conf")})
+	d.getenv = env(map[string]string{})
+	result := d.checkDestinationSourced(rawConfig(filepath.Join(dir, "monitors.conf")))
+	assert.Equal(t, PassStatus, result.Status, result.Detail)
+}
+
+func TestCheckSockets(t *testing.T) {
+	runtimeDir, err := os.MkdirTemp("", "hdm")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(runtimeDir) })
+
+	listen := func(signature string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(runtimeDir, "hypr", signature), 0o750))
+		for _, socket := range []string{".socket.sock", ".socket2.sock"} {
+			listener, err := net.Listen("unix", filepath.Join(runtimeDir, "hypr", signature, socket))
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = listener.Close() })
+		}
+	}
+	listen("running")
+	require.NoError(t, os.MkdirAll(filepath.Join(runtimeDir, "hypr", "stale"), 0o750))
+
+	d := NewDoctor(Options{})
+	result := d.checkSockets(context.Background(), runtimeDir, "running")
+	assert.Equal(t, PassStatus, result.Status, result.Detail)
+
+	result = d.checkSockets(context.Background(), runtimeDir, "stale")
+	assert.Equal(t, FailStatus, result.Status)
+	assert.Contains(t, result.Detail, "the running instance is running")
+	assert.Contains(t, result.Remediation, "HYPRLAND_INSTANCE_SIGNATURE=running")
+
+	result = d.checkSockets(context.Background(), runtimeDir, "")
+	assert.Equal(t, WarnStatus, result.Status)
+
+	result = d.checkSockets(context.Background(), t.TempDir(), "")
+	assert.Equal(t, FailStatus, result.Status)
+}
+
+func TestCheckSystemdEnvironment(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		err       error
+		signature string
+		status    Status
+	}{
+		{name: "matching", output: "PATH=/bin\nHYPRLAND_INSTANCE_SIGNATURE=abc", signature: "abc", status: PassStatus},
+		{name: "missing", output: "PATH=/bin", signature: "abc", status: WarnStatus},
+		{name: "stale", output: "HYPRLAND_INSTANCE_SIGNATURE=old", signature: "abc", status: WarnStatus},
+		{name: "no systemd", err: errors.New("not found"), signature: "abc", status: SkipStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDoctor(Options{})
+			d.runCommand = func(_ context.Context, name string, args ...string) (string, error) {
+				assert.Equal(t, "systemctl", name)
+				assert.Equal(t, []string{"--user", "show-environment"}, args)
+				return tt.output, tt.err
+			}
+			result := d.checkSystemdEnvironment(context.Background(), tt.signature)
+			assert.Equal(t, tt.status, result.Status, result.Detail)
+		})
+	}
+}
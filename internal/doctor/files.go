@@ -0,0 +1,100 @@
+package doctor
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/hypr"
+	"golang.org/x/sys/unix"
+)
+
+// isSourced follows the source= lines starting at hyprlandConfig, globs and nested sources
+// are resolved the way Hyprland does, relative paths are relative to the sourcing file
+func isSourced(hyprlandConfig, destination string, getenv func(string) string) (bool, error) {
+	return sourcedFrom(hyprlandConfig, destination, getenv, map[string]bool{})
+}
+
+func sourcedFrom(path, destination string, getenv func(string) string, visited map[string]bool) (bool, error) {
+	if visited[path] {
+		return false, nil
+	}
+	visited[path] = true
+
+	//nolint:gosec
+	file, err := os.Open(path)
+	if err != nil {
+		return false, fmt.Errorf("cant read %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	sources := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		value, ok := hypr.KeywordValue(scanner.Text(), "source")
+		if !ok || value == "" {
+			continue
+		}
+		expanded := expandPath(value, filepath.Dir(path), getenv)
+		matches, err := filepath.Glob(expanded)
+		if err != nil || len(matches) == 0 {
+			matches = []string{expanded}
+		}
+		sources = append(sources, matches...)
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("cant read %s: %w", path, err)
+	}
+
+	for _, source := range sources {
+		if samePath(source, destination) {
+			return true, nil
+		}
+	}
+	for _, source := range sources {
+		if !readable(source) {
+			continue
+		}
+		if found, err := sourcedFrom(source, destination, getenv, visited); err == nil && found {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// expandPath resolves ~, environment variables and paths relative to dir
+func expandPath(path, dir string, getenv func(string) string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		path = getenv("HOME") + path[1:]
+	}
+	path = os.Expand(path, getenv)
+	if !filepath.IsAbs(path) && dir != "" {
+		path = filepath.Join(dir, path)
+	}
+	return filepath.Clean(path)
+}
+
+// samePath compares the paths without resolving a symlink at the end, the destination
+// itself is a symlink for static profiles
+func samePath(a, b string) bool {
+	if filepath.Clean(a) == filepath.Clean(b) {
+		return true
+	}
+	aInfo, aErr := os.Lstat(a)
+	bInfo, bErr := os.Lstat(b)
+	return aErr == nil && bErr == nil && os.SameFile(aInfo, bInfo)
+}
+
+func parentDir(path string) string {
+	return filepath.Dir(filepath.Clean(path))
+}
+
+func readable(path string) bool {
+	return unix.Access(path, unix.R_OK) == nil
+}
+
+func writable(path string) bool {
+	return unix.Access(path, unix.W_OK) == nil
+}
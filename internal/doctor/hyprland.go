@@ -0,0 +1,123 @@
+package doctor
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/dial"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/hypr"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+)
+
+const importEnvironmentHint = "Import it into the systemd user environment from hyprland.conf:\n" +
+	"exec-once = dbus-update-activation-environment --systemd HYPRLAND_INSTANCE_SIGNATURE WAYLAND_DISPLAY XDG_CURRENT_DESKTOP"
+
+// checkHyprland verifies the environment variables used to find the sockets, the sockets
+// themselves and the environment the systemd service is started with
+func (d *Doctor) checkHyprland(ctx context.Context) []Result {
+	results := []Result{}
+	xdgRuntimeDir := d.getenv(utils.XDGRuntimeDir)
+	signature := d.getenv(instanceSignatureEnv)
+
+	switch {
+	case xdgRuntimeDir == "":
+		results = append(results, Result{
+			Name: "Hyprland environment", Status: FailStatus, Detail: utils.XDGRuntimeDir + " is not set",
+			Remediation: "Run the command from a Hyprland session",
+		})
+	case signature == "":
+		results = append(results, Result{
+			Name: "Hyprland environment", Status: FailStatus, Detail: instanceSignatureEnv + " is not set",
+			Remediation: "Run the command from a Hyprland session\n" + importEnvironmentHint,
+		})
+	default:
+		results = append(results, Result{
+			Name: "Hyprland environment", Status: PassStatus,
+			Detail: fmt.Sprintf("%s=%s", instanceSignatureEnv, signature),
+		})
+	}
+
+	if xdgRuntimeDir == "" {
+		results = append(results, skipped("Hyprland sockets", utils.XDGRuntimeDir+" is not set"))
+	} else {
+		results = append(results, d.checkSockets(ctx, xdgRuntimeDir, signature))
+	}
+	return append(results, d.checkSystemdEnvironment(ctx, signature))
+}
+
+func (d *Doctor) checkSockets(ctx context.Context, xdgRuntimeDir, signature string) Result {
+	name := "Hyprland sockets"
+	var socketErr error
+	if signature != "" {
+		for _, socket := range []string{
+			hypr.GetHyprSocket(xdgRuntimeDir, signature),
+			hypr.GetHyprEventsSocket(xdgRuntimeDir, signature),
+		} {
+			_, teardown, err := dial.GetUnixSocketConnection(ctx, socket)
+			if err != nil {
+				socketErr = err
+				break
+			}
+			teardown()
+		}
+		if socketErr == nil {
+			return Result{Name: name, Status: PassStatus, Detail: "connected to " + xdgRuntimeDir + "/hypr/" + signature}
+		}
+	}
+
+	running, err := hypr.DiscoverInstanceSignature(ctx, xdgRuntimeDir, "")
+	if err != nil {
+		return Result{
+			Name: name, Status: FailStatus, Detail: err.Error(),
+			Remediation: "Start Hyprland, the daemon talks to it over " + xdgRuntimeDir + "/hypr/<signature>/.socket*.sock",
+		}
+	}
+	if signature == "" {
+		return Result{
+			Name: name, Status: WarnStatus, Detail: "found a running instance " + running,
+			Remediation: "export " + instanceSignatureEnv + "=" + running,
+		}
+	}
+	return Result{
+		Name: name, Status: FailStatus,
+		Detail:      fmt.Sprintf("%s is stale (%v), the running instance is %s", signature, socketErr, running),
+		Remediation: "Restart the service from the current Hyprland session or export " + instanceSignatureEnv + "=" + running,
+	}
+}
+
+// checkSystemdEnvironment compares the signature the systemd user manager passes to services
+// with the current one, a missing or old value is a common reason for the service not switching
+func (d *Doctor) checkSystemdEnvironment(ctx context.Context, signature string) Result {
+	name := "systemd user environment"
+	out, err := d.runCommand(ctx, "systemctl", "--user", "show-environment")
+	if err != nil {
+		return skipped(name, "systemctl --user is not available")
+	}
+
+	systemdSignature := ""
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), instanceSignatureEnv+"="); ok {
+			systemdSignature = value
+		}
+	}
+
+	switch {
+	case systemdSignature == "":
+		return Result{
+			Name: name, Status: WarnStatus,
+			Detail:      instanceSignatureEnv + " is not set, the systemd service cannot find Hyprland",
+			Remediation: importEnvironmentHint,
+		}
+	case signature != "" && systemdSignature != signature:
+		return Result{
+			Name: name, Status: WarnStatus,
+			Detail: fmt.Sprintf("%s is %s but the current session uses %s",
+				instanceSignatureEnv, systemdSignature, signature),
+			Remediation: importEnvironmentHint,
+		}
+	}
+	return Result{Name: name, Status: PassStatus, Detail: fmt.Sprintf("%s=%s", instanceSignatureEnv, systemdSignature)}
+}
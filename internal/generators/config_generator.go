@@ -6,18 +6,23 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"sync"
 	"text/template"
 	"time"
 
 	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/hypr"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/layout"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/matchers"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/power"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
 	"github.com/sirupsen/logrus"
 )
 
+// ErrLayoutBlocked is returned when the generated layout fails the layout checks under the block policy
+var ErrLayoutBlocked = errors.New("generated monitor layout failed the layout checks")
+
 type ConfigGenerator struct {
 	mtime   map[string]time.Time
 	mtimeMu sync.RWMutex
@@ -39,7 +44,13 @@ func NewConfigGenerator(cfg *config.Config) (*ConfigGenerator, error) {
 }
 
 func (g *ConfigGenerator) ValidateTemplates() error {
-	for _, profile := range g.cfg.Get().Profiles {
+	return g.ValidateTemplatesOf(g.cfg.Get())
+}
+
+// ValidateTemplatesOf parses every template referenced by cfg, it is used to vet a config
+// before it replaces the currently active one
+func (g *ConfigGenerator) ValidateTemplatesOf(cfg *config.RawConfig) error {
+	for _, profile := range cfg.Profiles {
 		if *profile.ConfigType == config.Static {
 			continue
 		}
@@ -53,7 +64,7 @@ func (g *ConfigGenerator) ValidateTemplates() error {
 		}
 
 		_, err = template.New("template").Funcs(
-			getFuncMap(power.UnknownPowerState, power.UnknownLidState)).Parse(string(templateContent))
+			getFuncMap(power.UnknownPowerState, power.UnknownLidState, power.BatteryState{})).Parse(string(templateContent))
 		if err != nil {
 			return fmt.Errorf("failed to parse template %s: %w", templatePath, err)
 		}
@@ -65,42 +76,76 @@ func (g *ConfigGenerator) ValidateTemplates() error {
 // GenerateConfig either renders a template or links a file, and returns if any changed were done
 // this includes stating the config files to catch if the user modified them by hand (in linking scenario)
 func (g *ConfigGenerator) GenerateConfig(cfg *config.RawConfig, profile *matchers.MatchedProfile,
-	connectedMonitors []*hypr.MonitorSpec, powerState power.PowerState, lidState power.LidState, destination string, dryRun bool,
+	connectedMonitors []*hypr.MonitorSpec, powerState power.PowerState, lidState power.LidState,
+	batteryState power.BatteryState, destination string, dryRun bool,
 ) (bool, error) {
 	switch *profile.Profile.ConfigType {
 	case config.Static:
-		return g.linkConfigFile(profile.Profile, destination, dryRun)
+		return g.linkConfigFile(cfg, profile.Profile, connectedMonitors, destination, dryRun)
 	case config.Template:
-		return g.renderTemplateFile(cfg, profile, connectedMonitors, powerState, lidState, destination, dryRun)
+		return g.renderTemplateFile(cfg, profile, connectedMonitors, powerState, lidState, batteryState, destination, dryRun)
 	default:
 		return false, fmt.Errorf("unsupported config type: %v", *profile.Profile.ConfigType)
 	}
 }
 
-func (g *ConfigGenerator) renderTemplateFile(cfg *config.RawConfig, profile *matchers.MatchedProfile,
-	connectedMonitors []*hypr.MonitorSpec, powerState power.PowerState, lidState power.LidState, destination string, dryRun bool,
-) (bool, error) {
+// Render returns what GenerateConfig would write to the destination for the profile, for static
+// profiles this is the contents of the linked file
+func (g *ConfigGenerator) Render(cfg *config.RawConfig, profile *matchers.MatchedProfile,
+	connectedMonitors []*hypr.MonitorSpec, powerState power.PowerState, lidState power.LidState,
+	batteryState power.BatteryState,
+) ([]byte, error) {
+	switch *profile.Profile.ConfigType {
+	case config.Static:
+		//nolint:gosec
+		contents, err := os.ReadFile(profile.Profile.ConfigFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file %s: %w", profile.Profile.ConfigFile, err)
+		}
+		return contents, nil
+	case config.Template:
+		return g.render(cfg, profile, connectedMonitors, powerState, lidState, batteryState)
+	default:
+		return nil, fmt.Errorf("unsupported config type: %v", *profile.Profile.ConfigType)
+	}
+}
+
+func (g *ConfigGenerator) render(cfg *config.RawConfig, profile *matchers.MatchedProfile,
+	connectedMonitors []*hypr.MonitorSpec, powerState power.PowerState, lidState power.LidState,
+	batteryState power.BatteryState,
+) ([]byte, error) {
 	templatePath := profile.Profile.ConfigFile
 
 	//nolint:gosec
 	templateContent, err := os.ReadFile(templatePath)
 	if err != nil {
-		return false, fmt.Errorf("failed to read template file %s: %w", templatePath, err)
+		return nil, fmt.Errorf("failed to read template file %s: %w", templatePath, err)
 	}
 
-	tmpl, err := template.New("config").Funcs(getFuncMap(powerState, lidState)).Parse(string(templateContent))
+	tmpl, err := template.New("config").Funcs(getFuncMap(powerState, lidState, batteryState)).Parse(string(templateContent))
 	if err != nil {
-		return false, fmt.Errorf("failed to parse template: %w", err)
+		return nil, fmt.Errorf("failed to parse template: %w", err)
 	}
 
-	templateData := g.createTemplateData(cfg, profile, connectedMonitors, powerState, lidState)
+	templateData := g.createTemplateData(cfg, profile, connectedMonitors, powerState, lidState, batteryState)
 
 	var rendered bytes.Buffer
 	if err := tmpl.Execute(&rendered, templateData); err != nil {
-		return false, fmt.Errorf("failed to execute template: %w", err)
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+	return rendered.Bytes(), nil
+}
+
+func (g *ConfigGenerator) renderTemplateFile(cfg *config.RawConfig, profile *matchers.MatchedProfile,
+	connectedMonitors []*hypr.MonitorSpec, powerState power.PowerState, lidState power.LidState,
+	batteryState power.BatteryState, destination string, dryRun bool,
+) (bool, error) {
+	templatePath := profile.Profile.ConfigFile
+	renderedContent, err := g.render(cfg, profile, connectedMonitors, powerState, lidState, batteryState)
+	if err != nil {
+		return false, err
 	}
 
-	renderedContent := rendered.Bytes()
 	//nolint:gosec
 	if existingContent, err := os.ReadFile(destination); err == nil {
 		if bytes.Equal(existingContent, renderedContent) {
@@ -110,6 +155,10 @@ func (g *ConfigGenerator) renderTemplateFile(cfg *config.RawConfig, profile *mat
 		}
 	}
 
+	if err := checkLayout(cfg, profile.Profile, renderedContent, connectedMonitors); err != nil {
+		return false, err
+	}
+
 	if dryRun {
 		logrus.WithFields(utils.NewLogrusCustomFields(map[string]interface{}{
 			"config_file": templatePath, "destination": destination,
@@ -132,7 +181,36 @@ func (g *ConfigGenerator) renderTemplateFile(cfg *config.RawConfig, profile *mat
 	return true, nil
 }
 
-func getFuncMap(powerState power.PowerState, lidState power.LidState) template.FuncMap {
+// checkLayout validates the monitor layout the generated contents produce on the connected monitors,
+// depending on the policy the issues are only logged or stop the contents from being applied
+func checkLayout(cfg *config.RawConfig, profile *config.Profile, contents []byte,
+	connectedMonitors []*hypr.MonitorSpec,
+) error {
+	policy := *cfg.LayoutChecks.Policy
+	if policy == config.OffLayoutCheckPolicy {
+		return nil
+	}
+	issues := layout.Validate(contents, connectedMonitors)
+	if len(issues) == 0 {
+		return nil
+	}
+
+	messages := []string{}
+	for _, issue := range issues {
+		logrus.WithFields(logrus.Fields{
+			"profile": profile.Name,
+			"check":   issue.Check.String(),
+			"policy":  policy.Value(),
+		}).Warn("Layout check failed: " + issue.Message)
+		messages = append(messages, issue.Message)
+	}
+	if policy == config.BlockLayoutCheckPolicy {
+		return fmt.Errorf("%w for profile %s: %s", ErrLayoutBlocked, profile.Name, strings.Join(messages, "; "))
+	}
+	return nil
+}
+
+func getFuncMap(powerState power.PowerState, lidState power.LidState, batteryState power.BatteryState) template.FuncMap {
 	funcMap := template.FuncMap{
 		"isOnBattery": func() bool {
 			return powerState == power.BatteryPowerState
@@ -152,12 +230,22 @@ func getFuncMap(powerState power.PowerState, lidState power.LidState) template.F
 		"isLidOpened": func() bool {
 			return lidState == power.OpenedLidState
 		},
+		"batteryPercentage": func() int {
+			return batteryState.Percentage()
+		},
+		"isBatteryBelow": func(threshold int) bool {
+			return batteryState.IsBelow(threshold)
+		},
+		"powerProfile": func() string {
+			return batteryState.PowerProfile.String()
+		},
 	}
 	return funcMap
 }
 
 func (g *ConfigGenerator) createTemplateData(cfg *config.RawConfig, profile *matchers.MatchedProfile,
 	connectedMonitors []*hypr.MonitorSpec, powerState power.PowerState, lidState power.LidState,
+	batteryState power.BatteryState,
 ) map[string]any {
 	data := make(map[string]any)
 
@@ -172,6 +260,8 @@ func (g *ConfigGenerator) createTemplateData(cfg *config.RawConfig, profile *mat
 	data["Monitors"] = monitorsStripped
 	data["PowerState"] = powerState.String()
 	data["LidState"] = lidState.String()
+	data["BatteryPercentage"] = batteryState.Percentage()
+	data["PowerProfile"] = batteryState.PowerProfile.String()
 
 	requiredMonitors := []*MonitorSpec{}
 	extraMonitors := []*MonitorSpec{}
@@ -251,13 +341,24 @@ func (g *ConfigGenerator) createTemplateData(cfg *config.RawConfig, profile *mat
 	return data
 }
 
-func (g *ConfigGenerator) linkConfigFile(profile *config.Profile, destination string, dryRun bool) (bool, error) {
+func (g *ConfigGenerator) linkConfigFile(cfg *config.RawConfig, profile *config.Profile,
+	connectedMonitors []*hypr.MonitorSpec, destination string, dryRun bool,
+) (bool, error) {
 	source := profile.ConfigFile
 	differentContents, err := g.compareSymlinks(destination, source, profile)
 	if err == nil {
 		return differentContents, nil
 	}
 
+	//nolint:gosec
+	contents, err := os.ReadFile(source)
+	if err != nil {
+		return false, fmt.Errorf("failed to read config file %s: %w", source, err)
+	}
+	if err := checkLayout(cfg, profile, contents, connectedMonitors); err != nil {
+		return false, err
+	}
+
 	if _, err := os.Stat(destination); err == nil || !os.IsNotExist(err) {
 		if err := os.Remove(destination); err != nil {
 			return false, fmt.Errorf("failed to remove existing config: %w", err)
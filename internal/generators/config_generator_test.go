@@ -43,7 +43,7 @@ func TestConfigGenerator_GenerateConfig_Static(t *testing.T) {
 	matchedProfile := matchers.NewMatchedProfile(profile, map[int]*config.RequiredMonitor{})
 
 	changed, err := generator.GenerateConfig(cfg.Get(), matchedProfile, monitors,
-		power.ACPowerState, power.OpenedLidState, destination, false)
+		power.ACPowerState, power.OpenedLidState, power.BatteryState{}, destination, false)
 	assert.NoError(t, err, "GenerateConfig failed")
 	assert.True(t, changed, "file was not changed")
 
@@ -63,7 +63,7 @@ func TestConfigGenerator_GenerateConfig_Static(t *testing.T) {
 	}
 
 	changed, err = generator.GenerateConfig(cfg.Get(), matchedProfile, monitors,
-		power.ACPowerState, power.OpenedLidState, destination, false)
+		power.ACPowerState, power.OpenedLidState, power.BatteryState{}, destination, false)
 	assert.NoError(t, err, "GenerateConfig failed")
 	assert.False(t, changed, "file was changed")
 
@@ -71,14 +71,14 @@ func TestConfigGenerator_GenerateConfig_Static(t *testing.T) {
 	err = os.Chtimes(destination, time.Now(), time.Now())
 	assert.NoError(t, err, "touch failed")
 	changed, err = generator.GenerateConfig(cfg.Get(), matchedProfile, monitors,
-		power.ACPowerState, power.OpenedLidState, destination, false)
+		power.ACPowerState, power.OpenedLidState, power.BatteryState{}, destination, false)
 	assert.NoError(t, err, "GenerateConfig failed")
 	assert.True(t, changed, "file was not changed")
 
 	// assert dry runs
 	require.NoError(t, os.Remove(destination), "should be able to remove the destination file")
 	changed, err = generator.GenerateConfig(cfg.Get(), matchedProfile, monitors,
-		power.ACPowerState, power.OpenedLidState, destination, true)
+		power.ACPowerState, power.OpenedLidState, power.BatteryState{}, destination, true)
 	assert.False(t, changed, "nothing should change on dry run")
 	assert.NoError(t, err, "no error should be thrown on dry run")
 	testutils.AssertFileDoesNotExist(t, destination)
@@ -182,7 +182,7 @@ func TestConfigGenerator_GenerateConfig_Template(t *testing.T) {
 
 	// Test with battery power state
 	changed, err := generator.GenerateConfig(cfg.Get(), matchedProfile, monitors,
-		power.BatteryPowerState, power.OpenedLidState, destination, false)
+		power.BatteryPowerState, power.OpenedLidState, power.BatteryState{}, destination, false)
 	if err != nil {
 		t.Fatalf("GenerateConfig failed: %v", err)
 	}
@@ -194,7 +194,7 @@ func TestConfigGenerator_GenerateConfig_Template(t *testing.T) {
 
 	// Test with AC power state
 	changed, err = generator.GenerateConfig(cfg.Get(), matchedProfile, monitors,
-		power.ACPowerState, power.ClosedLidState, destination, false)
+		power.ACPowerState, power.ClosedLidState, power.BatteryState{}, destination, false)
 	if err != nil {
 		t.Fatalf("GenerateConfig failed with AC power: %v", err)
 	}
@@ -205,7 +205,7 @@ func TestConfigGenerator_GenerateConfig_Template(t *testing.T) {
 	testutils.AssertFixture(t, destination, "testdata/fixtures/ac.conf", *regenerate)
 
 	changed, err = generator.GenerateConfig(cfg.Get(), matchedProfile, monitors,
-		power.ACPowerState, power.ClosedLidState, destination, false)
+		power.ACPowerState, power.ClosedLidState, power.BatteryState{}, destination, false)
 	if err != nil {
 		t.Fatalf("GenerateConfig failed with AC power: %v", err)
 	}
@@ -217,8 +217,71 @@ func TestConfigGenerator_GenerateConfig_Template(t *testing.T) {
 
 	require.NoError(t, os.Remove(destination), "should be able to remove the destination file")
 	changed, err = generator.GenerateConfig(cfg.Get(), matchedProfile, monitors,
-		power.ACPowerState, power.ClosedLidState, destination, true)
+		power.ACPowerState, power.ClosedLidState, power.BatteryState{}, destination, true)
 	assert.False(t, changed, "should not change anything on dry run")
 	assert.NoError(t, err, "should not err on dry run")
 	testutils.AssertFileDoesNotExist(t, destination)
 }
+
+func TestConfigGenerator_GenerateConfig_LayoutChecks(t *testing.T) {
+	overlapping := "monitor=eDP-1,1920x1080@60,0x0,1\nmonitor=DP-1,2560x1440@60,1000x0,1\n"
+	monitors := []*hypr.MonitorSpec{
+		{
+			Name: "eDP-1", ID: utils.IntPtr(0), Description: "Built-in Display",
+			Width: 1920, Height: 1080, Scale: 1, AvailableModes: []string{"1920x1080@60.00Hz"},
+		},
+		{
+			Name: "DP-1", ID: utils.IntPtr(1), Description: "External Monitor",
+			Width: 2560, Height: 1440, Scale: 1, AvailableModes: []string{"2560x1440@60.00Hz"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		policy      config.LayoutCheckPolicy
+		configType  config.ConfigFileType
+		expectError bool
+	}{
+		{name: "warn template", policy: config.WarnLayoutCheckPolicy, configType: config.Template},
+		{name: "off template", policy: config.OffLayoutCheckPolicy, configType: config.Template},
+		{name: "block template", policy: config.BlockLayoutCheckPolicy, configType: config.Template, expectError: true},
+		{name: "warn static", policy: config.WarnLayoutCheckPolicy, configType: config.Static},
+		{name: "block static", policy: config.BlockLayoutCheckPolicy, configType: config.Static, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testutils.NewTestConfig(t).WithLayoutChecks(&config.LayoutChecksSection{
+				Policy: utils.JustPtr(tt.policy),
+			}).Get()
+			generator, err := generators.NewConfigGenerator(cfg)
+			require.NoError(t, err, "config generators should be able to init")
+
+			tempDir := t.TempDir()
+			destination := filepath.Join(tempDir, "hyprland.conf")
+			configFile := filepath.Join(tempDir, "overlapping.conf")
+			// nolint:gosec
+			require.NoError(t, os.WriteFile(configFile, []byte(overlapping), 0o644))
+
+			profile := &config.Profile{
+				Name:       "overlapping",
+				ConfigFile: configFile,
+				ConfigType: utils.JustPtr(tt.configType),
+			}
+			matchedProfile := matchers.NewMatchedProfile(profile, map[int]*config.RequiredMonitor{})
+			changed, err := generator.GenerateConfig(cfg.Get(), matchedProfile, monitors,
+				power.ACPowerState, power.OpenedLidState, power.BatteryState{}, destination, false)
+
+			if tt.expectError {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, generators.ErrLayoutBlocked)
+				assert.Contains(t, err.Error(), "eDP-1 (1920x1080 at 0x0) overlaps DP-1 (2560x1440 at 1000x0)")
+				assert.False(t, changed)
+				testutils.AssertFileDoesNotExist(t, destination)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, changed)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+// Package hotplug provides flap detection for monitors that repeatedly
+// connect and disconnect, e.g. behind flaky DisplayPort/USB-C docks
+package hotplug
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/hypr"
+	"github.com/sirupsen/logrus"
+)
+
+// FlapDetector tracks connect/disconnect transitions per monitor name and quarantines
+// monitors that change state too often, quarantined monitors are hidden from the caller
+// until their cooldown expires
+type FlapDetector struct {
+	mu               sync.Mutex
+	present          map[string]bool
+	transitions      map[string][]time.Time
+	quarantinedUntil map[string]time.Time
+	now              func() time.Time
+}
+
+func NewFlapDetector() *FlapDetector {
+	return &FlapDetector{
+		present:          make(map[string]bool),
+		transitions:      make(map[string][]time.Time),
+		quarantinedUntil: make(map[string]time.Time),
+		now:              time.Now,
+	}
+}
+
+// Seed records the current set of monitors without counting it as transitions
+func (f *FlapDetector) Seed(monitors []*hypr.MonitorSpec) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.present = namesOf(monitors)
+}
+
+// Observe records the transitions between the previously seen monitors and the given ones,
+// it returns the names of monitors that got quarantined by this observation
+func (f *FlapDetector) Observe(cfg *config.FlapDetectionSection, monitors []*hypr.MonitorSpec) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	current := namesOf(monitors)
+	changed := []string{}
+	for name := range current {
+		if !f.present[name] {
+			changed = append(changed, name)
+		}
+	}
+	for name := range f.present {
+		if !current[name] {
+			changed = append(changed, name)
+		}
+	}
+	f.present = current
+
+	if !*cfg.Enabled {
+		return nil
+	}
+
+	window := time.Duration(*cfg.WindowMs) * time.Millisecond
+	cooldown := time.Duration(*cfg.CooldownMs) * time.Millisecond
+	quarantined := []string{}
+	for _, name := range changed {
+		f.transitions[name] = append(f.transitions[name], now)
+		history := f.transitions[name]
+		// drop transitions that fell out of the window
+		start := 0
+		for start < len(history) && now.Sub(history[start]) > window {
+			start++
+		}
+		history = history[start:]
+		f.transitions[name] = history
+
+		if len(history) <= *cfg.MaxTransitions || f.isQuarantinedLocked(name, now) {
+			continue
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"monitor":     name,
+			"transitions": len(history),
+			"window":      window,
+			"cooldown":    cooldown,
+		}).Warn("Monitor is flapping, quarantining it")
+		f.quarantinedUntil[name] = now.Add(cooldown)
+		delete(f.transitions, name)
+		quarantined = append(quarantined, name)
+	}
+
+	sort.Strings(quarantined)
+	return quarantined
+}
+
+// Filter drops the quarantined monitors
+func (f *FlapDetector) Filter(monitors []*hypr.MonitorSpec) []*hypr.MonitorSpec {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	filtered := make([]*hypr.MonitorSpec, 0, len(monitors))
+	for _, monitor := range monitors {
+		if f.isQuarantinedLocked(monitor.Name, now) {
+			logrus.WithField("monitor", monitor.Name).Debug("Skipping quarantined monitor")
+			continue
+		}
+		filtered = append(filtered, monitor)
+	}
+	return filtered
+}
+
+// NextRelease returns the time left until the earliest quarantine expires
+func (f *FlapDetector) NextRelease() (time.Duration, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.now()
+	var next time.Duration
+	found := false
+	for name, until := range f.quarantinedUntil {
+		if !until.After(now) {
+			delete(f.quarantinedUntil, name)
+			continue
+		}
+		if left := until.Sub(now); !found || left < next {
+			next = left
+			found = true
+		}
+	}
+	return next, found
+}
+
+func (f *FlapDetector) isQuarantinedLocked(name string, now time.Time) bool {
+	until, ok := f.quarantinedUntil[name]
+	return ok && until.After(now)
+}
+
+func namesOf(monitors []*hypr.MonitorSpec) map[string]bool {
+	names := make(map[string]bool, len(monitors))
+	for _, monitor := range monitors {
+		names[monitor.Name] = true
+	}
+	return names
+}
@@ -0,0 +1,138 @@
+package hotplug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/hypr"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func monitors(names ...string) []*hypr.MonitorSpec {
+	specs := []*hypr.MonitorSpec{}
+	for i, name := range names {
+		specs = append(specs, &hypr.MonitorSpec{Name: name, ID: utils.IntPtr(i)})
+	}
+	return specs
+}
+
+func flapConfig(enabled bool) *config.FlapDetectionSection {
+	return &config.FlapDetectionSection{
+		Enabled:        utils.BoolPtr(enabled),
+		WindowMs:       utils.IntPtr(1000),
+		MaxTransitions: utils.IntPtr(3),
+		CooldownMs:     utils.IntPtr(5000),
+	}
+}
+
+func TestFlapDetector(t *testing.T) {
+	tests := []struct {
+		name                string
+		cfg                 *config.FlapDetectionSection
+		step                time.Duration
+		events              [][]*hypr.MonitorSpec
+		expectedQuarantined []string
+		expectedFiltered    []string
+	}{
+		{
+			name: "flapping monitor is quarantined",
+			cfg:  flapConfig(true),
+			step: 100 * time.Millisecond,
+			events: [][]*hypr.MonitorSpec{
+				monitors("eDP-1", "DP-1"),
+				monitors("eDP-1"),
+				monitors("eDP-1", "DP-1"),
+				monitors("eDP-1"),
+				monitors("eDP-1", "DP-1"),
+			},
+			expectedQuarantined: []string{"DP-1"},
+			expectedFiltered:    []string{"eDP-1"},
+		},
+		{
+			name: "slow changes are not flapping",
+			cfg:  flapConfig(true),
+			step: 600 * time.Millisecond,
+			events: [][]*hypr.MonitorSpec{
+				monitors("eDP-1", "DP-1"),
+				monitors("eDP-1"),
+				monitors("eDP-1", "DP-1"),
+				monitors("eDP-1"),
+				monitors("eDP-1", "DP-1"),
+			},
+			expectedFiltered: []string{"eDP-1", "DP-1"},
+		},
+		{
+			name: "disabled detection",
+			cfg:  flapConfig(false),
+			step: 100 * time.Millisecond,
+			events: [][]*hypr.MonitorSpec{
+				monitors("eDP-1", "DP-1"),
+				monitors("eDP-1"),
+				monitors("eDP-1", "DP-1"),
+				monitors("eDP-1"),
+				monitors("eDP-1", "DP-1"),
+			},
+			expectedFiltered: []string{"eDP-1", "DP-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Unix(0, 0)
+			detector := NewFlapDetector()
+			detector.now = func() time.Time { return now }
+			detector.Seed(monitors("eDP-1"))
+
+			quarantined := []string{}
+			for _, event := range tt.events {
+				now = now.Add(tt.step)
+				quarantined = append(quarantined, detector.Observe(tt.cfg, event)...)
+			}
+
+			if tt.expectedQuarantined == nil {
+				assert.Empty(t, quarantined)
+			} else {
+				assert.Equal(t, tt.expectedQuarantined, quarantined)
+			}
+
+			filtered := []string{}
+			for _, monitor := range detector.Filter(tt.events[len(tt.events)-1]) {
+				filtered = append(filtered, monitor.Name)
+			}
+			assert.Equal(t, tt.expectedFiltered, filtered)
+		})
+	}
+}
+
+func TestFlapDetector_Release(t *testing.T) {
+	now := time.Unix(0, 0)
+	detector := NewFlapDetector()
+	detector.now = func() time.Time { return now }
+	cfg := flapConfig(true)
+
+	_, ok := detector.NextRelease()
+	assert.False(t, ok, "nothing should be quarantined yet")
+
+	detector.Seed(monitors("eDP-1"))
+	for i := range 4 {
+		now = now.Add(10 * time.Millisecond)
+		if i%2 == 0 {
+			detector.Observe(cfg, monitors("eDP-1", "DP-1"))
+		} else {
+			detector.Observe(cfg, monitors("eDP-1"))
+		}
+	}
+
+	left, ok := detector.NextRelease()
+	require.True(t, ok)
+	assert.Equal(t, 5*time.Second, left)
+	assert.Len(t, detector.Filter(monitors("eDP-1", "DP-1")), 1)
+
+	now = now.Add(5 * time.Second)
+	_, ok = detector.NextRelease()
+	assert.False(t, ok, "quarantine should have expired")
+	assert.Len(t, detector.Filter(monitors("eDP-1", "DP-1")), 2)
+}
@@ -10,23 +10,36 @@ import (
 	"reflect"
 	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/dial"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 )
 
+// errConnectionLost marks failures that are recoverable by reconnecting to Hyprland
+var errConnectionLost = errors.New("connection to hyprland lost")
+
 type IPC struct {
+	cfg               *config.Config
 	instanceSignature string
 	xdgRuntimeDir     string
 	events            chan MonitorSpecs
+	reconnected       chan struct{}
+	configReloaded    chan struct{}
 	monitors          MonitorSpecs
 	mu                sync.RWMutex
+	connected         atomic.Bool
+	// disconnectedAt holds the unix nano time the events socket was last lost (or the IPC created)
+	disconnectedAt atomic.Int64
+	stopped        atomic.Bool
 }
 
-func NewIPC(ctx context.Context) (*IPC, error) {
-	signature := os.Getenv("HYPRLAND_INSTANCE_SIGNATURE")
+func NewIPC(ctx context.Context, cfg *config.Config) (*IPC, error) {
+	signature := os.Getenv(instanceSignatureEnv)
 	if signature == "" {
 		return nil, errors.New("HYPRLAND_INSTANCE_SIGNATURE environment variable not set - are you running under Hyprland?")
 	}
@@ -37,11 +50,15 @@ func NewIPC(ctx context.Context) (*IPC, error) {
 	}
 
 	ipc := &IPC{
+		cfg:               cfg,
 		instanceSignature: signature,
 		xdgRuntimeDir:     xdgRuntimeDir,
 		events:            make(chan MonitorSpecs, 10),
+		reconnected:       make(chan struct{}, 1),
+		configReloaded:    make(chan struct{}, 1),
 		mu:                sync.RWMutex{},
 	}
+	ipc.disconnectedAt.Store(time.Now().UnixNano())
 
 	monitors, err := ipc.queryConnectedMonitors(ctx)
 	if err != nil {
@@ -57,28 +74,114 @@ func (h *IPC) Listen() <-chan MonitorSpecs {
 	return h.events
 }
 
+// Reconnected receives a message every time the IPC re-establishes
+// the connection to a (possibly new) Hyprland instance
+func (h *IPC) Reconnected() <-chan struct{} {
+	return h.reconnected
+}
+
+// ConfigReloaded receives a message every time Hyprland reloads its configuration,
+// only when configreloaded is one of the re-evaluation triggers
+func (h *IPC) ConfigReloaded() <-chan struct{} {
+	return h.configReloaded
+}
+
+// Healthy reports an error once the event loop exited or the events socket has been
+// disconnected for longer than reconnect_unhealthy_after_ms, retrying within that limit is healthy
+func (h *IPC) Healthy() error {
+	if h.stopped.Load() {
+		return errors.New("hyprland event loop is not running")
+	}
+	if h.connected.Load() {
+		return nil
+	}
+	disconnectedFor := time.Since(time.Unix(0, h.disconnectedAt.Load()))
+	if disconnectedFor > h.unhealthyAfter() {
+		return fmt.Errorf("not connected to the hyprland events socket for %s", disconnectedFor.Round(time.Second))
+	}
+	return nil
+}
+
 func (h *IPC) RunEventLoop(ctx context.Context) error {
+	defer close(h.events)
+	defer h.stopped.Store(true)
+
+	lastSent := MonitorSpecs{}
+	backoff := h.initialBackoff()
+
+	for {
+		err := h.listenForEvents(ctx, &lastSent)
+		if ctx.Err() != nil {
+			logrus.Debug("Hypr IPC context cancelled, exiting event loop")
+			return context.Cause(ctx)
+		}
+		if !errors.Is(err, errConnectionLost) {
+			return err
+		}
+
+		for {
+			logrus.WithError(err).WithFields(logrus.Fields{"backoff": backoff}).Warn(
+				"Lost connection to Hyprland, will try to reconnect")
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				logrus.Debug("Hypr IPC context cancelled while waiting to reconnect")
+				return context.Cause(ctx)
+			}
+			backoff = min(backoff*2, h.maxBackoff())
+
+			if err = h.reconnect(ctx); err == nil {
+				break
+			}
+		}
+
+		backoff = h.initialBackoff()
+		// force sending the next monitors state, the new instance might have different ids
+		lastSent = MonitorSpecs{}
+
+		select {
+		case h.reconnected <- struct{}{}:
+		default:
+			logrus.Debug("Reconnect notification already pending, skipping send")
+		}
+	}
+}
+
+// listenForEvents blocks until the events socket is closed, errConnectionLost is returned
+// when the failure can be recovered by reconnecting
+func (h *IPC) listenForEvents(ctx context.Context, lastSent *MonitorSpecs) error {
+	h.mu.RLock()
 	socketPath := GetHyprEventsSocket(h.xdgRuntimeDir, h.instanceSignature)
+	h.mu.RUnlock()
+
 	eg, ctx := errgroup.WithContext(ctx)
 
 	conn, connTeardown, err := dial.GetUnixSocketConnection(ctx, socketPath)
 	if err != nil {
-		return fmt.Errorf("cant open unix events socket connection to %s: %w", socketPath, err)
+		return fmt.Errorf("%w: cant open unix events socket connection to %s: %w", errConnectionLost, socketPath, err)
 	}
+	h.connected.Store(true)
+	defer func() {
+		h.disconnectedAt.Store(time.Now().UnixNano())
+		h.connected.Store(false)
+	}()
 
+	done := make(chan struct{})
 	eg.Go(func() error {
-		<-ctx.Done()
-		logrus.Debug("Hypr IPC context cancelled, closing connection to unblock scanner")
-		connTeardown()
-		return context.Cause(ctx)
+		select {
+		case <-ctx.Done():
+			logrus.Debug("Hypr IPC context cancelled, closing connection to unblock scanner")
+			connTeardown()
+			return context.Cause(ctx)
+		case <-done:
+			return nil
+		}
 	})
 
 	eg.Go(func() error {
-		defer close(h.events)
+		defer close(done)
 		defer connTeardown()
 
-		lastSent := MonitorSpecs{}
-
 		scanner := bufio.NewScanner(conn)
 		for scanner.Scan() {
 			select {
@@ -97,23 +200,33 @@ func (h *IPC) RunEventLoop(ctx context.Context) error {
 			if !found {
 				continue
 			}
-			// skip updates for non monitor related events
-			if !slices.Contains([]HyprEventType{MonitorAdded, MonitorRemoved}, event.Type) {
+			// skip updates for events the user does not want to re-evaluate on
+			if !h.shouldReevaluate(event.Type) {
+				logrus.WithFields(logrus.Fields{"event": event.Type.Value()}).Debug("Event is not a trigger, skipping")
 				continue
 			}
 
 			// refetch the current state, it's unknown whether monitorremoved
-			// event means disabled or physically removed
+			// event means disabled or physically removed, a config reload might have changed modes
 			monitors, err := h.queryConnectedMonitors(ctx)
+			if err != nil {
+				return fmt.Errorf("%w: cant get monitors spec: %w", errConnectionLost, err)
+			}
 
 			h.mu.Lock()
 			h.monitors = monitors
 			h.mu.Unlock()
 
-			if err != nil {
-				return fmt.Errorf("cant get monitors spec: %w", err)
+			if event.Type == ConfigReloaded {
+				logrus.Info("Hyprland configuration reloaded")
+				select {
+				case h.configReloaded <- struct{}{}:
+				default:
+					logrus.Debug("Config reload notification already pending, skipping send")
+				}
 			}
-			if reflect.DeepEqual(monitors, lastSent) {
+
+			if reflect.DeepEqual(monitors, *lastSent) {
 				logrus.Debug("Monitors are unchanged between now and the last sent value, skipping send")
 				continue
 			}
@@ -122,7 +235,7 @@ func (h *IPC) RunEventLoop(ctx context.Context) error {
 
 			select {
 			case h.events <- monitors:
-				lastSent = monitors
+				*lastSent = monitors
 				logrus.Debug("Monitors event sent")
 			case <-ctx.Done():
 				logrus.Debug("Hypr IPC context cancelled during event send")
@@ -131,11 +244,11 @@ func (h *IPC) RunEventLoop(ctx context.Context) error {
 		}
 
 		if err := scanner.Err(); err != nil {
-			return fmt.Errorf("scanner error: %w", err)
+			return fmt.Errorf("%w: scanner error: %w", errConnectionLost, err)
 		}
 
-		logrus.Debug("Hypr IPC scanner finished")
-		return nil
+		logrus.Debug("Hypr IPC scanner finished, events socket closed")
+		return errConnectionLost
 	})
 
 	if err = eg.Wait(); err != nil {
@@ -144,6 +257,65 @@ func (h *IPC) RunEventLoop(ctx context.Context) error {
 	return nil
 }
 
+// reconnect rediscovers the running Hyprland instance and refreshes the monitors state
+func (h *IPC) reconnect(ctx context.Context) error {
+	h.mu.RLock()
+	current := h.instanceSignature
+	h.mu.RUnlock()
+
+	signature, err := DiscoverInstanceSignature(ctx, h.xdgRuntimeDir, current)
+	if err != nil {
+		logrus.WithError(err).Info("No running Hyprland instance found")
+		return fmt.Errorf("cant discover hyprland instance: %w", err)
+	}
+
+	if signature != current {
+		logrus.WithFields(logrus.Fields{"from": current, "to": signature}).Info(
+			"Hyprland instance signature changed")
+		// callbacks (e.g. hyprctl in post_apply_exec) should target the new instance
+		if err := os.Setenv(instanceSignatureEnv, signature); err != nil {
+			logrus.WithError(err).Warn("Cant update " + instanceSignatureEnv)
+		}
+	}
+
+	h.mu.Lock()
+	h.instanceSignature = signature
+	h.mu.Unlock()
+
+	monitors, err := h.queryConnectedMonitors(ctx)
+	if err != nil {
+		return fmt.Errorf("cant query monitors after reconnecting: %w", err)
+	}
+
+	h.mu.Lock()
+	h.monitors = monitors
+	h.mu.Unlock()
+
+	logrus.WithFields(logrus.Fields{"signature": signature, "monitors": len(monitors)}).Info(
+		"Reconnected to Hyprland")
+	return nil
+}
+
+func (h *IPC) shouldReevaluate(eventType HyprEventType) bool {
+	trigger, ok := eventType.Trigger()
+	if !ok {
+		return false
+	}
+	return slices.Contains(h.cfg.Get().HyprIPC.ReevaluateOn, trigger)
+}
+
+func (h *IPC) initialBackoff() time.Duration {
+	return time.Duration(*h.cfg.Get().HyprIPC.ReconnectInitialBackoffMs) * time.Millisecond
+}
+
+func (h *IPC) maxBackoff() time.Duration {
+	return time.Duration(*h.cfg.Get().HyprIPC.ReconnectMaxBackoffMs) * time.Millisecond
+}
+
+func (h *IPC) unhealthyAfter() time.Duration {
+	return time.Duration(*h.cfg.Get().HyprIPC.ReconnectUnhealthyAfterMs) * time.Millisecond
+}
+
 func (h *IPC) GetConnectedMonitors() MonitorSpecs {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
@@ -152,7 +324,9 @@ func (h *IPC) GetConnectedMonitors() MonitorSpecs {
 
 func (h *IPC) queryConnectedMonitors(ctx context.Context) (MonitorSpecs, error) {
 	logrus.Debug("Querying connected monitors")
+	h.mu.RLock()
 	socketPath := GetHyprSocket(h.xdgRuntimeDir, h.instanceSignature)
+	h.mu.RUnlock()
 	conn, teardown, err := dial.GetUnixSocketConnection(ctx, socketPath)
 	if err != nil {
 		return nil, fmt.Errorf("cant open socket to %s: %w", socketPath, err)
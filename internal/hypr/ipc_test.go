@@ -1,14 +1,17 @@
 package hypr_test
 
 import (
+	"bufio"
 	"context"
 	"net"
 	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/hypr"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/testutils"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
@@ -32,6 +35,8 @@ func TestIPC_Run(t *testing.T) {
 		expectError        bool
 		description        string
 		exitOnError        bool
+		reevaluateOn       []config.HyprEventTrigger
+		expectReload       bool
 	}{
 		{
 			name: "happy_path",
@@ -86,6 +91,46 @@ func TestIPC_Run(t *testing.T) {
 			expectError: false,
 			description: "Should not send events when data does not change",
 		},
+		{
+			name: "config_reloaded",
+			mockEvents: []string{
+				"configreloaded>>",
+			},
+			expectedCommands: []string{
+				"j/monitors all", // initial setup
+				"j/monitors all", // config reload
+			},
+			responsePaths: []string{
+				"testdata/monitors_response_valid_1.json",
+				"testdata/monitors_response_valid_2.json",
+			},
+			expectedEventPaths: []string{
+				"testdata/monitors_response_valid_2.json",
+			},
+			expectReload: true,
+			description:  "Should refetch monitors on config reload and forward mode changes",
+		},
+		{
+			name: "filtered_triggers",
+			mockEvents: []string{
+				"configreloaded>>",
+				"monitorremovedv2>>2,DP-1,External Monitor",
+				"monitoraddedv2>>2,DP-1,External Monitor",
+			},
+			expectedCommands: []string{
+				"j/monitors all", // initial setup
+				"j/monitors all", // monitor added
+			},
+			responsePaths: []string{
+				"testdata/monitors_response_valid_1.json",
+				"testdata/monitors_response_valid_2.json",
+			},
+			expectedEventPaths: []string{
+				"testdata/monitors_response_valid_2.json",
+			},
+			reevaluateOn: []config.HyprEventTrigger{config.MonitorAddedTrigger},
+			description:  "Should only react to the configured triggers",
+		},
 		{
 			name: "parse_error",
 			mockEvents: []string{
@@ -144,7 +189,9 @@ func TestIPC_Run(t *testing.T) {
 				hypr.GetHyprEventsSocket)
 			ipcListener, ipcCleanUp := testutils.SetupHyprSocket(ctx, t, xdgRuntimeDir, signature, hypr.GetHyprSocket)
 			writerDone := testutils.SetupFakeHyprIPCWriter(t, ipcListener, responseData, tt.expectedCommands, tt.exitOnError)
-			ipc, err := hypr.NewIPC(ctx)
+			ipc, err := hypr.NewIPC(ctx, testutils.NewTestConfig(t).WithHyprIPC(&config.HyprIPCSection{
+				ReevaluateOn: tt.reevaluateOn,
+			}).Get())
 			require.NoError(t, err, "failed to create ipc")
 			defer func() {
 				eventsSocketCleanUp()
@@ -158,6 +205,13 @@ func TestIPC_Run(t *testing.T) {
 			case <-writerDone:
 				break
 			case err := <-ipcDone:
+				if tt.expectReload {
+					select {
+					case <-ipc.ConfigReloaded():
+					default:
+						t.Error("Expected a config reload notification")
+					}
+				}
 				if !tt.expectError && expectedEventCount > 0 {
 					assert.Equal(t, expectedEvents, events, tt.description)
 					return
@@ -226,6 +280,119 @@ collectLoop:
 	return ipcDone, events
 }
 
+func TestIPC_Reconnect(t *testing.T) {
+	if *debug {
+		logrus.SetLevel(logrus.DebugLevel)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	initial, err := os.ReadFile("testdata/monitors_response_valid_1.json")
+	require.NoError(t, err)
+	afterRestart, err := os.ReadFile("testdata/monitors_response_valid_2.json")
+	require.NoError(t, err)
+	expected := hypr.MonitorSpecs{}
+	require.NoError(t, utils.UnmarshalResponse(afterRestart, &expected))
+	require.NoError(t, expected.Validate())
+
+	xdgRuntimeDir, signature := testutils.SetupHyprEnvVars(t)
+	cfg := testutils.NewTestConfig(t).WithHyprIPC(&config.HyprIPCSection{
+		ReconnectInitialBackoffMs: utils.IntPtr(10),
+		ReconnectMaxBackoffMs:     utils.IntPtr(50),
+	}).Get()
+
+	oldEvents, _ := testutils.SetupHyprSocket(ctx, t, xdgRuntimeDir, signature, hypr.GetHyprEventsSocket)
+	oldIPC, _ := testutils.SetupHyprSocket(ctx, t, xdgRuntimeDir, signature, hypr.GetHyprSocket)
+	oldWriterDone := serveMonitors(t, oldIPC, initial)
+
+	ipc, err := hypr.NewIPC(ctx, cfg)
+	require.NoError(t, err, "failed to create ipc")
+
+	// simulate a compositor crash: drop the events connection and remove the sockets
+	go func() {
+		conn, err := oldEvents.Accept()
+		if err != nil {
+			t.Errorf("Failed to accept connection: %v", err)
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+		_ = conn.Close()
+		_ = oldEvents.Close()
+		_ = oldIPC.Close()
+	}()
+
+	newSignature := "restarted_signature"
+	// nolint:gosec
+	require.NoError(t, os.MkdirAll(filepath.Join(xdgRuntimeDir, "hypr", newSignature), 0o755))
+	newEvents, newEventsCleanUp := testutils.SetupHyprSocket(
+		ctx, t, xdgRuntimeDir, newSignature, hypr.GetHyprEventsSocket)
+	newIPC, newIPCCleanUp := testutils.SetupHyprSocket(ctx, t, xdgRuntimeDir, newSignature, hypr.GetHyprSocket)
+	newWriterDone := serveMonitors(t, newIPC, afterRestart)
+	eventsDone := testutils.SetupFakeHyprEventsServer(ctx, t, newEvents, []string{
+		"monitoraddedv2>>2,DP-1,External Monitor",
+	})
+
+	ipcDone := make(chan error, 1)
+	go func() {
+		ipcDone <- ipc.RunEventLoop(ctx)
+	}()
+
+	select {
+	case <-ipc.Reconnected():
+	case <-time.After(2 * time.Second):
+		t.Fatal("IPC did not reconnect in time")
+	}
+	assert.Equal(t, expected, ipc.GetConnectedMonitors(), "monitors should be refreshed after reconnecting")
+	assert.Equal(t, newSignature, os.Getenv("HYPRLAND_INSTANCE_SIGNATURE"), "signature should be updated")
+
+	select {
+	case event := <-ipc.Listen():
+		assert.Equal(t, expected, event, "events from the new instance should be forwarded")
+	case <-time.After(2 * time.Second):
+		t.Fatal("IPC did not forward the event from the new instance")
+	}
+
+	cancel()
+	newEventsCleanUp()
+	newIPCCleanUp()
+	for _, done := range []chan struct{}{oldWriterDone, newWriterDone, eventsDone} {
+		select {
+		case <-done:
+		case <-time.After(1 * time.Second):
+			t.Error("Server didn't finish in time")
+		}
+	}
+	select {
+	case err := <-ipcDone:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(1 * time.Second):
+		t.Error("IPC didn't finish in time")
+	}
+}
+
+// serveMonitors answers every monitors query with the given response,
+// connections without a command (liveness probes) are ignored
+func serveMonitors(t *testing.T, listener net.Listener, response []byte) chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			s := bufio.NewScanner(conn)
+			if s.Scan() && s.Text() == "j/monitors all" {
+				_, err := conn.Write(response)
+				assert.NoError(t, err, "failed to write response")
+			}
+			_ = conn.Close()
+		}
+	}()
+	return done
+}
+
 func TestNewIPC_MissingEnvironmentVariables(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -272,7 +439,7 @@ func TestNewIPC_MissingEnvironmentVariables(t *testing.T) {
 
 			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 			defer cancel()
-			ipc, err := hypr.NewIPC(ctx)
+			ipc, err := hypr.NewIPC(ctx, nil)
 
 			if tt.wantErr == "" {
 				if err != nil {
@@ -438,7 +605,7 @@ func TestIPC_GetConnectedMonitors(t *testing.T) {
 				responseData,
 			}, []string{"j/monitors all"}, false)
 
-			ipc, err := hypr.NewIPC(ctx)
+			ipc, err := hypr.NewIPC(ctx, testutils.NewTestConfig(t).Get())
 			var monitors hypr.MonitorSpecs
 			if ipc != nil {
 				monitors = ipc.GetConnectedMonitors()
@@ -463,3 +630,69 @@ func TestIPC_GetConnectedMonitors(t *testing.T) {
 		})
 	}
 }
+
+func TestIPC_Healthy(t *testing.T) {
+	if *debug {
+		logrus.SetLevel(logrus.DebugLevel)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	initial, err := os.ReadFile("testdata/monitors_response_valid_1.json")
+	require.NoError(t, err)
+
+	xdgRuntimeDir, signature := testutils.SetupHyprEnvVars(t)
+	cfg := testutils.NewTestConfig(t).WithHyprIPC(&config.HyprIPCSection{
+		ReconnectInitialBackoffMs: utils.IntPtr(10),
+		ReconnectMaxBackoffMs:     utils.IntPtr(50),
+		ReconnectUnhealthyAfterMs: utils.IntPtr(300),
+	}).Get()
+
+	events, _ := testutils.SetupHyprSocket(ctx, t, xdgRuntimeDir, signature, hypr.GetHyprEventsSocket)
+	hyprIPC, _ := testutils.SetupHyprSocket(ctx, t, xdgRuntimeDir, signature, hypr.GetHyprSocket)
+	writerDone := serveMonitors(t, hyprIPC, initial)
+
+	ipc, err := hypr.NewIPC(ctx, cfg)
+	require.NoError(t, err, "failed to create ipc")
+	assert.NoError(t, ipc.Healthy(), "should be healthy before the first connection within the limit")
+
+	// drop the events connection and never come back
+	go func() {
+		conn, err := events.Accept()
+		if err != nil {
+			t.Errorf("Failed to accept connection: %v", err)
+			return
+		}
+		_ = conn.Close()
+		_ = events.Close()
+		_ = hyprIPC.Close()
+	}()
+
+	ipcDone := make(chan error, 1)
+	go func() {
+		ipcDone <- ipc.RunEventLoop(ctx)
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	assert.NoError(t, ipc.Healthy(), "should be healthy while reconnecting within the limit")
+
+	require.Eventually(t, func() bool {
+		return ipc.Healthy() != nil
+	}, 2*time.Second, 10*time.Millisecond, "should be unhealthy once reconnecting takes too long")
+	assert.Contains(t, ipc.Healthy().Error(), "not connected to the hyprland events socket")
+
+	cancel()
+	select {
+	case err := <-ipcDone:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(1 * time.Second):
+		t.Fatal("IPC didn't finish in time")
+	}
+	select {
+	case <-writerDone:
+	case <-time.After(1 * time.Second):
+		t.Error("Server didn't finish in time")
+	}
+	assert.Equal(t, "hyprland event loop is not running", ipc.Healthy().Error())
+}
@@ -0,0 +1,265 @@
+package hypr
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	monitorLinePrefix  = regexp.MustCompile(`^monitor\s*=\s*`)
+	monitorResolution  = regexp.MustCompile(`^(preferred|highres|highrr|maxwidth|\d+x\d+(@\d+(\.\d+)?(Hz)?)?)$`)
+	monitorPosition    = regexp.MustCompile(`^(auto(-(right|left|up|down|center-(right|left|up|down)))?|-?\d+x-?\d+)$`)
+	monitorIntegerArgs = map[string][2]int{
+		"transform": {0, 7},
+		"vrr":       {0, 2},
+		"bitdepth":  {8, 10},
+	}
+	monitorFloatArgs = map[string]bool{
+		"sdrbrightness":     true,
+		"sdrsaturation":     true,
+		"sdr_min_luminance": true,
+		"sdr_max_luminance": true,
+		"min_luminance":     true,
+		"max_luminance":     true,
+		"max_avg_luminance": true,
+	}
+	monitorStringArgs = map[string]bool{
+		"mirror": true,
+		"cm":     true,
+		"icc":    true,
+	}
+)
+
+// MonitorLineValue returns the value of a monitor= keyword line without the trailing comment,
+// ok is false for any other line
+func MonitorLineValue(line string) (string, bool) {
+	trimmed := strings.TrimSpace(stripComment(line))
+	loc := monitorLinePrefix.FindStringIndex(trimmed)
+	if loc == nil {
+		return "", false
+	}
+	return trimmed[loc[1]:], true
+}
+
+// KeywordValue returns the trimmed value of a keyword= line without the trailing comment,
+// ok is false for any other line
+func KeywordValue(line, keyword string) (string, bool) {
+	trimmed := strings.TrimSpace(stripComment(line))
+	rest, ok := strings.CutPrefix(trimmed, keyword)
+	if !ok {
+		return "", false
+	}
+	value, ok := strings.CutPrefix(strings.TrimLeft(rest, " \t"), "=")
+	if !ok {
+		return "", false
+	}
+	return strings.TrimSpace(value), true
+}
+
+// stripComment cuts the line at the first #, ## is an escaped # in Hyprland configs
+func stripComment(line string) string {
+	var out strings.Builder
+	for i := 0; i < len(line); i++ {
+		if line[i] != '#' {
+			out.WriteByte(line[i])
+			continue
+		}
+		if i+1 < len(line) && line[i+1] == '#' {
+			out.WriteByte('#')
+			i++
+			continue
+		}
+		break
+	}
+	return out.String()
+}
+
+// ValidateMonitorLine checks the value of a monitor= line against the Hyprland syntax:
+// name,resolution,position,scale[,option,value...], name,disable, name,transform,t
+// or name,addreserved,top,bottom,left,right, options that are not known are passed through
+func ValidateMonitorLine(value string) error {
+	fields, err := splitMonitorLine(value)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(fields[1]) {
+	case "disable", "disabled", "addreserved":
+		return nil
+	case "transform":
+		return validateMonitorOption("transform", fields[2])
+	}
+
+	options := fields[4:]
+	for i := 0; i < len(options); i += 2 {
+		if err := validateMonitorOption(options[i], options[i+1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitMonitorLine splits the value of a monitor= line into its trimmed arguments and checks
+// its structure, the option values are not checked
+func splitMonitorLine(value string) ([]string, error) {
+	if strings.Contains(value, "<no value>") {
+		return nil, errors.New("contains <no value>, the template referenced data that does not exist")
+	}
+
+	fields := strings.Split(value, ",")
+	for i, field := range fields {
+		fields[i] = strings.TrimSpace(field)
+	}
+	if len(fields) < 2 {
+		return nil, errors.New("expected at least a name and a resolution")
+	}
+
+	switch strings.ToLower(fields[1]) {
+	case "disable", "disabled":
+		if len(fields) != 2 {
+			return nil, errors.New("disable does not take any arguments")
+		}
+		return fields, nil
+	case "transform":
+		if len(fields) != 3 {
+			return nil, errors.New("transform expects a single value")
+		}
+		return fields, nil
+	case "addreserved":
+		if len(fields) != 6 {
+			return nil, errors.New("addreserved expects top, bottom, left and right")
+		}
+		for _, field := range fields[2:] {
+			if _, err := strconv.Atoi(field); err != nil {
+				return nil, fmt.Errorf("addreserved expects integers, got %q", field)
+			}
+		}
+		return fields, nil
+	}
+
+	if len(fields) < 4 {
+		return nil, errors.New("expected name, resolution, position and scale")
+	}
+	if !monitorResolution.MatchString(fields[1]) {
+		return nil, fmt.Errorf("invalid resolution %q", fields[1])
+	}
+	if !monitorPosition.MatchString(fields[2]) {
+		return nil, fmt.Errorf("invalid position %q", fields[2])
+	}
+	if fields[3] != "auto" {
+		scale, err := strconv.ParseFloat(fields[3], 64)
+		if err != nil || scale <= 0 {
+			return nil, fmt.Errorf("invalid scale %q", fields[3])
+		}
+	}
+
+	options := fields[4:]
+	if len(options)%2 != 0 {
+		return nil, fmt.Errorf("option %q is missing a value", options[len(options)-1])
+	}
+	return fields, nil
+}
+
+func validateMonitorOption(key, value string) error {
+	if bounds, ok := monitorIntegerArgs[key]; ok {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < bounds[0] || parsed > bounds[1] {
+			return fmt.Errorf("invalid %s %q, expecting an integer within [%d, %d]", key, value, bounds[0], bounds[1])
+		}
+		return nil
+	}
+	if monitorFloatArgs[key] {
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("invalid %s %q, expecting a number", key, value)
+		}
+		return nil
+	}
+	if monitorStringArgs[key] {
+		if value == "" {
+			return fmt.Errorf("%s is missing a value", key)
+		}
+		return nil
+	}
+	// Hyprland keeps adding options, e.g. supports_wide_color, the unknown ones are passed through
+	return nil
+}
+
+// MonitorRuleKind distinguishes the forms a monitor= line can take
+type MonitorRuleKind int
+
+const (
+	// ModeMonitorRule sets the resolution, position, scale and options
+	ModeMonitorRule MonitorRuleKind = iota
+	DisableMonitorRule
+	TransformMonitorRule
+	ReservedMonitorRule
+)
+
+// MonitorRule is a parsed monitor= line, Target is the monitor name, a desc: prefixed
+// description or empty for the rule applied to every monitor without a rule of its own
+type MonitorRule struct {
+	Kind       MonitorRuleKind
+	Target     string
+	Resolution string
+	Position   string
+	Scale      string
+	Transform  *int
+	Mirror     string
+}
+
+// ParseMonitorLine checks the structure of a monitor= line and splits it into its arguments,
+// the option values are not validated and an invalid transform is left unset
+func ParseMonitorLine(value string) (*MonitorRule, error) {
+	fields, err := splitMonitorLine(value)
+	if err != nil {
+		return nil, err
+	}
+	rule := &MonitorRule{Target: fields[0]}
+
+	switch strings.ToLower(fields[1]) {
+	case "disable", "disabled":
+		rule.Kind = DisableMonitorRule
+		return rule, nil
+	case "transform":
+		rule.Kind = TransformMonitorRule
+		rule.Transform = parseTransform(fields[2])
+		return rule, nil
+	case "addreserved":
+		rule.Kind = ReservedMonitorRule
+		return rule, nil
+	}
+
+	rule.Kind = ModeMonitorRule
+	rule.Resolution, rule.Position, rule.Scale = fields[1], fields[2], fields[3]
+	options := fields[4:]
+	for i := 0; i < len(options); i += 2 {
+		switch options[i] {
+		case "transform":
+			rule.Transform = parseTransform(options[i+1])
+		case "mirror":
+			rule.Mirror = options[i+1]
+		}
+	}
+	return rule, nil
+}
+
+func parseTransform(value string) *int {
+	bounds := monitorIntegerArgs["transform"]
+	transform, err := strconv.Atoi(value)
+	if err != nil || transform < bounds[0] || transform > bounds[1] {
+		return nil
+	}
+	return &transform
+}
+
+// Matches returns whether the rule targets the monitor, the catch-all rule is not
+// considered a match
+func (r *MonitorRule) Matches(monitor *MonitorSpec) bool {
+	if description, ok := strings.CutPrefix(r.Target, "desc:"); ok {
+		return description != "" && strings.HasPrefix(monitor.Description, description)
+	}
+	return r.Target != "" && r.Target == monitor.Name
+}
@@ -0,0 +1,156 @@
+package hypr_test
+
+import (
+	"testing"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/hypr"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonitorLineValue(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected string
+		ok       bool
+	}{
+		{line: "monitor=eDP-1,preferred,auto,1", expected: "eDP-1,preferred,auto,1", ok: true},
+		{line: "  monitor = eDP-1, disable  # laptop", expected: "eDP-1, disable", ok: true},
+		{line: "monitor=desc:Samsung ##1,preferred,auto,1", expected: "desc:Samsung #1,preferred,auto,1", ok: true},
+		{line: "# monitor=eDP-1,disable", ok: false},
+		{line: "monitorv2 {", ok: false},
+		{line: "workspace = 1, monitor:eDP-1", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			value, ok := hypr.MonitorLineValue(tt.line)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestKeywordValue(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected string
+		ok       bool
+	}{
+		{line: "source = ~/.config/hypr/monitors.conf", expected: "~/.config/hypr/monitors.conf", ok: true},
+		{line: "  source=$HOME/monitors.conf # generated", expected: "$HOME/monitors.conf", ok: true},
+		{line: "# source = monitors.conf", ok: false},
+		{line: "sourced = monitors.conf", ok: false},
+		{line: "exec-once = source env.sh", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			value, ok := hypr.KeywordValue(tt.line, "source")
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestValidateMonitorLine(t *testing.T) {
+	tests := []struct {
+		value  string
+		errMsg string
+	}{
+		{value: "eDP-1,2880x1920@120.00000,0x0,2.0,vrr,1"},
+		{value: "desc:BOE NE135A1M-NY1,2880x1920@120.00,-1920x0,2.00000000,transform,0,vrr,1,bitdepth,10"},
+		{value: ",preferred,auto,1"},
+		{value: "DP-1,1920x1080@60.00Hz,auto,1"},
+		{value: "DP-1, highrr, auto-center-right, auto, mirror, eDP-1, cm, hdr, sdrbrightness, 1.2"},
+		{value: "DP-15,disable"},
+		{value: "eDP-1, transform, 1"},
+		{value: "eDP-1, addreserved, 10, 0, 0, 0"},
+		{value: "eDP-1", errMsg: "expected at least a name and a resolution"},
+		{value: ",auto", errMsg: "expected name, resolution, position and scale"},
+		{value: "eDP-1,1920x,0x0,1", errMsg: "invalid resolution \"1920x\""},
+		{value: "eDP-1,preferred,left,1", errMsg: "invalid position \"left\""},
+		{value: "eDP-1,preferred,auto,0", errMsg: "invalid scale \"0\""},
+		{value: "eDP-1,preferred,auto,1,transform", errMsg: "option \"transform\" is missing a value"},
+		{value: "eDP-1,preferred,auto,1,transform,8", errMsg: "invalid transform \"8\""},
+		{value: "eDP-1,preferred,auto,1,supports_wide_color,1,supports_hdr,-1"},
+		{value: "eDP-1,preferred,auto,1,rotate", errMsg: "option \"rotate\" is missing a value"},
+		{value: "eDP-1,transform,8", errMsg: "invalid transform \"8\""},
+		{value: "eDP-1,disable,1", errMsg: "disable does not take any arguments"},
+		{value: "eDP-1,addreserved,10,0", errMsg: "addreserved expects top, bottom, left and right"},
+		{value: "<no value>,preferred,auto,1", errMsg: "contains <no value>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			err := hypr.ValidateMonitorLine(tt.value)
+			if tt.errMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestParseMonitorLine(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected *hypr.MonitorRule
+		errMsg   string
+	}{
+		{
+			value: "eDP-1, 2880x1920@120, 0x0, 2, transform, 1, mirror, DP-1",
+			expected: &hypr.MonitorRule{
+				Kind: hypr.ModeMonitorRule, Target: "eDP-1", Resolution: "2880x1920@120",
+				Position: "0x0", Scale: "2", Transform: utils.IntPtr(1), Mirror: "DP-1",
+			},
+		},
+		{
+			value: ",preferred,auto,auto",
+			expected: &hypr.MonitorRule{
+				Kind: hypr.ModeMonitorRule, Resolution: "preferred", Position: "auto", Scale: "auto",
+			},
+		},
+		{value: "DP-1,disable", expected: &hypr.MonitorRule{Kind: hypr.DisableMonitorRule, Target: "DP-1"}},
+		{
+			value:    "DP-1,transform,3",
+			expected: &hypr.MonitorRule{Kind: hypr.TransformMonitorRule, Target: "DP-1", Transform: utils.IntPtr(3)},
+		},
+		{value: "DP-1,addreserved,1,2,3,4", expected: &hypr.MonitorRule{Kind: hypr.ReservedMonitorRule, Target: "DP-1"}},
+		{
+			value: "DP-1,preferred,auto,1,supports_wide_color,1,transform,8,bitdepth,12",
+			expected: &hypr.MonitorRule{
+				Kind: hypr.ModeMonitorRule, Target: "DP-1", Resolution: "preferred", Position: "auto", Scale: "1",
+			},
+		},
+		{value: "DP-1,preferred,auto", errMsg: "expected name, resolution, position and scale"},
+		{value: "DP-1,preferred,auto,1,vrr", errMsg: "option \"vrr\" is missing a value"},
+		{value: "DP-1,1920x,auto,1", errMsg: "invalid resolution \"1920x\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			rule, err := hypr.ParseMonitorLine(tt.value)
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rule)
+		})
+	}
+}
+
+func TestMonitorRule_Matches(t *testing.T) {
+	monitor := &hypr.MonitorSpec{Name: "DP-1", Description: "Dell Inc. U2720Q 1234"}
+
+	assert.True(t, (&hypr.MonitorRule{Target: "DP-1"}).Matches(monitor))
+	assert.True(t, (&hypr.MonitorRule{Target: "desc:Dell Inc. U2720Q"}).Matches(monitor))
+	assert.False(t, (&hypr.MonitorRule{Target: "DP-2"}).Matches(monitor))
+	assert.False(t, (&hypr.MonitorRule{Target: "desc:LG"}).Matches(monitor))
+	assert.False(t, (&hypr.MonitorRule{Target: ""}).Matches(monitor))
+}
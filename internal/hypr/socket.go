@@ -1,6 +1,17 @@
 package hypr
 
-import "fmt"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/dial"
+	"github.com/sirupsen/logrus"
+)
+
+const instanceSignatureEnv = "HYPRLAND_INSTANCE_SIGNATURE"
 
 func GetHyprEventsSocket(xdgRuntimeDir, instanceSignature string) string {
 	return fmt.Sprintf("%s/hypr/%s/.socket2.sock", xdgRuntimeDir, instanceSignature)
@@ -9,3 +20,50 @@ func GetHyprEventsSocket(xdgRuntimeDir, instanceSignature string) string {
 func GetHyprSocket(xdgRuntimeDir, instanceSignature string) string {
 	return fmt.Sprintf("%s/hypr/%s/.socket.sock", xdgRuntimeDir, instanceSignature)
 }
+
+// DiscoverInstanceSignature scans $XDG_RUNTIME_DIR/hypr/* for a Hyprland instance accepting connections,
+// the preferred signature wins when alive, otherwise the most recently started instance is returned
+func DiscoverInstanceSignature(ctx context.Context, xdgRuntimeDir, preferred string) (string, error) {
+	entries, err := os.ReadDir(filepath.Join(xdgRuntimeDir, "hypr"))
+	if err != nil {
+		return "", fmt.Errorf("cant list hyprland instances: %w", err)
+	}
+
+	best := ""
+	var bestModTime int64
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		signature := entry.Name()
+		fi, err := os.Stat(GetHyprEventsSocket(xdgRuntimeDir, signature))
+		if err != nil {
+			continue
+		}
+		if !isInstanceAlive(ctx, xdgRuntimeDir, signature) {
+			logrus.WithFields(logrus.Fields{"signature": signature}).Debug("Skipping stale hyprland instance")
+			continue
+		}
+		if signature == preferred {
+			return signature, nil
+		}
+		if best == "" || fi.ModTime().UnixNano() > bestModTime {
+			best = signature
+			bestModTime = fi.ModTime().UnixNano()
+		}
+	}
+
+	if best == "" {
+		return "", errors.New("no running hyprland instance found")
+	}
+	return best, nil
+}
+
+func isInstanceAlive(ctx context.Context, xdgRuntimeDir, signature string) bool {
+	_, teardown, err := dial.GetUnixSocketConnection(ctx, GetHyprSocket(xdgRuntimeDir, signature))
+	if err != nil {
+		return false
+	}
+	teardown()
+	return true
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
 	"github.com/sirupsen/logrus"
 )
 
@@ -108,6 +109,7 @@ const (
 	MonitorUnknown HyprEventType = iota
 	MonitorAdded
 	MonitorRemoved
+	ConfigReloaded
 )
 
 func (m HyprEventType) Value() string {
@@ -116,22 +118,37 @@ func (m HyprEventType) Value() string {
 		return "monitoraddedv2>>"
 	case MonitorRemoved:
 		return "monitorremovedv2>>"
+	case ConfigReloaded:
+		return "configreloaded>>"
 	}
 	return "unknownevent>>"
 }
 
+// Trigger maps the event to the user configurable re-evaluation trigger
+func (m HyprEventType) Trigger() (config.HyprEventTrigger, bool) {
+	switch m {
+	case MonitorAdded:
+		return config.MonitorAddedTrigger, true
+	case MonitorRemoved:
+		return config.MonitorRemovedTrigger, true
+	case ConfigReloaded:
+		return config.ConfigReloadedTrigger, true
+	}
+	return 0, false
+}
+
+func (m HyprEventType) IsMonitorEvent() bool {
+	return m == MonitorAdded || m == MonitorRemoved
+}
+
 type HyprEvent struct {
 	Type    HyprEventType
 	Monitor *MonitorSpec
 }
 
 func (m HyprEvent) Validate() error {
-	switch m.Type {
-	case MonitorAdded:
-	case MonitorRemoved:
-		if m.Monitor == nil {
-			return errors.New("hypr event monitor type does not have the monitor description")
-		}
+	if m.Type.IsMonitorEvent() && m.Monitor == nil {
+		return errors.New("hypr event monitor type does not have the monitor description")
 	}
 
 	return nil
@@ -145,6 +162,11 @@ func extractTypedHyprEvent(line string, eventType HyprEventType) (bool, *HyprEve
 
 	logrus.WithFields(logrus.Fields{"event": line, "as": eventType.Value()}).Debug("trying to parse event")
 
+	// only monitor events carry a payload that is used downstream
+	if !eventType.IsMonitorEvent() {
+		return done, &HyprEvent{Type: eventType}, nil
+	}
+
 	parts := strings.Split(after, ",")
 	if len(parts) != 3 {
 		return done, nil, fmt.Errorf("cant parse event %s", after)
@@ -166,7 +188,7 @@ func extractTypedHyprEvent(line string, eventType HyprEventType) (bool, *HyprEve
 }
 
 func extractHyprEvent(line string) (bool, *HyprEvent, error) {
-	possibleEvents := []HyprEventType{MonitorAdded, MonitorRemoved}
+	possibleEvents := []HyprEventType{MonitorAdded, MonitorRemoved, ConfigReloaded}
 	for _, event := range possibleEvents {
 		ok, parsedEvent, err := extractTypedHyprEvent(line, event)
 		if !ok {
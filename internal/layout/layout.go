@@ -0,0 +1,459 @@
+// Package layout parses a generated Hyprland monitor config back into a layout and checks it
+// for overlapping or unreachable monitors, unavailable modes and broken mirror chains
+package layout
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/hypr"
+)
+
+// epsilon absorbs the rounding of logical sizes with fractional scales
+const epsilon = 0.01
+
+var modeRegex = regexp.MustCompile(`^(\d+)x(\d+)(?:@(\d+(?:\.\d+)?)(?:Hz)?)?$`)
+
+type Check int
+
+const (
+	MalformedLineCheck Check = iota
+	OverlapCheck
+	GapCheck
+	UnavailableModeCheck
+	MirrorCheck
+)
+
+func (c Check) String() string {
+	switch c {
+	case MalformedLineCheck:
+		return "malformed_line"
+	case OverlapCheck:
+		return "overlap"
+	case GapCheck:
+		return "gap"
+	case UnavailableModeCheck:
+		return "unavailable_mode"
+	case MirrorCheck:
+		return "mirror"
+	}
+	return "unknown"
+}
+
+// Issue is a single problem found in the generated layout
+type Issue struct {
+	Check   Check
+	Message string
+}
+
+// Placement is where a connected monitor ends up once the generated monitor rules are applied,
+// the size is logical, i.e. after the scale and the transform
+type Placement struct {
+	Monitor  *hypr.MonitorSpec
+	Rule     *hypr.MonitorRule
+	Disabled bool
+	// Positioned is false for auto positions, these are placed by Hyprland without overlaps
+	Positioned bool
+	X, Y       float64
+	Width      float64
+	Height     float64
+	Scale      float64
+	Transform  int
+}
+
+func (p *Placement) String() string {
+	return fmt.Sprintf("%s (%gx%g at %gx%g)", p.Monitor.Name, p.Width, p.Height, p.X, p.Y)
+}
+
+func (p *Placement) mirrored() bool {
+	return p.Rule != nil && p.Rule.Mirror != "" && p.Rule.Mirror != "none"
+}
+
+// Validate parses the monitor= lines of the generated config and validates the resulting layout
+// of the connected monitors
+func Validate(rendered []byte, monitors hypr.MonitorSpecs) []Issue {
+	placements, issues := Parse(rendered, monitors)
+	issues = append(issues, unavailableModes(placements)...)
+	issues = append(issues, mirrorChains(placements)...)
+	issues = append(issues, overlaps(placements)...)
+	issues = append(issues, gaps(placements)...)
+	return issues
+}
+
+// Parse applies the monitor= lines of the generated config to the connected monitors, the last
+// rule targeting a monitor wins and the catch-all rule applies to monitors without one,
+// monitors without any rule are left out
+func Parse(rendered []byte, monitors hypr.MonitorSpecs) ([]*Placement, []Issue) {
+	issues := []Issue{}
+	rules := []*hypr.MonitorRule{}
+	transforms := []*hypr.MonitorRule{}
+	scanner := bufio.NewScanner(strings.NewReader(string(rendered)))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		value, ok := hypr.MonitorLineValue(scanner.Text())
+		if !ok {
+			continue
+		}
+		rule, err := hypr.ParseMonitorLine(value)
+		if err != nil {
+			issues = append(issues, Issue{
+				Check:   MalformedLineCheck,
+				Message: fmt.Sprintf("line %d %q is malformed: %v", lineNumber, strings.TrimSpace(scanner.Text()), err),
+			})
+			continue
+		}
+		switch rule.Kind {
+		case hypr.ModeMonitorRule, hypr.DisableMonitorRule:
+			rules = append(rules, rule)
+		case hypr.TransformMonitorRule:
+			transforms = append(transforms, rule)
+		case hypr.ReservedMonitorRule:
+		}
+	}
+
+	placements := []*Placement{}
+	for _, monitor := range monitors {
+		rule := findRule(rules, monitor)
+		if rule == nil {
+			continue
+		}
+		placement := &Placement{Monitor: monitor, Rule: rule, Disabled: rule.Kind == hypr.DisableMonitorRule}
+		if !placement.Disabled {
+			transform := 0
+			if rule.Transform != nil {
+				transform = *rule.Transform
+			}
+			for _, transformRule := range transforms {
+				if transformRule.Matches(monitor) {
+					transform = *transformRule.Transform
+				}
+			}
+			place(placement, transform)
+		}
+		placements = append(placements, placement)
+	}
+	return placements, issues
+}
+
+func findRule(rules []*hypr.MonitorRule, monitor *hypr.MonitorSpec) *hypr.MonitorRule {
+	var matched, fallback *hypr.MonitorRule
+	for _, rule := range rules {
+		if rule.Matches(monitor) {
+			matched = rule
+		}
+		if rule.Target == "" {
+			fallback = rule
+		}
+	}
+	if matched != nil {
+		return matched
+	}
+	return fallback
+}
+
+// place computes the logical geometry, named resolutions are approximated with the available modes
+func place(placement *Placement, transform int) {
+	monitor, rule := placement.Monitor, placement.Rule
+	width, height := float64(monitor.Width), float64(monitor.Height)
+	if mode, ok := parseMode(rule.Resolution); ok {
+		width, height = mode.width, mode.height
+	} else if mode, ok := namedMode(rule.Resolution, monitor.AvailableModes); ok {
+		width, height = mode.width, mode.height
+	}
+
+	scale := monitor.Scale
+	if parsed, err := strconv.ParseFloat(rule.Scale, 64); err == nil {
+		scale = parsed
+	}
+	if scale <= 0 {
+		scale = 1
+	}
+	placement.Scale, placement.Transform = scale, transform
+	width, height = width/scale, height/scale
+	if transform%2 == 1 {
+		width, height = height, width
+	}
+	placement.Width, placement.Height = width, height
+
+	var x, y int
+	if _, err := fmt.Sscanf(rule.Position, "%dx%d", &x, &y); err == nil {
+		placement.Positioned = true
+		placement.X, placement.Y = float64(x), float64(y)
+	}
+}
+
+type mode struct {
+	width   float64
+	height  float64
+	refresh *float64
+}
+
+func parseMode(value string) (mode, bool) {
+	groups := modeRegex.FindStringSubmatch(value)
+	if groups == nil {
+		return mode{}, false
+	}
+	width, _ := strconv.ParseFloat(groups[1], 64)
+	height, _ := strconv.ParseFloat(groups[2], 64)
+	parsed := mode{width: width, height: height}
+	if groups[3] != "" {
+		refresh, _ := strconv.ParseFloat(groups[3], 64)
+		parsed.refresh = &refresh
+	}
+	return parsed, true
+}
+
+// namedMode picks the mode Hyprland would use for preferred, highres, highrr and maxwidth,
+// the first available mode stands in for the preferred one
+func namedMode(name string, availableModes []string) (mode, bool) {
+	modes := []mode{}
+	for _, available := range availableModes {
+		if parsed, ok := parseMode(available); ok {
+			modes = append(modes, parsed)
+		}
+	}
+	if len(modes) == 0 {
+		return mode{}, false
+	}
+
+	best := modes[0]
+	for _, candidate := range modes[1:] {
+		switch name {
+		case "highres":
+			if candidate.width*candidate.height > best.width*best.height {
+				best = candidate
+			}
+		case "maxwidth":
+			if candidate.width > best.width {
+				best = candidate
+			}
+		case "highrr":
+			if refreshOf(candidate) > refreshOf(best) {
+				best = candidate
+			}
+		}
+	}
+	return best, true
+}
+
+func refreshOf(m mode) float64 {
+	if m.refresh == nil {
+		return 0
+	}
+	return *m.refresh
+}
+
+// unavailableModes reports explicit resolutions the monitor does not advertise, the refresh rate
+// is compared with a 1Hz tolerance since Hyprland picks the closest one
+func unavailableModes(placements []*Placement) []Issue {
+	issues := []Issue{}
+	for _, placement := range placements {
+		if placement.Disabled || len(placement.Monitor.AvailableModes) == 0 {
+			continue
+		}
+		requested, ok := parseMode(placement.Rule.Resolution)
+		if !ok {
+			continue
+		}
+		found := false
+		for _, available := range placement.Monitor.AvailableModes {
+			candidate, ok := parseMode(available)
+			if !ok || candidate.width != requested.width || candidate.height != requested.height {
+				continue
+			}
+			if requested.refresh == nil || math.Abs(refreshOf(candidate)-*requested.refresh) < 1 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			issues = append(issues, Issue{
+				Check: UnavailableModeCheck,
+				Message: fmt.Sprintf("mode %s is not available on %s, available modes: %s",
+					placement.Rule.Resolution, placement.Monitor.Name,
+					strings.Join(placement.Monitor.AvailableModes, ", ")),
+			})
+		}
+	}
+	return issues
+}
+
+// mirrorChains reports mirrors of monitors that are not connected or disabled,
+// mirrors of mirrors and loops
+func mirrorChains(placements []*Placement) []Issue {
+	issues := []Issue{}
+	targets := map[string]*Placement{}
+	for _, placement := range placements {
+		if !placement.mirrored() {
+			continue
+		}
+		var target *Placement
+		for _, candidate := range placements {
+			rule := &hypr.MonitorRule{Target: placement.Rule.Mirror}
+			if rule.Matches(candidate.Monitor) {
+				target = candidate
+				break
+			}
+		}
+		name := placement.Monitor.Name
+		switch {
+		case target == nil:
+			issues = append(issues, Issue{
+				Check:   MirrorCheck,
+				Message: fmt.Sprintf("%s mirrors %s which is not connected", name, placement.Rule.Mirror),
+			})
+		case target.Disabled:
+			issues = append(issues, Issue{
+				Check:   MirrorCheck,
+				Message: fmt.Sprintf("%s mirrors %s which is disabled", name, target.Monitor.Name),
+			})
+		default:
+			targets[name] = target
+		}
+	}
+
+	reported := map[string]bool{}
+	names := make([]string, 0, len(targets))
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		target := targets[name]
+		if !target.mirrored() {
+			continue
+		}
+		chain := []string{name}
+		visited := map[string]bool{name: true}
+		loop := false
+		for current := target; current != nil; current = targets[current.Monitor.Name] {
+			chain = append(chain, current.Monitor.Name)
+			if visited[current.Monitor.Name] {
+				loop = true
+				break
+			}
+			visited[current.Monitor.Name] = true
+		}
+		if loop {
+			key := loopKey(chain)
+			if reported[key] {
+				continue
+			}
+			reported[key] = true
+			issues = append(issues, Issue{
+				Check:   MirrorCheck,
+				Message: "mirror loop " + strings.Join(chain, " -> "),
+			})
+			continue
+		}
+		issues = append(issues, Issue{
+			Check:   MirrorCheck,
+			Message: fmt.Sprintf("%s mirrors %s which is itself a mirror: %s", name, target.Monitor.Name, strings.Join(chain, " -> ")),
+		})
+	}
+	return issues
+}
+
+// loopKey identifies a loop regardless of the monitor it was found from
+func loopKey(chain []string) string {
+	start := slices.Index(chain, chain[len(chain)-1])
+	members := append([]string{}, chain[start:len(chain)-1]...)
+	sort.Strings(members)
+	return strings.Join(members, ",")
+}
+
+// active returns the monitors that take up space in the layout at a known position
+func active(placements []*Placement) ([]*Placement, bool) {
+	result := []*Placement{}
+	allPositioned := true
+	for _, placement := range placements {
+		if placement.Disabled || placement.mirrored() {
+			continue
+		}
+		if !placement.Positioned {
+			allPositioned = false
+			continue
+		}
+		result = append(result, placement)
+	}
+	return result, allPositioned
+}
+
+func overlaps(placements []*Placement) []Issue {
+	issues := []Issue{}
+	positioned, _ := active(placements)
+	for i, a := range positioned {
+		for _, b := range positioned[i+1:] {
+			width := math.Min(a.X+a.Width, b.X+b.Width) - math.Max(a.X, b.X)
+			height := math.Min(a.Y+a.Height, b.Y+b.Height) - math.Max(a.Y, b.Y)
+			if width > epsilon && height > epsilon {
+				issues = append(issues, Issue{
+					Check:   OverlapCheck,
+					Message: fmt.Sprintf("%s overlaps %s", a, b),
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// gaps reports layouts where the cursor cannot travel between all monitors, i.e. the monitors
+// do not form a single group of edge sharing rectangles, skipped when Hyprland places any monitor
+func gaps(placements []*Placement) []Issue {
+	positioned, allPositioned := active(placements)
+	if !allPositioned || len(positioned) < 2 {
+		return []Issue{}
+	}
+
+	group := make([]int, len(positioned))
+	for i := range group {
+		group[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if group[i] != i {
+			group[i] = find(group[i])
+		}
+		return group[i]
+	}
+	for i, a := range positioned {
+		for j := i + 1; j < len(positioned); j++ {
+			if adjacent(a, positioned[j]) {
+				group[find(i)] = find(j)
+			}
+		}
+	}
+
+	groups := map[int][]string{}
+	roots := []int{}
+	for i, placement := range positioned {
+		root := find(i)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], placement.String())
+	}
+	if len(roots) < 2 {
+		return []Issue{}
+	}
+	described := []string{}
+	for _, root := range roots {
+		described = append(described, "["+strings.Join(groups[root], ", ")+"]")
+	}
+	return []Issue{{
+		Check:   GapCheck,
+		Message: "monitors are not connected edge to edge, the cursor cannot move between " + strings.Join(described, " and "),
+	}}
+}
+
+// adjacent returns whether the monitors overlap or share a part of an edge
+func adjacent(a, b *Placement) bool {
+	width := math.Min(a.X+a.Width, b.X+b.Width) - math.Max(a.X, b.X)
+	height := math.Min(a.Y+a.Height, b.Y+b.Height) - math.Max(a.Y, b.Y)
+	return (width > epsilon && height > -epsilon) || (height > epsilon && width > -epsilon)
+}
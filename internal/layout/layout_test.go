@@ -0,0 +1,202 @@
+package layout_test
+
+import (
+	"testing"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/hypr"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/layout"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMonitors() hypr.MonitorSpecs {
+	return hypr.MonitorSpecs{
+		{
+			ID: utils.IntPtr(0), Name: "eDP-1", Description: "BOE NE135A1M-NY1",
+			Width: 2880, Height: 1920, RefreshRate: 120, Scale: 2,
+			AvailableModes: []string{"2880x1920@120.00Hz", "2880x1920@60.00Hz"},
+		},
+		{
+			ID: utils.IntPtr(1), Name: "DP-1", Description: "Dell Inc. DELL U2723QE",
+			Width: 3840, Height: 2160, RefreshRate: 60, Scale: 1,
+			AvailableModes: []string{"3840x2160@60.00Hz", "2560x1440@59.95Hz", "1920x1080@60.00Hz"},
+		},
+		{
+			ID: utils.IntPtr(2), Name: "HDMI-A-1", Description: "LG Electronics LG ULTRAGEAR",
+			Width: 1920, Height: 1080, RefreshRate: 144, Scale: 1,
+			AvailableModes: []string{"1920x1080@144.00Hz", "1920x1080@60.00Hz"},
+		},
+	}
+}
+
+func TestParse(t *testing.T) {
+	rendered := `
+# laptop
+monitor = eDP-1, 2880x1920@120, 0x0, 2
+monitor = desc:Dell Inc. DELL U2723QE, preferred, 1440x0, 1.5, transform, 1
+monitor = , preferred, auto, 1
+monitor = HDMI-A-1, 1920x1080@60, 0x0, 1
+monitor = HDMI-A-1, disable
+monitor = eDP-1, transform, 1
+monitor = eDP-1, addreserved, 10, 0, 0, 0
+`
+	placements, issues := layout.Parse([]byte(rendered), testMonitors())
+	assert.Empty(t, issues)
+	require.Len(t, placements, 3)
+
+	assert.Equal(t, "eDP-1", placements[0].Monitor.Name)
+	assert.True(t, placements[0].Positioned)
+	assert.Equal(t, []float64{0, 0, 960, 1440},
+		[]float64{placements[0].X, placements[0].Y, placements[0].Width, placements[0].Height})
+
+	assert.Equal(t, "DP-1", placements[1].Monitor.Name)
+	assert.Equal(t, []float64{1440, 0, 1440, 2560},
+		[]float64{placements[1].X, placements[1].Y, placements[1].Width, placements[1].Height})
+	assert.Equal(t, 1.5, placements[1].Scale)
+	assert.Equal(t, 1, placements[1].Transform)
+
+	assert.Equal(t, "HDMI-A-1", placements[2].Monitor.Name)
+	assert.True(t, placements[2].Disabled)
+}
+
+func TestParse_CatchAllRule(t *testing.T) {
+	placements, issues := layout.Parse([]byte("monitor=,highres,auto,auto\nmonitor=DP-1,badres,0x0,1"), testMonitors())
+	require.Len(t, issues, 1)
+	assert.Equal(t, layout.MalformedLineCheck, issues[0].Check)
+	require.Len(t, placements, 3)
+	for _, placement := range placements {
+		assert.False(t, placement.Positioned)
+	}
+	assert.Equal(t, 1440.0, placements[0].Width, "auto scale keeps the current one")
+	assert.Equal(t, 3840.0, placements[1].Width, "highres picks the largest mode")
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		rendered string
+		checks   []layout.Check
+		messages []string
+	}{
+		{
+			name: "valid side by side layout",
+			rendered: `monitor=eDP-1,2880x1920@120,0x0,2
+monitor=DP-1,3840x2160@60,1440x0,1
+monitor=HDMI-A-1,1920x1080@144,5280x0,1`,
+		},
+		{
+			name: "valid stacked layout with a fractional scale",
+			rendered: `monitor=DP-1,3840x2160@60,0x0,1.5
+monitor=eDP-1,2880x1920@120,0x1440,2
+monitor=HDMI-A-1,disable`,
+		},
+		{
+			name: "options unknown to the checks are passed through",
+			rendered: `monitor=eDP-1,2880x1920@120,0x0,2,supports_wide_color,1,supports_hdr,1
+monitor=DP-1,3840x2160@60,1440x0,1,bitdepth,12
+monitor=HDMI-A-1,disable`,
+		},
+		{
+			name:     "odd number of option arguments",
+			rendered: `monitor=eDP-1,2880x1920@120,0x0,2,supports_hdr`,
+			checks:   []layout.Check{layout.MalformedLineCheck},
+		},
+		{
+			name: "overlap",
+			rendered: `monitor=eDP-1,2880x1920@120,0x0,2
+monitor=DP-1,3840x2160@60,1000x0,1`,
+			checks:   []layout.Check{layout.OverlapCheck},
+			messages: []string{"eDP-1 (1440x960 at 0x0) overlaps DP-1 (3840x2160 at 1000x0)"},
+		},
+		{
+			name: "rotation makes the monitors overlap",
+			rendered: `monitor=eDP-1,2880x1920@120,0x0,2
+monitor=DP-1,3840x2160@60,0x960,1,transform,3
+monitor=HDMI-A-1,1920x1080@144,1440x0,1`,
+			checks:   []layout.Check{layout.OverlapCheck},
+			messages: []string{"DP-1 (2160x3840 at 0x960) overlaps HDMI-A-1 (1920x1080 at 1440x0)"},
+		},
+		{
+			name: "gap between monitors",
+			rendered: `monitor=eDP-1,2880x1920@120,0x0,2
+monitor=DP-1,3840x2160@60,1500x0,1
+monitor=HDMI-A-1,1920x1080@144,5340x0,1`,
+			checks: []layout.Check{layout.GapCheck},
+			messages: []string{
+				"the cursor cannot move between [eDP-1 (1440x960 at 0x0)] and [DP-1 (3840x2160 at 1500x0), HDMI-A-1 (1920x1080 at 5340x0)]",
+			},
+		},
+		{
+			name: "monitors touching only at a corner are not reachable",
+			rendered: `monitor=eDP-1,2880x1920@120,0x0,2
+monitor=HDMI-A-1,1920x1080@144,1440x960,1
+monitor=DP-1,disable`,
+			checks: []layout.Check{layout.GapCheck},
+		},
+		{
+			name: "auto positions skip the gap check",
+			rendered: `monitor=eDP-1,2880x1920@120,0x0,2
+monitor=DP-1,3840x2160@60,5000x0,1
+monitor=HDMI-A-1,preferred,auto,1`,
+		},
+		{
+			name: "unavailable modes",
+			rendered: `monitor=eDP-1,2880x1920@90,0x0,2
+monitor=DP-1,2560x1440@60,1440x0,1
+monitor=HDMI-A-1,2560x1440,4000x0,1`,
+			checks:   []layout.Check{layout.UnavailableModeCheck, layout.UnavailableModeCheck},
+			messages: []string{"mode 2880x1920@90 is not available on eDP-1", "mode 2560x1440 is not available on HDMI-A-1"},
+		},
+		{
+			name: "mirrors are not part of the layout",
+			rendered: `monitor=eDP-1,2880x1920@120,0x0,2
+monitor=DP-1,3840x2160@60,0x0,1,mirror,eDP-1
+monitor=HDMI-A-1,1920x1080@144,1440x0,1,mirror,desc:BOE NE135A1M`,
+		},
+		{
+			name: "mirror of a disabled or missing monitor",
+			rendered: `monitor=eDP-1,disable
+monitor=DP-1,3840x2160@60,0x0,1,mirror,eDP-1
+monitor=HDMI-A-1,1920x1080@144,0x0,1,mirror,DP-5`,
+			checks: []layout.Check{layout.MirrorCheck, layout.MirrorCheck},
+			messages: []string{
+				"DP-1 mirrors eDP-1 which is disabled",
+				"HDMI-A-1 mirrors DP-5 which is not connected",
+			},
+		},
+		{
+			name: "mirror chain",
+			rendered: `monitor=eDP-1,2880x1920@120,0x0,2
+monitor=DP-1,3840x2160@60,0x0,1,mirror,eDP-1
+monitor=HDMI-A-1,1920x1080@144,0x0,1,mirror,DP-1`,
+			checks:   []layout.Check{layout.MirrorCheck},
+			messages: []string{"HDMI-A-1 mirrors DP-1 which is itself a mirror: HDMI-A-1 -> DP-1 -> eDP-1"},
+		},
+		{
+			name: "mirror loop",
+			rendered: `monitor=eDP-1,2880x1920@120,0x0,2
+monitor=DP-1,3840x2160@60,0x0,1,mirror,HDMI-A-1
+monitor=HDMI-A-1,1920x1080@144,0x0,1,mirror,DP-1`,
+			checks:   []layout.Check{layout.MirrorCheck},
+			messages: []string{"mirror loop DP-1 -> HDMI-A-1 -> DP-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := layout.Validate([]byte(tt.rendered), testMonitors())
+			checks := []layout.Check{}
+			for _, issue := range issues {
+				checks = append(checks, issue.Check)
+			}
+			if tt.checks == nil {
+				tt.checks = []layout.Check{}
+			}
+			assert.Equal(t, tt.checks, checks, "%v", issues)
+			for i, message := range tt.messages {
+				assert.Contains(t, issues[i].Message, message)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Package logind manages systemd-logind inhibitor locks
+package logind
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	login1Destination = "org.freedesktop.login1"
+	login1Path        = "/org/freedesktop/login1"
+	inhibitMethod     = "org.freedesktop.login1.Manager.Inhibit"
+	lidSwitchWhat     = "handle-lid-switch"
+	inhibitWho        = "hyprdynamicmonitors"
+	inhibitWhy        = "Clamshell profile is active"
+	inhibitMode       = "block"
+)
+
+// LidSwitchInhibitor holds a "handle-lid-switch" inhibitor lock so logind does not suspend
+// the machine while a clamshell profile is active, the lock lives as long as its fd is open
+type LidSwitchInhibitor struct {
+	connect func() (*dbus.Conn, error)
+	conn    *dbus.Conn
+	lock    *os.File
+	mu      sync.Mutex
+}
+
+// NewLidSwitchInhibitor creates an inhibitor that connects to the bus lazily on the first acquire
+func NewLidSwitchInhibitor(connect func() (*dbus.Conn, error)) *LidSwitchInhibitor {
+	return &LidSwitchInhibitor{connect: connect}
+}
+
+// Set acquires the lock when inhibit is true and releases it otherwise, it is a no-op
+// when the lock is already in the requested state
+func (l *LidSwitchInhibitor) Set(ctx context.Context, inhibit bool) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if inhibit == (l.lock != nil) {
+		return nil
+	}
+	if !inhibit {
+		return l.releaseLocked()
+	}
+	return l.acquireLocked(ctx)
+}
+
+// Held reports whether the inhibitor lock is currently taken
+func (l *LidSwitchInhibitor) Held() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lock != nil
+}
+
+// Release drops the lock if held, it should be called on shutdown
+func (l *LidSwitchInhibitor) Release() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.releaseLocked()
+}
+
+func (l *LidSwitchInhibitor) acquireLocked(ctx context.Context) error {
+	if l.conn == nil || !l.conn.Connected() {
+		conn, err := l.connect()
+		if err != nil {
+			return fmt.Errorf("cant connect to dbus: %w", err)
+		}
+		l.conn = conn
+	}
+
+	var fd dbus.UnixFD
+	obj := l.conn.Object(login1Destination, dbus.ObjectPath(login1Path))
+	if err := obj.CallWithContext(ctx, inhibitMethod, 0, lidSwitchWhat, inhibitWho, inhibitWhy,
+		inhibitMode).Store(&fd); err != nil {
+		return fmt.Errorf("cant take the %s inhibitor lock: %w", lidSwitchWhat, err)
+	}
+
+	l.lock = os.NewFile(uintptr(fd), lidSwitchWhat+"-inhibitor")
+	logrus.Info("Took the logind lid switch inhibitor lock")
+	return nil
+}
+
+func (l *LidSwitchInhibitor) releaseLocked() error {
+	if l.lock == nil {
+		return nil
+	}
+	err := l.lock.Close()
+	l.lock = nil
+	if err != nil {
+		return fmt.Errorf("cant release the %s inhibitor lock: %w", lidSwitchWhat, err)
+	}
+	logrus.Info("Released the logind lid switch inhibitor lock")
+	return nil
+}
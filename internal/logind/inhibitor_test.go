@@ -0,0 +1,86 @@
+package logind_test
+
+import (
+	"context"
+	"os"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/logind"
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLogin1 struct {
+	calls  [][]string
+	writes []*os.File
+	mu     sync.Mutex
+}
+
+func (f *fakeLogin1) Inhibit(what, who, why, mode string) (dbus.UnixFD, *dbus.Error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, []string{what, who, why, mode})
+	_, w, err := os.Pipe()
+	if err != nil {
+		return 0, dbus.MakeFailedError(err)
+	}
+	f.writes = append(f.writes, w)
+	return dbus.UnixFD(w.Fd()), nil
+}
+
+func (f *fakeLogin1) received() [][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.calls)
+}
+
+func setupFakeLogin1(t *testing.T) *fakeLogin1 {
+	conn, err := dbus.ConnectSessionBus()
+	require.NoError(t, err, "failed to connect to session bus")
+	t.Cleanup(func() { _ = conn.Close() })
+
+	reply, err := conn.RequestName("org.freedesktop.login1", dbus.NameFlagDoNotQueue)
+	require.NoError(t, err)
+	if reply != dbus.RequestNameReplyPrimaryOwner {
+		t.Skip("org.freedesktop.login1 is already owned on the session bus")
+	}
+
+	fake := &fakeLogin1{}
+	t.Cleanup(func() {
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		for _, w := range fake.writes {
+			_ = w.Close()
+		}
+	})
+	require.NoError(t, conn.Export(fake, "/org/freedesktop/login1", "org.freedesktop.login1.Manager"))
+	return fake
+}
+
+func TestLidSwitchInhibitor(t *testing.T) {
+	fake := setupFakeLogin1(t)
+	inhibitor := logind.NewLidSwitchInhibitor(func() (*dbus.Conn, error) { return dbus.ConnectSessionBus() })
+	ctx := context.Background()
+
+	require.NoError(t, inhibitor.Set(ctx, true))
+	assert.True(t, inhibitor.Held())
+	require.NoError(t, inhibitor.Set(ctx, true), "acquiring twice should be a no-op")
+	calls := fake.received()
+	require.Len(t, calls, 1)
+	assert.Equal(t, []string{"handle-lid-switch", "hyprdynamicmonitors", "Clamshell profile is active", "block"},
+		calls[0])
+
+	require.NoError(t, inhibitor.Set(ctx, false))
+	assert.False(t, inhibitor.Held())
+	require.NoError(t, inhibitor.Set(ctx, false), "releasing twice should be a no-op")
+
+	require.NoError(t, inhibitor.Set(ctx, true))
+	assert.True(t, inhibitor.Held())
+	assert.Len(t, fake.received(), 2, "switching back should take a new lock")
+	require.NoError(t, inhibitor.Release())
+	assert.False(t, inhibitor.Held())
+	assert.NoError(t, inhibitor.Release(), "releasing twice should be a no-op")
+}
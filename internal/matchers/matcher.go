@@ -17,7 +17,7 @@ func NewMatcher() *Matcher {
 }
 
 func (m *Matcher) Match(cfg *config.RawConfig, connectedMonitors []*hypr.MonitorSpec,
-	powerState power.PowerState, lidState power.LidState,
+	powerState power.PowerState, lidState power.LidState, batteryState power.BatteryState,
 ) (bool, *MatchedProfile, error) {
 	score := make(map[string]int)
 	profileRules := make(map[string]map[int]*config.RequiredMonitor)
@@ -29,8 +29,8 @@ func (m *Matcher) Match(cfg *config.RawConfig, connectedMonitors []*hypr.Monitor
 
 	for name, profile := range profiles {
 		conditions := profile.Conditions
-		fullMatchScore := m.calcFullProfileScore(cfg, conditions)
-		profileScore, profileRule := m.scoreProfile(cfg, conditions, powerState, lidState, connectedMonitors)
+		fullMatchScore := m.FullProfileScore(cfg, conditions)
+		profileScore, profileRule := m.scoreProfile(cfg, conditions, powerState, lidState, batteryState, connectedMonitors)
 		score[name] = profileScore
 		profileRules[name] = profileRule
 		logrus.Debugf("Profile %s score %d, full match %d", name, score[name], fullMatchScore)
@@ -63,6 +63,15 @@ func (m *Matcher) Match(cfg *config.RawConfig, connectedMonitors []*hypr.Monitor
 	return ok, NewFallbackProfile(fallbackProfile), nil
 }
 
+// Score returns the score of a single profile, the score it needs to be a candidate and the
+// profile with the monitors its required monitors matched, used to inspect profiles that lost
+func (m *Matcher) Score(cfg *config.RawConfig, profile *config.Profile, connectedMonitors []*hypr.MonitorSpec,
+	powerState power.PowerState, lidState power.LidState, batteryState power.BatteryState,
+) (int, int, *MatchedProfile) {
+	score, rules := m.scoreProfile(cfg, profile.Conditions, powerState, lidState, batteryState, connectedMonitors)
+	return score, m.FullProfileScore(cfg, profile.Conditions), NewMatchedProfile(profile, rules)
+}
+
 func (m *Matcher) returnNoneOrFallback(cfg *config.RawConfig) (bool, *config.Profile) {
 	if cfg.FallbackProfile != nil {
 		return true, cfg.FallbackProfile
@@ -71,7 +80,8 @@ func (m *Matcher) returnNoneOrFallback(cfg *config.RawConfig) (bool, *config.Pro
 }
 
 func (m *Matcher) scoreProfile(cfg *config.RawConfig, conditions *config.ProfileCondition,
-	powerState power.PowerState, lidState power.LidState, connectedMonitors []*hypr.MonitorSpec,
+	powerState power.PowerState, lidState power.LidState, batteryState power.BatteryState,
+	connectedMonitors []*hypr.MonitorSpec,
 ) (int, map[int]*config.RequiredMonitor) {
 	monitorToRule := make(map[int]*config.RequiredMonitor)
 	profileScore := 0
@@ -83,6 +93,18 @@ func (m *Matcher) scoreProfile(cfg *config.RawConfig, conditions *config.Profile
 		profileScore += *cfg.Scoring.LidStateMatch
 	}
 
+	if conditions.BatteryBelow != nil && batteryState.IsBelow(*conditions.BatteryBelow) {
+		profileScore += *cfg.Scoring.BatteryMatch
+	}
+
+	if conditions.BatteryAbove != nil && batteryState.IsAbove(*conditions.BatteryAbove) {
+		profileScore += *cfg.Scoring.BatteryMatch
+	}
+
+	if conditions.PowerProfile != nil && conditions.PowerProfile.Value() == batteryState.PowerProfile.String() {
+		profileScore += *cfg.Scoring.PowerProfileMatch
+	}
+
 	usedMonitors := map[int]bool{}
 	for _, connectedMonitor := range connectedMonitors {
 		usedMonitors[*connectedMonitor.ID] = false
@@ -145,7 +167,8 @@ func (m *Matcher) scoreProfile(cfg *config.RawConfig, conditions *config.Profile
 	return profileScore, monitorToRule
 }
 
-func (m *Matcher) calcFullProfileScore(cfg *config.RawConfig, conditions *config.ProfileCondition) int {
+// FullProfileScore is the score of a profile when all of its conditions match
+func (m *Matcher) FullProfileScore(cfg *config.RawConfig, conditions *config.ProfileCondition) int {
 	fullMatchScore := 0
 	if conditions.PowerState != nil {
 		fullMatchScore += *cfg.Scoring.PowerStateMatch
@@ -155,6 +178,18 @@ func (m *Matcher) calcFullProfileScore(cfg *config.RawConfig, conditions *config
 		fullMatchScore += *cfg.Scoring.LidStateMatch
 	}
 
+	if conditions.BatteryBelow != nil {
+		fullMatchScore += *cfg.Scoring.BatteryMatch
+	}
+
+	if conditions.BatteryAbove != nil {
+		fullMatchScore += *cfg.Scoring.BatteryMatch
+	}
+
+	if conditions.PowerProfile != nil {
+		fullMatchScore += *cfg.Scoring.PowerProfileMatch
+	}
+
 	for _, condition := range conditions.RequiredMonitors {
 		if condition.HasName() {
 			fullMatchScore += *cfg.Scoring.NameMatch
@@ -19,6 +19,7 @@ func TestMatcher_Match(t *testing.T) {
 		connectedMonitors []*hypr.MonitorSpec
 		powerState        power.PowerState
 		lidState          power.LidState
+		batteryState      power.BatteryState
 		expectedProfile   string
 		// profile name or empty string for no match
 		expectedMonitorToRule map[int]*config.RequiredMonitor
@@ -1241,13 +1242,110 @@ func TestMatcher_Match(t *testing.T) {
 			expectedProfile: "specific_with_desc",
 			description:     "Most specific regex with description (25pts) should win over less specific regex patterns (20pts each)",
 		},
+		{
+			name: "battery_below_scoring",
+			config: createTestConfig(t, map[string]*config.Profile{
+				"whatever": {
+					Name: "whatever",
+					Conditions: &config.ProfileCondition{
+						RequiredMonitors: []*config.RequiredMonitor{
+							{Name: utils.StringPtr("eDP-1")},
+						},
+					},
+				},
+				"low_battery": {
+					Name: "low_battery",
+					Conditions: &config.ProfileCondition{
+						BatteryBelow: utils.IntPtr(20),
+						RequiredMonitors: []*config.RequiredMonitor{
+							{Name: utils.StringPtr("eDP-1")},
+						},
+					},
+				},
+			}).Get(),
+			connectedMonitors: []*hypr.MonitorSpec{
+				{Name: "eDP-1", ID: utils.IntPtr(0), Description: "Built-in Display"},
+			},
+			batteryState:    power.NewBatteryState(15, power.BalancedPowerProfile),
+			expectedProfile: "low_battery",
+			description:     "Profile with a satisfied battery threshold should win",
+		},
+		{
+			name: "battery_above_not_satisfied",
+			config: createTestConfig(t, map[string]*config.Profile{
+				"high_battery": {
+					Name: "high_battery",
+					Conditions: &config.ProfileCondition{
+						BatteryAbove: utils.IntPtr(50),
+						RequiredMonitors: []*config.RequiredMonitor{
+							{Name: utils.StringPtr("eDP-1")},
+						},
+					},
+				},
+			}).Get(),
+			connectedMonitors: []*hypr.MonitorSpec{
+				{Name: "eDP-1", ID: utils.IntPtr(0), Description: "Built-in Display"},
+			},
+			batteryState:    power.NewBatteryState(30, power.BalancedPowerProfile),
+			expectedProfile: "",
+			description:     "Profile requiring a higher battery percentage should not match",
+		},
+		{
+			name: "battery_unknown",
+			config: createTestConfig(t, map[string]*config.Profile{
+				"low_battery": {
+					Name: "low_battery",
+					Conditions: &config.ProfileCondition{
+						BatteryBelow: utils.IntPtr(20),
+						RequiredMonitors: []*config.RequiredMonitor{
+							{Name: utils.StringPtr("eDP-1")},
+						},
+					},
+				},
+			}).Get(),
+			connectedMonitors: []*hypr.MonitorSpec{
+				{Name: "eDP-1", ID: utils.IntPtr(0), Description: "Built-in Display"},
+			},
+			expectedProfile: "",
+			description:     "Battery conditions never match when battery events are disabled",
+		},
+		{
+			name: "power_profile_scoring",
+			config: createTestConfig(t, map[string]*config.Profile{
+				"balanced": {
+					Name: "balanced",
+					Conditions: &config.ProfileCondition{
+						PowerProfile: utils.JustPtr(config.BalancedProfileType),
+						RequiredMonitors: []*config.RequiredMonitor{
+							{Name: utils.StringPtr("eDP-1")},
+						},
+					},
+				},
+				"saver": {
+					Name: "saver",
+					Conditions: &config.ProfileCondition{
+						PowerProfile: utils.JustPtr(config.PowerSaverProfileType),
+						RequiredMonitors: []*config.RequiredMonitor{
+							{Name: utils.StringPtr("eDP-1")},
+						},
+					},
+				},
+			}).Get(),
+			connectedMonitors: []*hypr.MonitorSpec{
+				{Name: "eDP-1", ID: utils.IntPtr(0), Description: "Built-in Display"},
+			},
+			batteryState:    power.NewBatteryState(80, power.PowerSaverPowerProfile),
+			expectedProfile: "saver",
+			description:     "Profile with the active power profile should win",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			matcher := matchers.NewMatcher()
 
-			found, result, err := matcher.Match(tt.config.Get(), tt.connectedMonitors, tt.powerState, tt.lidState)
+			found, result, err := matcher.Match(tt.config.Get(), tt.connectedMonitors, tt.powerState, tt.lidState,
+				tt.batteryState)
 			if err != nil {
 				t.Fatalf("Match returned unexpected error: %v", err)
 			}
@@ -1277,6 +1375,29 @@ func TestMatcher_Match(t *testing.T) {
 	}
 }
 
+func TestMatcher_Score(t *testing.T) {
+	cfg := createTestConfig(t, map[string]*config.Profile{
+		"docked": {
+			Name: "docked",
+			Conditions: &config.ProfileCondition{
+				PowerState: utils.JustPtr(config.AC),
+				RequiredMonitors: []*config.RequiredMonitor{
+					{Name: utils.StringPtr("eDP-1")},
+					{Name: utils.StringPtr("DP-1")},
+				},
+			},
+		},
+	}).Get().Get()
+	monitors := []*hypr.MonitorSpec{{Name: "eDP-1", ID: utils.IntPtr(0)}}
+
+	score, full, profile := matchers.NewMatcher().Score(cfg, cfg.Profiles["docked"], monitors,
+		power.ACPowerState, power.UnknownLidState, power.BatteryState{})
+	assert.Equal(t, 13, score)
+	assert.Equal(t, 23, full)
+	assert.Equal(t, "docked", profile.Profile.Name)
+	assert.Equal(t, "eDP-1", *profile.MonitorToRule[0].Name)
+}
+
 // Helper function to create test config with default scoring
 func createTestConfig(t *testing.T, profiles map[string]*config.Profile) *testutils.TestConfig {
 	return testutils.NewTestConfig(t).WithProfiles(profiles).WithScoring(&config.ScoringSection{
@@ -3,6 +3,8 @@ package notifications
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/TheCreeper/go-notify"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
@@ -48,3 +50,87 @@ func (s *Service) NotifyProfileApplied(profile *config.Profile, dryRun bool) err
 	logrus.Info("Update notification sent to the user")
 	return nil
 }
+
+func (s *Service) NotifyInvalidConfig(err error) error {
+	if *s.config.Get().Notifications.Disabled {
+		logrus.Debug("notifications are not enabled, not sending")
+		return nil
+	}
+
+	summary := "Invalid configuration, keeping the previous one"
+	ntf := notify.NewNotification(summary, err.Error())
+	ntf.Timeout = *s.config.Get().Notifications.TimeoutMs
+	ntf.Hints = s.hints
+
+	if _, err := ntf.Show(); err != nil {
+		return fmt.Errorf("cant send invalid config notification: %w", err)
+	}
+	logrus.Info("Invalid config notification sent to the user")
+	return nil
+}
+
+func (s *Service) NotifyConfigRecovered() error {
+	if *s.config.Get().Notifications.Disabled {
+		logrus.Debug("notifications are not enabled, not sending")
+		return nil
+	}
+
+	summary := "Configuration is valid again"
+	body := "Reloaded " + s.config.Get().ConfigPath
+	ntf := notify.NewNotification(summary, body)
+	ntf.Timeout = *s.config.Get().Notifications.TimeoutMs
+	ntf.Hints = s.hints
+
+	if _, err := ntf.Show(); err != nil {
+		return fmt.Errorf("cant send config recovered notification: %w", err)
+	}
+	logrus.Info("Config recovered notification sent to the user")
+	return nil
+}
+
+func (s *Service) NotifyLayoutBlocked(profile *config.Profile, err error, dryRun bool) error {
+	if *s.config.Get().Notifications.Disabled {
+		logrus.Debug("notifications are not enabled, not sending")
+		return nil
+	}
+	if dryRun {
+		logrus.WithFields(utils.NewLogrusEmptyFields().WithLogID(utils.DryRunNotificationLogID)).
+			Info("[DRY RUN] Would send notification")
+		return nil
+	}
+
+	summary := "Monitor profile `" + profile.Name + "` not applied"
+	ntf := notify.NewNotification(summary, err.Error())
+	ntf.Timeout = *s.config.Get().Notifications.TimeoutMs
+	ntf.Hints = s.hints
+
+	if _, err := ntf.Show(); err != nil {
+		return fmt.Errorf("cant send layout blocked notification for %s: %w", profile.Name, err)
+	}
+	logrus.Info("Layout blocked notification sent to the user")
+	return nil
+}
+
+func (s *Service) NotifyMonitorsQuarantined(monitors []string, cooldown time.Duration, dryRun bool) error {
+	if *s.config.Get().Notifications.Disabled {
+		logrus.Debug("notifications are not enabled, not sending")
+		return nil
+	}
+	if dryRun {
+		logrus.WithFields(utils.NewLogrusEmptyFields().WithLogID(utils.DryRunNotificationLogID)).
+			Info("[DRY RUN] Would send notification")
+		return nil
+	}
+
+	summary := "Flapping monitors ignored"
+	body := strings.Join(monitors, ", ") + " connected and disconnected too often, ignoring for " + cooldown.String()
+	ntf := notify.NewNotification(summary, body)
+	ntf.Timeout = *s.config.Get().Notifications.TimeoutMs
+	ntf.Hints = s.hints
+
+	if _, err := ntf.Show(); err != nil {
+		return fmt.Errorf("cant send quarantine notification: %w", err)
+	}
+	logrus.Info("Quarantine notification sent to the user")
+	return nil
+}
@@ -0,0 +1,378 @@
+package power
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/errs"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+	"github.com/godbus/dbus/v5"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+type PowerProfile int
+
+const (
+	UnknownPowerProfile PowerProfile = iota
+	PowerSaverPowerProfile
+	BalancedPowerProfile
+	PerformancePowerProfile
+)
+
+func (p PowerProfile) String() string {
+	switch p {
+	case PowerSaverPowerProfile:
+		return "power-saver"
+	case BalancedPowerProfile:
+		return "balanced"
+	case PerformancePowerProfile:
+		return "performance"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// ParsePowerProfile maps the power-profiles-daemon profile name to a PowerProfile
+func ParsePowerProfile(value string) (PowerProfile, bool) {
+	for _, profile := range []PowerProfile{PowerSaverPowerProfile, BalancedPowerProfile, PerformancePowerProfile} {
+		if profile.String() == value {
+			return profile, true
+		}
+	}
+	return UnknownPowerProfile, false
+}
+
+// BatteryState holds the battery percentage along with which thresholds it is below of,
+// the zero value represents an unknown state (e.g. battery events are disabled)
+type BatteryState struct {
+	known        bool
+	percentage   float64
+	below        map[int]bool
+	PowerProfile PowerProfile
+}
+
+func NewBatteryState(percentage float64, powerProfile PowerProfile) BatteryState {
+	return BatteryState{known: true, percentage: percentage, below: map[int]bool{}, PowerProfile: powerProfile}
+}
+
+func (b BatteryState) Known() bool {
+	return b.known
+}
+
+// Percentage returns the rounded battery percentage, -1 when unknown
+func (b BatteryState) Percentage() int {
+	if !b.known {
+		return -1
+	}
+	return int(math.Round(b.percentage))
+}
+
+// IsBelow reports whether the battery is below the threshold, taking the hysteresis
+// into account for tracked thresholds
+func (b BatteryState) IsBelow(threshold int) bool {
+	if !b.known {
+		return false
+	}
+	if below, ok := b.below[threshold]; ok {
+		return below
+	}
+	return b.percentage < float64(threshold)
+}
+
+// IsAbove is the complement of IsBelow for a known battery state
+func (b BatteryState) IsAbove(threshold int) bool {
+	return b.known && !b.IsBelow(threshold)
+}
+
+func (b BatteryState) String() string {
+	if !b.known {
+		return "UNKNOWN"
+	}
+	return fmt.Sprintf("%d%% (%s)", b.Percentage(), b.PowerProfile.String())
+}
+
+// Update returns the next state for a new reading: once below a threshold the battery
+// stays below until it reaches threshold+hysteresis, this avoids flapping around the threshold
+func (b BatteryState) Update(percentage float64, powerProfile PowerProfile, thresholds []int, hysteresis int) BatteryState {
+	next := NewBatteryState(percentage, powerProfile)
+	for _, threshold := range thresholds {
+		wasBelow, tracked := b.below[threshold]
+		switch {
+		case b.known && tracked && wasBelow:
+			next.below[threshold] = percentage < float64(threshold+hysteresis)
+		default:
+			next.below[threshold] = percentage < float64(threshold)
+		}
+	}
+	return next
+}
+
+// SameThresholds reports whether both states are equivalent for profile matching
+func (b BatteryState) SameThresholds(other BatteryState) bool {
+	return b.known == other.known && b.PowerProfile == other.PowerProfile && maps.Equal(b.below, other.below)
+}
+
+type BatteryEvent struct {
+	State BatteryState
+}
+
+type BatteryDetector struct {
+	enableBatteryEvents bool
+	cfg                 *config.Config
+
+	conn             *dbus.Conn
+	events           chan BatteryEvent
+	signals          chan *dbus.Signal
+	stateMu          sync.RWMutex
+	dbusMatchOptions [][]dbus.MatchOption
+
+	batteryState   BatteryState
+	batteryStateMu sync.RWMutex
+}
+
+func NewBatteryDetector(ctx context.Context, cfg *config.Config, conn *dbus.Conn,
+	enableBatteryEvents bool,
+) (*BatteryDetector, error) {
+	detector := &BatteryDetector{
+		conn:                conn,
+		cfg:                 cfg,
+		events:              make(chan BatteryEvent, 10),
+		signals:             make(chan *dbus.Signal, 10),
+		enableBatteryEvents: enableBatteryEvents,
+	}
+
+	if !enableBatteryEvents {
+		logrus.Debug("Battery events are disabled, battery state is unknown")
+		return detector, nil
+	}
+
+	state, err := detector.getCurrentState(ctx, BatteryState{})
+	if err != nil {
+		_ = conn.Close()
+		//nolint:errorlint
+		return nil, fmt.Errorf("%w: %v", errs.ErrUPowerMisconfigured, err)
+	}
+	detector.batteryState = state
+
+	logrus.WithField("state", state.String()).Info("UPower D-Bus battery detection initialized")
+
+	return detector, nil
+}
+
+func (b *BatteryDetector) GetCurrentState() BatteryState {
+	b.batteryStateMu.RLock()
+	defer b.batteryStateMu.RUnlock()
+	return b.batteryState
+}
+
+func (b *BatteryDetector) Listen() <-chan BatteryEvent {
+	return b.events
+}
+
+func (b *BatteryDetector) shouldHandleSignal(sig *dbus.Signal) bool {
+	for _, filter := range b.cfg.Get().BatteryEvents.DbusSignalReceiveFilters {
+		if filter.Name != nil && *filter.Name != sig.Name {
+			continue
+		}
+		sigBody := utils.SignalBodyToString(sig.Body)
+		if filter.Body != nil && !strings.Contains(sigBody, *filter.Body) {
+			continue
+		}
+		logrus.Debug("Filter matching the signal")
+		return true
+	}
+	return false
+}
+
+func (b *BatteryDetector) createMatchRules() [][]dbus.MatchOption {
+	rules := [][]dbus.MatchOption{}
+	for _, rule := range b.cfg.Get().BatteryEvents.DbusSignalMatchRules {
+		matchRules := []dbus.MatchOption{}
+		if rule.Interface != nil {
+			matchRules = append(matchRules, dbus.WithMatchInterface(*rule.Interface))
+		}
+		if rule.Sender != nil {
+			matchRules = append(matchRules, dbus.WithMatchSender(*rule.Sender))
+		}
+		if rule.Member != nil {
+			matchRules = append(matchRules, dbus.WithMatchMember(*rule.Member))
+		}
+		if rule.ObjectPath != nil {
+			matchRules = append(matchRules, dbus.WithMatchObjectPath(dbus.ObjectPath(*rule.ObjectPath)))
+		}
+		rules = append(rules, matchRules)
+	}
+	return rules
+}
+
+func (b *BatteryDetector) Reload(ctx context.Context) error {
+	if !b.enableBatteryEvents {
+		logrus.Debug("Battery events are disabled, not reloading battery rules")
+		return nil
+	}
+
+	b.stateMu.Lock()
+	defer b.stateMu.Unlock()
+	rules := b.createMatchRules()
+
+	for _, ruleSet := range b.dbusMatchOptions {
+		if err := b.conn.RemoveMatchSignalContext(ctx, ruleSet...); err != nil {
+			return fmt.Errorf("cant remove signal rule for dbus: %w", err)
+		}
+	}
+
+	for _, ruleSet := range rules {
+		if err := b.conn.AddMatchSignalContext(ctx, ruleSet...); err != nil {
+			return fmt.Errorf("cant add signal rule for dbus: %w", err)
+		}
+	}
+
+	b.dbusMatchOptions = rules
+	logrus.Debug("Reloaded battery detector")
+	return nil
+}
+
+func (b *BatteryDetector) query(ctx context.Context, query *config.DbusQueryObject) (dbus.Variant, error) {
+	obj := b.conn.Object(query.Destination, dbus.ObjectPath(query.Path))
+
+	logrus.WithFields(logrus.Fields{
+		"destination": query.Destination,
+		"path":        query.Path,
+		"method":      query.Method,
+		"args":        query.CollectArgs(),
+	}).Debug("About to make D-Bus method call")
+
+	var value dbus.Variant
+	if err := obj.CallWithContext(ctx, query.Method, 0, query.CollectArgs()...).Store(&value); err != nil {
+		return value, fmt.Errorf("cant call %s on %s: %w", query.Method, query.Path, err)
+	}
+	return value, nil
+}
+
+// getCurrentState queries the percentage and the power profile and computes the thresholds
+// relative to the previous state, power-profiles-daemon is optional
+func (b *BatteryDetector) getCurrentState(ctx context.Context, previous BatteryState) (BatteryState, error) {
+	cfg := b.cfg.Get()
+
+	value, err := b.query(ctx, cfg.BatteryEvents.PercentageQueryObject)
+	if err != nil {
+		return BatteryState{}, fmt.Errorf("failed to get the battery percentage from UPower: %w", err)
+	}
+	percentage, ok := value.Value().(float64)
+	if !ok {
+		return BatteryState{}, fmt.Errorf("battery percentage %s is not a double", value.String())
+	}
+
+	powerProfile := UnknownPowerProfile
+	value, err = b.query(ctx, cfg.BatteryEvents.PowerProfileQueryObject)
+	if err != nil {
+		logrus.WithError(err).Debug("Cant get the active power profile, is power-profiles-daemon running?")
+	} else if profile, ok := value.Value().(string); ok {
+		powerProfile, _ = ParsePowerProfile(profile)
+	}
+
+	return previous.Update(percentage, powerProfile, cfg.BatteryThresholds(), *cfg.BatteryEvents.HysteresisPercent), nil
+}
+
+// Healthy reports an error when the D-Bus connection used for battery events is gone
+func (b *BatteryDetector) Healthy() error {
+	if !b.enableBatteryEvents || b.conn == nil {
+		return nil
+	}
+	if !b.conn.Connected() {
+		return errors.New("battery events d-bus connection is closed")
+	}
+	return nil
+}
+
+func (b *BatteryDetector) Run(ctx context.Context) error {
+	eg, ctx := errgroup.WithContext(ctx)
+
+	eg.Go(func() error {
+		<-ctx.Done()
+		logrus.Debug("Battery detector context cancelled, closing D-Bus connection")
+		if b.conn != nil {
+			_ = b.conn.Close()
+		}
+		return context.Cause(ctx)
+	})
+
+	if !b.enableBatteryEvents {
+		logrus.Info("Battery events are disabled, waiting for ctx cancellation")
+		return eg.Wait()
+	}
+
+	if err := b.Reload(ctx); err != nil {
+		return fmt.Errorf("cant reload: %w", err)
+	}
+	b.conn.Signal(b.signals)
+
+	eg.Go(func() error {
+		defer close(b.events)
+		defer b.conn.RemoveSignal(b.signals)
+
+		logrus.Debug("Battery detector started, listening for D-Bus signals")
+
+		lastSent := b.GetCurrentState()
+
+		for {
+			select {
+			case signal, ok := <-b.signals:
+				if !ok {
+					return errors.New("dbus battery events channel closed")
+				}
+				logrus.WithFields(logrus.Fields{
+					"signal_name": signal.Name,
+					"signal_path": signal.Path,
+				}).Debug("Received D-Bus signal")
+
+				if !b.shouldHandleSignal(signal) {
+					logrus.WithField("signal_name", signal.Name).Debug("Ignoring unknown battery signal")
+					continue
+				}
+
+				currentState, err := b.getCurrentState(ctx, b.GetCurrentState())
+				if err != nil {
+					return fmt.Errorf("failed to get battery state after signal %s: %w", signal.Name, err)
+				}
+
+				b.batteryStateMu.Lock()
+				b.batteryState = currentState
+				b.batteryStateMu.Unlock()
+
+				// percentage changes alone do not trigger a re-evaluation, only crossing a threshold does
+				if currentState.SameThresholds(lastSent) {
+					logrus.WithField("battery_state", currentState.String()).Debug("Battery thresholds unchanged")
+					continue
+				}
+
+				logrus.WithFields(logrus.Fields{
+					"from": lastSent.String(),
+					"to":   currentState.String(),
+				}).Info("Battery state changed")
+
+				select {
+				case b.events <- BatteryEvent{State: currentState}:
+					lastSent = currentState
+				case <-ctx.Done():
+					return context.Cause(ctx)
+				}
+			case <-ctx.Done():
+				logrus.Debug("Battery detector context cancelled, shutting down")
+				return context.Cause(ctx)
+			}
+		}
+	})
+
+	if err := eg.Wait(); err != nil {
+		return fmt.Errorf("goroutines for battery detector failed %w", err)
+	}
+	return nil
+}
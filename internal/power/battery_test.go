@@ -0,0 +1,138 @@
+package power_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/power"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/testutils"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatteryState_Hysteresis(t *testing.T) {
+	thresholds := []int{20}
+	hysteresis := 2
+
+	tests := []struct {
+		name          string
+		readings      []float64
+		expectedBelow []bool
+	}{
+		{
+			name:          "crossing down",
+			readings:      []float64{25, 21, 19.5},
+			expectedBelow: []bool{false, false, true},
+		},
+		{
+			name:          "stays below within hysteresis",
+			readings:      []float64{19, 20, 21, 21.9},
+			expectedBelow: []bool{true, true, true, true},
+		},
+		{
+			name:          "leaves after hysteresis",
+			readings:      []float64{19, 21, 22, 21},
+			expectedBelow: []bool{true, true, false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := power.BatteryState{}
+			for i, reading := range tt.readings {
+				state = state.Update(reading, power.BalancedPowerProfile, thresholds, hysteresis)
+				assert.Equal(t, tt.expectedBelow[i], state.IsBelow(20), "reading %d (%v)", i, reading)
+				assert.Equal(t, !tt.expectedBelow[i], state.IsAbove(20), "reading %d (%v)", i, reading)
+			}
+		})
+	}
+}
+
+func TestBatteryState_Unknown(t *testing.T) {
+	state := power.BatteryState{}
+	assert.False(t, state.Known())
+	assert.Equal(t, -1, state.Percentage())
+	assert.False(t, state.IsBelow(100))
+	assert.False(t, state.IsAbove(0))
+	assert.Equal(t, "UNKNOWN", state.String())
+}
+
+func TestBatteryState_SameThresholds(t *testing.T) {
+	thresholds := []int{20, 50}
+	first := power.BatteryState{}.Update(40, power.BalancedPowerProfile, thresholds, 2)
+	second := first.Update(35, power.BalancedPowerProfile, thresholds, 2)
+	assert.True(t, first.SameThresholds(second), "percentage changes within thresholds are not relevant")
+
+	third := second.Update(35, power.PowerSaverPowerProfile, thresholds, 2)
+	assert.False(t, second.SameThresholds(third), "power profile changes are relevant")
+
+	fourth := third.Update(15, power.PowerSaverPowerProfile, thresholds, 2)
+	assert.False(t, third.SameThresholds(fourth), "crossing a threshold is relevant")
+}
+
+func TestBatteryDetector_Disabled(t *testing.T) {
+	cfg := testutils.NewTestConfig(t).Get()
+	detector, err := power.NewBatteryDetector(context.Background(), cfg, nil, false)
+	require.NoError(t, err)
+	assert.False(t, detector.GetCurrentState().Known())
+	assert.NoError(t, detector.Healthy())
+	assert.NoError(t, detector.Reload(context.Background()))
+}
+
+func TestBatteryDetector_Integration(t *testing.T) {
+	service, testBusName, testObjectPath, cleanup := testutils.SetupTestDbusService(t)
+	defer cleanup()
+	service.SetBatteryPercentage(50)
+
+	cfg := testutils.NewTestConfig(t).WithProfiles(map[string]*config.Profile{
+		"low": {
+			Name: "low",
+			Conditions: &config.ProfileCondition{
+				BatteryBelow:     utils.IntPtr(20),
+				RequiredMonitors: []*config.RequiredMonitor{{Name: utils.StringPtr("eDP-1")}},
+			},
+		},
+	}).WithBatterySection(testutils.CreateBatteryConfig(testBusName, testObjectPath)).Get()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conn, err := dbus.ConnectSessionBus()
+	require.NoError(t, err, "failed to connect to session bus")
+
+	detector, err := power.NewBatteryDetector(ctx, cfg, conn, true)
+	require.NoError(t, err, "should be able to create test battery detector")
+	require.Equal(t, 50, detector.GetCurrentState().Percentage())
+
+	go func() {
+		err := detector.Run(ctx)
+		if err != nil && ctx.Err() == nil {
+			t.Errorf("detector run failed: %v", err)
+		}
+	}()
+	time.Sleep(200 * time.Millisecond)
+
+	// the percentage used by the templates is only refreshed when a threshold is crossed
+	service.SetBatteryPercentage(45)
+	require.NoError(t, service.EmitSignal(), "should be able to emit signal")
+	select {
+	case event := <-detector.Listen():
+		t.Fatalf("unexpected battery event %s", event.State.String())
+	case <-time.After(300 * time.Millisecond):
+	}
+	assert.Equal(t, 45, detector.GetCurrentState().Percentage(), "the detector keeps the latest reading")
+
+	service.SetBatteryPercentage(15)
+	require.NoError(t, service.EmitSignal(), "should be able to emit signal")
+	select {
+	case event := <-detector.Listen():
+		assert.Equal(t, 15, event.State.Percentage())
+		assert.True(t, event.State.IsBelow(20))
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for battery event")
+	}
+}
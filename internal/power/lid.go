@@ -206,6 +206,17 @@ func (l *LidStateDetector) getCurrentState(ctx context.Context) (LidState, error
 	return OpenedLidState, nil
 }
 
+// Healthy reports an error when the D-Bus connection used for lid events is gone
+func (l *LidStateDetector) Healthy() error {
+	if !l.enableLidEvents || l.conn == nil {
+		return nil
+	}
+	if !l.conn.Connected() {
+		return errors.New("lid events d-bus connection is closed")
+	}
+	return nil
+}
+
 func (l *LidStateDetector) Run(ctx context.Context) error {
 	eg, ctx := errgroup.WithContext(ctx)
 
@@ -194,6 +194,17 @@ func (p *PowerDetector) Reload(ctx context.Context) error {
 	return nil
 }
 
+// Healthy reports an error when the D-Bus connection used for power events is gone
+func (p *PowerDetector) Healthy() error {
+	if p.disablePowerEvents || p.conn == nil {
+		return nil
+	}
+	if !p.conn.Connected() {
+		return errors.New("power events d-bus connection is closed")
+	}
+	return nil
+}
+
 func (p *PowerDetector) Run(ctx context.Context) error {
 	eg, ctx := errgroup.WithContext(ctx)
 
@@ -0,0 +1,313 @@
+package power
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	powerSupplyPathEnvVarOverride = "HYPRDYNAMICMONITORS_POWER_SUPPLY_PATH_OVERRIDE"
+	powerSupplyPath               = "/sys/class/power_supply"
+	lidPathEnvVarOverride         = "HYPRDYNAMICMONITORS_LID_PATH_OVERRIDE"
+	lidPath                       = "/proc/acpi/button/lid"
+)
+
+func pathOrOverride(envVar, path string) string {
+	if override, ok := os.LookupEnv(envVar); ok {
+		return override
+	}
+	return path
+}
+
+// PowerSupplyRoot returns the directory holding the power supplies, /sys/class/power_supply by default
+func PowerSupplyRoot() string {
+	return pathOrOverride(powerSupplyPathEnvVarOverride, powerSupplyPath)
+}
+
+// LidRoot returns the directory holding the ACPI lid buttons, /proc/acpi/button/lid by default
+func LidRoot() string {
+	return pathOrOverride(lidPathEnvVarOverride, lidPath)
+}
+
+// ReadSysfsPowerState reports AC when any non-battery power supply under root is online
+func ReadSysfsPowerState(root string) (PowerState, error) {
+	onlineFiles, err := filepath.Glob(filepath.Join(root, "*", "online"))
+	if err != nil {
+		return UnknownPowerState, fmt.Errorf("cant list power supplies in %s: %w", root, err)
+	}
+
+	found := false
+	for _, onlineFile := range onlineFiles {
+		supply := filepath.Dir(onlineFile)
+		//nolint:gosec
+		if supplyType, err := os.ReadFile(filepath.Join(supply, "type")); err == nil &&
+			strings.TrimSpace(string(supplyType)) == "Battery" {
+			continue
+		}
+
+		//nolint:gosec
+		online, err := os.ReadFile(onlineFile)
+		if err != nil {
+			logrus.WithError(err).WithField("path", onlineFile).Debug("Cant read power supply state")
+			continue
+		}
+		found = true
+		if strings.TrimSpace(string(online)) == "1" {
+			return ACPowerState, nil
+		}
+	}
+
+	if !found {
+		return UnknownPowerState, fmt.Errorf("no power supplies with an online attribute in %s", root)
+	}
+	return BatteryPowerState, nil
+}
+
+// ReadProcLidState parses the first lid button state file under root, e.g. "state:      open"
+func ReadProcLidState(root string) (LidState, error) {
+	stateFiles, err := filepath.Glob(filepath.Join(root, "*", "state"))
+	if err != nil {
+		return UnknownLidState, fmt.Errorf("cant list lid buttons in %s: %w", root, err)
+	}
+	if len(stateFiles) == 0 {
+		return UnknownLidState, fmt.Errorf("no lid buttons in %s", root)
+	}
+
+	//nolint:gosec
+	contents, err := os.ReadFile(stateFiles[0])
+	if err != nil {
+		return UnknownLidState, fmt.Errorf("cant read lid state: %w", err)
+	}
+
+	fields := strings.Fields(string(contents))
+	if len(fields) == 0 {
+		return UnknownLidState, fmt.Errorf("lid state file %s is empty", stateFiles[0])
+	}
+	switch fields[len(fields)-1] {
+	case "open":
+		return OpenedLidState, nil
+	case "closed":
+		return ClosedLidState, nil
+	default:
+		return UnknownLidState, fmt.Errorf("unknown lid state %q in %s", fields[len(fields)-1], stateFiles[0])
+	}
+}
+
+// watchSysfs calls onChange on every poll interval and whenever inotify reports a change under root,
+// sysfs and procfs attributes mostly do not emit inotify events so polling is the baseline
+func watchSysfs(ctx context.Context, root string, interval func() time.Duration, onChange func() error) error {
+	var events <-chan fsnotify.Event
+	var errs <-chan error
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		logrus.WithError(err).Debug("Cant create an inotify watcher, relying on polling")
+	} else {
+		defer watcher.Close()
+		dirs, _ := filepath.Glob(filepath.Join(root, "*"))
+		for _, dir := range append([]string{root}, dirs...) {
+			if err := watcher.Add(dir); err != nil {
+				logrus.WithError(err).WithField("path", dir).Debug("Cant watch path, relying on polling")
+			}
+		}
+		events = watcher.Events
+		errs = watcher.Errors
+	}
+
+	timer := time.NewTimer(interval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return errors.New("inotify events channel closed")
+			}
+			logrus.WithField("event", event.String()).Debug("Received inotify event")
+			if err := onChange(); err != nil {
+				return err
+			}
+		case err, ok := <-errs:
+			if !ok {
+				return errors.New("inotify errors channel closed")
+			}
+			logrus.WithError(err).Warn("Inotify watcher error")
+		case <-timer.C:
+			if err := onChange(); err != nil {
+				return err
+			}
+			timer.Reset(interval())
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	}
+}
+
+// SysfsPowerDetector reads the power state from /sys/class/power_supply, used when UPower is not available
+type SysfsPowerDetector struct {
+	cfg          *config.Config
+	root         string
+	events       chan PowerEvent
+	powerState   PowerState
+	powerStateMu sync.RWMutex
+}
+
+func NewSysfsPowerDetector(cfg *config.Config) (*SysfsPowerDetector, error) {
+	root := PowerSupplyRoot()
+	state, err := ReadSysfsPowerState(root)
+	if err != nil {
+		return nil, fmt.Errorf("cant read the power state from sysfs: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{"root": root, "state": state.String()}).Info("Sysfs power detection initialized")
+	return &SysfsPowerDetector{
+		cfg:        cfg,
+		root:       root,
+		events:     make(chan PowerEvent, 10),
+		powerState: state,
+	}, nil
+}
+
+func (p *SysfsPowerDetector) GetCurrentState() PowerState {
+	p.powerStateMu.RLock()
+	defer p.powerStateMu.RUnlock()
+	return p.powerState
+}
+
+func (p *SysfsPowerDetector) Listen() <-chan PowerEvent {
+	return p.events
+}
+
+// Reload is a no-op, the poll interval is read from the config on every tick
+func (p *SysfsPowerDetector) Reload(context.Context) error {
+	return nil
+}
+
+func (p *SysfsPowerDetector) Healthy() error {
+	return nil
+}
+
+func (p *SysfsPowerDetector) Run(ctx context.Context) error {
+	defer close(p.events)
+	logrus.Debug("Sysfs power detector started")
+
+	interval := func() time.Duration {
+		return time.Duration(*p.cfg.Get().PowerEvents.SysfsPollIntervalMs) * time.Millisecond
+	}
+	err := watchSysfs(ctx, p.root, interval, func() error {
+		state, err := ReadSysfsPowerState(p.root)
+		if err != nil {
+			logrus.WithError(err).Warn("Cant read the power state from sysfs")
+			return nil
+		}
+
+		previous := p.GetCurrentState()
+		if state == previous {
+			return nil
+		}
+		p.powerStateMu.Lock()
+		p.powerState = state
+		p.powerStateMu.Unlock()
+
+		logrus.WithFields(logrus.Fields{"from": previous.String(), "to": state.String()}).Info("Power state changed")
+		select {
+		case p.events <- PowerEvent{State: state}:
+			return nil
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("sysfs power detector failed: %w", err)
+	}
+	return nil
+}
+
+// SysfsLidDetector reads the lid state from /proc/acpi/button/lid, used when UPower is not available
+type SysfsLidDetector struct {
+	cfg        *config.Config
+	root       string
+	events     chan LidEvent
+	lidState   LidState
+	lidStateMu sync.RWMutex
+}
+
+func NewSysfsLidDetector(cfg *config.Config) (*SysfsLidDetector, error) {
+	root := LidRoot()
+	state, err := ReadProcLidState(root)
+	if err != nil {
+		return nil, fmt.Errorf("cant read the lid state from procfs: %w", err)
+	}
+
+	logrus.WithFields(logrus.Fields{"root": root, "state": state.String()}).Info("Procfs lid detection initialized")
+	return &SysfsLidDetector{
+		cfg:      cfg,
+		root:     root,
+		events:   make(chan LidEvent, 10),
+		lidState: state,
+	}, nil
+}
+
+func (l *SysfsLidDetector) GetCurrentState() LidState {
+	l.lidStateMu.RLock()
+	defer l.lidStateMu.RUnlock()
+	return l.lidState
+}
+
+func (l *SysfsLidDetector) Listen() <-chan LidEvent {
+	return l.events
+}
+
+// Reload is a no-op, the poll interval is read from the config on every tick
+func (l *SysfsLidDetector) Reload(context.Context) error {
+	return nil
+}
+
+func (l *SysfsLidDetector) Healthy() error {
+	return nil
+}
+
+func (l *SysfsLidDetector) Run(ctx context.Context) error {
+	defer close(l.events)
+	logrus.Debug("Procfs lid detector started")
+
+	interval := func() time.Duration {
+		return time.Duration(*l.cfg.Get().LidEvents.SysfsPollIntervalMs) * time.Millisecond
+	}
+	err := watchSysfs(ctx, l.root, interval, func() error {
+		state, err := ReadProcLidState(l.root)
+		if err != nil {
+			logrus.WithError(err).Warn("Cant read the lid state from procfs")
+			return nil
+		}
+
+		previous := l.GetCurrentState()
+		if state == previous {
+			return nil
+		}
+		l.lidStateMu.Lock()
+		l.lidState = state
+		l.lidStateMu.Unlock()
+
+		logrus.WithFields(logrus.Fields{"from": previous.String(), "to": state.String()}).Info("Lid state changed")
+		select {
+		case l.events <- LidEvent{State: state}:
+			return nil
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("procfs lid detector failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package power_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/power"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/testutils"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSysfsFile(t *testing.T, path, contents string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	// nolint:gosec
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+}
+
+func TestReadSysfsPowerState(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string]string
+		expectedState power.PowerState
+		expectError   bool
+	}{
+		{
+			name: "ac online",
+			files: map[string]string{
+				"ACAD/type":   "Mains\n",
+				"ACAD/online": "1\n",
+				"BAT1/type":   "Battery\n",
+			},
+			expectedState: power.ACPowerState,
+		},
+		{
+			name: "ac offline",
+			files: map[string]string{
+				"AC/type":   "Mains\n",
+				"AC/online": "0\n",
+			},
+			expectedState: power.BatteryPowerState,
+		},
+		{
+			name: "battery online attribute is ignored",
+			files: map[string]string{
+				"AC/online":   "0\n",
+				"BAT0/type":   "Battery\n",
+				"BAT0/online": "1\n",
+			},
+			expectedState: power.BatteryPowerState,
+		},
+		{
+			name: "usb-c supply online",
+			files: map[string]string{
+				"AC/online":                          "0\n",
+				"ucsi-source-psy-USBC000:001/type":   "USB\n",
+				"ucsi-source-psy-USBC000:001/online": "1\n",
+			},
+			expectedState: power.ACPowerState,
+		},
+		{
+			name: "no power supplies",
+			files: map[string]string{
+				"BAT0/type": "Battery\n",
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for path, contents := range tt.files {
+				writeSysfsFile(t, filepath.Join(root, path), contents)
+			}
+
+			state, err := power.ReadSysfsPowerState(root)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedState, state)
+		})
+	}
+}
+
+func TestReadProcLidState(t *testing.T) {
+	tests := []struct {
+		name          string
+		contents      *string
+		expectedState power.LidState
+		expectError   bool
+	}{
+		{
+			name:          "open",
+			contents:      utils.StringPtr("state:      open\n"),
+			expectedState: power.OpenedLidState,
+		},
+		{
+			name:          "closed",
+			contents:      utils.StringPtr("state:      closed\n"),
+			expectedState: power.ClosedLidState,
+		},
+		{
+			name:        "garbage",
+			contents:    utils.StringPtr("state:      ajar\n"),
+			expectError: true,
+		},
+		{
+			name:        "no lid",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			if tt.contents != nil {
+				writeSysfsFile(t, filepath.Join(root, "LID0", "state"), *tt.contents)
+			}
+
+			state, err := power.ReadProcLidState(root)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedState, state)
+		})
+	}
+}
+
+func TestSysfsDetectors_Run(t *testing.T) {
+	powerRoot := t.TempDir()
+	lidRoot := t.TempDir()
+	t.Setenv("HYPRDYNAMICMONITORS_POWER_SUPPLY_PATH_OVERRIDE", powerRoot)
+	t.Setenv("HYPRDYNAMICMONITORS_LID_PATH_OVERRIDE", lidRoot)
+	writeSysfsFile(t, filepath.Join(powerRoot, "AC", "online"), "1\n")
+	writeSysfsFile(t, filepath.Join(lidRoot, "LID", "state"), "state:      open\n")
+
+	cfg := testutils.NewTestConfig(t).Get()
+	cfg.Get().PowerEvents.SysfsPollIntervalMs = utils.IntPtr(20)
+	cfg.Get().LidEvents.SysfsPollIntervalMs = utils.IntPtr(20)
+
+	powerDetector, err := power.NewSysfsPowerDetector(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, power.ACPowerState, powerDetector.GetCurrentState())
+
+	lidDetector, err := power.NewSysfsLidDetector(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, power.OpenedLidState, lidDetector.GetCurrentState())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	go func() { _ = powerDetector.Run(ctx) }()
+	go func() { _ = lidDetector.Run(ctx) }()
+
+	writeSysfsFile(t, filepath.Join(powerRoot, "AC", "online"), "0\n")
+	writeSysfsFile(t, filepath.Join(lidRoot, "LID", "state"), "state:      closed\n")
+
+	select {
+	case event := <-powerDetector.Listen():
+		assert.Equal(t, power.BatteryPowerState, event.State)
+	case <-ctx.Done():
+		t.Fatal("no power event received")
+	}
+	select {
+	case event := <-lidDetector.Listen():
+		assert.Equal(t, power.ClosedLidState, event.State)
+	case <-ctx.Done():
+		t.Fatal("no lid event received")
+	}
+	assert.Equal(t, power.BatteryPowerState, powerDetector.GetCurrentState())
+	assert.Equal(t, power.ClosedLidState, lidDetector.GetCurrentState())
+}
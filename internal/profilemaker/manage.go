@@ -0,0 +1,440 @@
+package profilemaker
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/configedit"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	conditionsTable      = []string{"conditions"}
+	requiredMonitorsPath = []string{"conditions", "required_monitors"}
+	tuiColorsTable       = []string{"tui", "colors"}
+)
+
+// ConditionKeys are the scalar profile conditions that can be set from the cli, required monitors
+// are edited by hand, with freeze or from the tui
+var ConditionKeys = []string{"power_state", "lid_state", "battery_below", "battery_above", "power_profile"}
+
+// ConfigFileAction tells what to do with the profile config file when the profile is renamed,
+// duplicated or deleted, an empty Location derives the new path from the profile name
+type ConfigFileAction struct {
+	Enabled  bool
+	Location string
+}
+
+// Show returns the profile tables as they are written in the configuration file
+func (s *Service) Show(profileName string) (string, error) {
+	doc, err := s.document()
+	if err != nil {
+		return "", err
+	}
+	return doc.Profile(profileName)
+}
+
+func (s *Service) Rename(from, to string, move ConfigFileAction) error {
+	doc, err := s.document()
+	if err != nil {
+		return err
+	}
+	if err := doc.RenameProfile(from, to); err != nil {
+		return fmt.Errorf("cant rename the profile: %w", err)
+	}
+
+	var rollback func() error
+	if move.Enabled {
+		if err := s.ensureNotShared(from); err != nil {
+			return err
+		}
+		source, target, value, err := s.newConfigFile(doc, to, from, to, move.Location)
+		if err != nil {
+			return err
+		}
+		if err := doc.SetProfileValue(to, nil, "config_file", value); err != nil {
+			return fmt.Errorf("cant point the profile to the moved config file: %w", err)
+		}
+		if err := os.Rename(source, target); err != nil {
+			return fmt.Errorf("cant move the config file: %w", err)
+		}
+		rollback = func() error { return os.Rename(target, source) }
+	}
+
+	return s.save(doc, rollback)
+}
+
+func (s *Service) Duplicate(from, to string, copyFile ConfigFileAction) error {
+	doc, err := s.document()
+	if err != nil {
+		return err
+	}
+	if err := doc.DuplicateProfile(from, to); err != nil {
+		return fmt.Errorf("cant duplicate the profile: %w", err)
+	}
+
+	var rollback func() error
+	if copyFile.Enabled {
+		source, target, value, err := s.newConfigFile(doc, to, from, to, copyFile.Location)
+		if err != nil {
+			return err
+		}
+		if err := doc.SetProfileValue(to, nil, "config_file", value); err != nil {
+			return fmt.Errorf("cant point the profile to the copied config file: %w", err)
+		}
+		if err := copyConfigFile(source, target); err != nil {
+			return err
+		}
+		rollback = func() error { return os.Remove(target) }
+	}
+
+	return s.save(doc, rollback)
+}
+
+func (s *Service) Delete(profileName string, deleteConfigFile bool) error {
+	doc, err := s.document()
+	if err != nil {
+		return err
+	}
+	if err := doc.DeleteProfile(profileName); err != nil {
+		return fmt.Errorf("cant delete the profile: %w", err)
+	}
+	if deleteConfigFile {
+		if err := s.ensureNotShared(profileName); err != nil {
+			return err
+		}
+	}
+
+	if err := s.save(doc, nil); err != nil {
+		return err
+	}
+
+	if deleteConfigFile {
+		configFile := s.cfg.Get().Profiles[profileName].ConfigFile
+		if err := os.Remove(configFile); err != nil {
+			return fmt.Errorf("profile deleted but cant remove its config file %s: %w", configFile, err)
+		}
+	}
+	return nil
+}
+
+// SetCondition sets a single condition, an empty value removes it
+func (s *Service) SetCondition(profileName, key, value string) error {
+	doc, err := s.document()
+	if err != nil {
+		return err
+	}
+
+	if err := setCondition(doc, profileName, key, value); err != nil {
+		return err
+	}
+	return s.save(doc, nil)
+}
+
+// ConditionsUpdate replaces the required monitors of a profile, Values are the scalar conditions
+// to set keyed like ConditionKeys where an empty value removes the condition
+type ConditionsUpdate struct {
+	RequiredMonitors []*config.RequiredMonitor
+	Values           map[string]string
+}
+
+// SetConditions writes the required monitors and the scalar conditions in a single change, the
+// conditions missing from Values are left as they are
+func (s *Service) SetConditions(profileName string, update ConditionsUpdate) error {
+	doc, err := s.document()
+	if err != nil {
+		return err
+	}
+
+	for key := range update.Values {
+		if err := validateConditionKey(key); err != nil {
+			return err
+		}
+	}
+
+	entries := make([][]configedit.Field, 0, len(update.RequiredMonitors))
+	for _, monitor := range update.RequiredMonitors {
+		entries = append(entries, requiredMonitorFields(monitor))
+	}
+	if err := doc.SetProfileArray(profileName, requiredMonitorsPath, entries); err != nil {
+		return fmt.Errorf("cant set the required monitors: %w", err)
+	}
+
+	for _, key := range ConditionKeys {
+		value, ok := update.Values[key]
+		if !ok {
+			continue
+		}
+		if err := setCondition(doc, profileName, key, value); err != nil {
+			return err
+		}
+	}
+	return s.save(doc, nil)
+}
+
+// SetThemeSource points the tui colors at the theme file, themes inside the config directory are
+// written relative to it
+func (s *Service) SetThemeSource(themeFile string) error {
+	doc, err := s.document()
+	if err != nil {
+		return err
+	}
+
+	source := themeFile
+	if relative, err := filepath.Rel(s.cfg.Get().ConfigDirPath, themeFile); err == nil &&
+		!strings.HasPrefix(relative, "..") {
+		source = relative
+	}
+	if err := doc.SetValue(tuiColorsTable, "source", source); err != nil {
+		return fmt.Errorf("cant set the theme source: %w", err)
+	}
+	return s.save(doc, nil)
+}
+
+func setCondition(doc *configedit.Document, profileName, key, value string) error {
+	var parsed any
+	if value != "" {
+		var err error
+		parsed, err = parseCondition(key, value)
+		if err != nil {
+			return err
+		}
+	} else if err := validateConditionKey(key); err != nil {
+		return err
+	}
+
+	if err := doc.SetProfileValue(profileName, conditionsTable, key, parsed); err != nil {
+		return fmt.Errorf("cant set the condition: %w", err)
+	}
+	return nil
+}
+
+// requiredMonitorFields lists the set fields in the order the config documents them, the regex
+// flags are only written when enabled
+func requiredMonitorFields(monitor *config.RequiredMonitor) []configedit.Field {
+	fields := []configedit.Field{}
+	if monitor.Name != nil {
+		fields = append(fields, configedit.Field{Key: "name", Value: *monitor.Name})
+	}
+	if monitor.Description != nil {
+		fields = append(fields, configedit.Field{Key: "description", Value: *monitor.Description})
+	}
+	if monitor.MonitorTag != nil {
+		fields = append(fields, configedit.Field{Key: "monitor_tag", Value: *monitor.MonitorTag})
+	}
+	if monitor.MatchNameUsingRegex != nil && *monitor.MatchNameUsingRegex {
+		fields = append(fields, configedit.Field{Key: "match_name_using_regex", Value: true})
+	}
+	if monitor.MatchDescriptionUsingRegex != nil && *monitor.MatchDescriptionUsingRegex {
+		fields = append(fields, configedit.Field{Key: "match_description_using_regex", Value: true})
+	}
+	return fields
+}
+
+func (s *Service) document() (*configedit.Document, error) {
+	//nolint:gosec
+	contents, err := os.ReadFile(s.cfg.Get().ConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("cant read the current config file: %w", err)
+	}
+	return configedit.Parse(contents), nil
+}
+
+// save validates the edited configuration next to the real one (relative config files resolve the
+// same way) before replacing it, rollback undoes the file changes when the result is invalid
+func (s *Service) save(doc *configedit.Document, rollback func() error) error {
+	configPath := s.cfg.Get().ConfigPath
+	undo := func() {
+		if rollback == nil {
+			return
+		}
+		if err := rollback(); err != nil {
+			logrus.WithError(err).Error("Cant revert the config file changes")
+		}
+	}
+
+	candidate, err := os.CreateTemp(filepath.Dir(configPath), ".config-*.toml")
+	if err != nil {
+		undo()
+		return fmt.Errorf("cant create a temporary config file: %w", err)
+	}
+	defer func() { _ = os.Remove(candidate.Name()) }()
+	if _, err := candidate.Write(doc.Bytes()); err != nil {
+		_ = candidate.Close()
+		undo()
+		return fmt.Errorf("cant write a temporary config file: %w", err)
+	}
+	if err := candidate.Close(); err != nil {
+		undo()
+		return fmt.Errorf("cant write a temporary config file: %w", err)
+	}
+
+	if _, err := config.Load(candidate.Name()); err != nil {
+		undo()
+		return fmt.Errorf("the edited config is not valid, nothing was changed: %w", err)
+	}
+
+	if err := utils.WriteAtomic(configPath, doc.Bytes()); err != nil {
+		undo()
+		return fmt.Errorf("cant write the config file: %w", err)
+	}
+	logrus.WithField("path", configPath).Debug("Config file updated")
+	return nil
+}
+
+// newConfigFile returns the current and the new absolute path of the profile config file and the
+// value to store in the config, the new path replaces from with to in the file name unless given
+func (s *Service) newConfigFile(doc *configedit.Document, profileName, from, to, location string) (string,
+	string, string, error,
+) {
+	raw, err := rawConfigFile(doc, profileName)
+	if err != nil {
+		return "", "", "", err
+	}
+	source, err := s.resolve(raw)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	value := location
+	if value == "" {
+		base := filepath.Base(raw)
+		if strings.Contains(base, from) {
+			base = strings.Replace(base, from, to, 1)
+		} else {
+			stem, ext, _ := strings.Cut(base, ".")
+			base = stem + "-" + to
+			if ext != "" {
+				base += "." + ext
+			}
+		}
+		value = filepath.Join(filepath.Dir(raw), base)
+	}
+	target, err := s.resolve(value)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if _, err := os.Stat(target); err == nil {
+		return "", "", "", fmt.Errorf("config file %s already exists, pass another in --config-file-location", target)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return "", "", "", fmt.Errorf("cant create directory: %w", err)
+	}
+	return source, target, value, nil
+}
+
+// resolve mirrors how profiles resolve config_file
+func (s *Service) resolve(configFile string) (string, error) {
+	profile := config.Profile{ConfigFile: configFile}
+	if err := profile.SetPath(s.cfg.Get().ConfigDirPath); err != nil {
+		return "", fmt.Errorf("cant set the profile path: %w", err)
+	}
+	absolute, err := filepath.Abs(os.ExpandEnv(profile.ConfigFile))
+	if err != nil {
+		return "", fmt.Errorf("cant get absolute path to config file %s: %w", profile.ConfigFile, err)
+	}
+	return absolute, nil
+}
+
+// ensureNotShared refuses to move or remove a config file another profile still uses
+func (s *Service) ensureNotShared(profileName string) error {
+	cfg := s.cfg.Get()
+	profile, ok := cfg.Profiles[profileName]
+	if !ok {
+		return fmt.Errorf("%s: %w", profileName, configedit.ErrNotFound)
+	}
+	users := []*config.Profile{}
+	for _, other := range cfg.Profiles {
+		if other.Name != profileName {
+			users = append(users, other)
+		}
+	}
+	if cfg.FallbackProfile != nil {
+		users = append(users, cfg.FallbackProfile)
+	}
+	for _, other := range users {
+		if other.ConfigFile == profile.ConfigFile {
+			return fmt.Errorf("config file %s is also used by %s", profile.ConfigFile, other.Name)
+		}
+	}
+	return nil
+}
+
+func rawConfigFile(doc *configedit.Document, profileName string) (string, error) {
+	var raw struct {
+		Profiles map[string]struct {
+			ConfigFile string `toml:"config_file"`
+		} `toml:"profiles"`
+	}
+	if _, err := toml.Decode(string(doc.Bytes()), &raw); err != nil {
+		return "", fmt.Errorf("failed to decode TOML: %w", err)
+	}
+	profile, ok := raw.Profiles[profileName]
+	if !ok || profile.ConfigFile == "" {
+		return "", fmt.Errorf("profile %s has no config_file", profileName)
+	}
+	return profile.ConfigFile, nil
+}
+
+func copyConfigFile(source, target string) error {
+	//nolint:gosec
+	contents, err := os.ReadFile(source)
+	if err != nil {
+		return fmt.Errorf("cant read the config file: %w", err)
+	}
+	if err := utils.WriteAtomic(target, contents); err != nil {
+		return fmt.Errorf("cant copy the config file: %w", err)
+	}
+	return nil
+}
+
+func validateConditionKey(key string) error {
+	for _, known := range ConditionKeys {
+		if key == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown condition %s, available: %s", key, strings.Join(ConditionKeys, ", "))
+}
+
+// parseCondition converts the cli value into what the config stores, enums are normalized
+func parseCondition(key, value string) (any, error) {
+	if err := validateConditionKey(key); err != nil {
+		return nil, err
+	}
+	switch key {
+	case "battery_below", "battery_above":
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be an integer: %w", key, err)
+		}
+		return number, nil
+	case "power_state":
+		var state config.PowerStateType
+		if err := state.UnmarshalTOML(value); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", key, err)
+		}
+		return state.Value(), nil
+	case "lid_state":
+		var state config.LidStateType
+		if err := state.UnmarshalTOML(value); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", key, err)
+		}
+		return state.Value(), nil
+	case "power_profile":
+		var profile config.PowerProfileType
+		if err := profile.UnmarshalTOML(value); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", key, err)
+		}
+		return profile.Value(), nil
+	}
+	return nil, errors.New("unreachable condition " + key)
+}
@@ -0,0 +1,171 @@
+package profilemaker_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/profilemaker"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const manageConfig = `[general]
+destination = "/tmp/hdm-manage/monitors.conf"
+
+# on the go
+[profiles.laptop]
+config_file = "hyprconfigs/laptop.conf"
+
+[profiles.laptop.conditions]
+power_state = "BAT" # unplugged
+
+[[profiles.laptop.conditions.required_monitors]]
+name = "eDP-1"
+
+[profiles.shared]
+config_file = "hyprconfigs/laptop.conf"
+
+[[profiles.shared.conditions.required_monitors]]
+name = "DP-1"
+`
+
+func setupManage(t *testing.T) (*profilemaker.Service, string) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "hyprconfigs"), 0o750))
+	// nolint:gosec
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "hyprconfigs", "laptop.conf"), []byte("monitor=,preferred,auto,1"), 0o644))
+	configPath := filepath.Join(dir, "config.toml")
+	// nolint:gosec
+	require.NoError(t, os.WriteFile(configPath, []byte(manageConfig), 0o644))
+
+	cfg, err := config.NewConfig(configPath)
+	require.NoError(t, err)
+	return profilemaker.NewService(cfg, nil), dir
+}
+
+func readConfig(t *testing.T, dir string) string {
+	// nolint:gosec
+	contents, err := os.ReadFile(filepath.Join(dir, "config.toml"))
+	require.NoError(t, err)
+	return string(contents)
+}
+
+func TestService_Duplicate_CopyConfigFile(t *testing.T) {
+	service, dir := setupManage(t)
+
+	require.NoError(t, service.Duplicate("laptop", "travel", profilemaker.ConfigFileAction{Enabled: true}))
+
+	assert.FileExists(t, filepath.Join(dir, "hyprconfigs", "travel.conf"))
+	assert.Contains(t, readConfig(t, dir), `name = "eDP-1"
+
+# on the go
+[profiles.travel]
+config_file = "hyprconfigs/travel.conf"
+
+[profiles.travel.conditions]
+power_state = "BAT" # unplugged
+
+[[profiles.travel.conditions.required_monitors]]
+name = "eDP-1"
+
+[profiles.shared]`)
+}
+
+func TestService_Rename_SharedConfigFile(t *testing.T) {
+	service, dir := setupManage(t)
+
+	err := service.Rename("laptop", "travel", profilemaker.ConfigFileAction{Enabled: true})
+	assert.Contains(t, err.Error(), "is also used by shared")
+	assert.Equal(t, manageConfig, readConfig(t, dir))
+
+	require.NoError(t, service.Rename("laptop", "travel", profilemaker.ConfigFileAction{}))
+	assert.Contains(t, readConfig(t, dir), "# on the go\n[profiles.travel]\n")
+}
+
+func TestService_Delete_ConfigFile(t *testing.T) {
+	service, dir := setupManage(t)
+
+	err := service.Delete("laptop", true)
+	assert.Contains(t, err.Error(), "is also used by shared")
+	assert.Equal(t, manageConfig, readConfig(t, dir))
+
+	require.NoError(t, service.Delete("shared", false))
+	assert.FileExists(t, filepath.Join(dir, "hyprconfigs", "laptop.conf"))
+	assert.NotContains(t, readConfig(t, dir), "shared")
+}
+
+func TestService_SetCondition(t *testing.T) {
+	service, dir := setupManage(t)
+
+	require.NoError(t, service.SetCondition("laptop", "power_state", "AC"))
+	require.NoError(t, service.SetCondition("shared", "battery_above", "40"))
+	assert.Contains(t, readConfig(t, dir), "power_state = \"AC\" # unplugged\n")
+	assert.Contains(t, readConfig(t, dir), "[profiles.shared.conditions]\nbattery_above = 40\n")
+
+	require.NoError(t, service.SetCondition("laptop", "power_state", ""))
+	assert.NotContains(t, readConfig(t, dir), "power_state")
+
+	assert.Error(t, service.SetCondition("laptop", "battery_below", "low"))
+	assert.Error(t, service.SetCondition("laptop", "unknown", "1"))
+	assert.Error(t, service.SetCondition("laptop", "battery_below", "150"))
+	assert.NotContains(t, readConfig(t, dir), "battery_below")
+}
+
+func TestService_SetConditions(t *testing.T) {
+	service, dir := setupManage(t)
+
+	require.NoError(t, service.SetConditions("laptop", profilemaker.ConditionsUpdate{
+		RequiredMonitors: []*config.RequiredMonitor{
+			{Name: utils.StringPtr("eDP-1"), MonitorTag: utils.StringPtr("laptop")},
+			{
+				Description:                utils.StringPtr("^Dell.*"),
+				MatchDescriptionUsingRegex: utils.JustPtr(true),
+			},
+		},
+		Values: map[string]string{"power_state": "", "lid_state": "Closed"},
+	}))
+	assert.Contains(t, readConfig(t, dir), `[profiles.laptop.conditions]
+lid_state = "Closed"
+
+[[profiles.laptop.conditions.required_monitors]]
+name = "eDP-1"
+monitor_tag = "laptop"
+
+[[profiles.laptop.conditions.required_monitors]]
+description = "^Dell.*"
+match_description_using_regex = true
+
+[profiles.shared]`)
+
+	before := readConfig(t, dir)
+	assert.Error(t, service.SetConditions("laptop", profilemaker.ConditionsUpdate{}))
+	assert.Error(t, service.SetConditions("laptop", profilemaker.ConditionsUpdate{
+		RequiredMonitors: []*config.RequiredMonitor{{Name: utils.StringPtr("eDP-1")}},
+		Values:           map[string]string{"unknown": "1"},
+	}))
+	assert.Equal(t, before, readConfig(t, dir))
+}
+
+func TestService_SetThemeSource(t *testing.T) {
+	service, dir := setupManage(t)
+	theme := filepath.Join(dir, "themes", "nord.toml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(theme), 0o750))
+	// nolint:gosec
+	require.NoError(t, os.WriteFile(theme, []byte("active_pane_color = \"#88C0D0\"\n"), 0o644))
+
+	require.NoError(t, service.SetThemeSource(theme))
+	assert.Contains(t, readConfig(t, dir), "[tui.colors]\nsource = \"themes/nord.toml\"\n")
+
+	outside := filepath.Join(t.TempDir(), "theme.toml")
+	// nolint:gosec
+	require.NoError(t, os.WriteFile(outside, []byte("active_pane_color = \"#7aa2f7\"\n"), 0o644))
+	require.NoError(t, service.SetThemeSource(outside))
+	assert.Contains(t, readConfig(t, dir), "[tui.colors]\nsource = \""+outside+"\"\n")
+
+	err := service.SetThemeSource(filepath.Join(dir, "themes", "missing.toml"))
+	require.Error(t, err)
+	assert.Contains(t, readConfig(t, dir), outside, "an invalid theme should not be written")
+}
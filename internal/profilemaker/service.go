@@ -24,6 +24,19 @@ var monitorsTemplate string
 //go:embed templates/tui.go.tmpl
 var tuiTemplate string
 
+const (
+	tuiStartMarker = "# <<<<< TUI AUTO START"
+	tuiEndMarker   = "# <<<<< TUI AUTO END"
+)
+
+// FileChange is the content a file has and the content it would have after a write, Before is
+// empty for files that do not exist yet
+type FileChange struct {
+	Path   string
+	Before string
+	After  string
+}
+
 type Service struct {
 	cfg *config.Config
 	ipc *hypr.IPC
@@ -51,6 +64,9 @@ func (s *Service) FreezeGivenAs(profileName, profileFileLocation string, current
 	if err := s.validate(cfg, profileName, profile); err != nil {
 		return fmt.Errorf("cant validate basic new profile properties: %w", err)
 	}
+	if err := os.MkdirAll(filepath.Dir(profile.ConfigFile), 0o750); err != nil {
+		return fmt.Errorf("cant create directory: %w", err)
+	}
 
 	profileSpec, err := s.encode(profile)
 	if err != nil {
@@ -76,6 +92,42 @@ func (s *Service) FreezeGivenAs(profileName, profileFileLocation string, current
 	return nil
 }
 
+// PreviewFreezeGivenAs returns the files FreezeGivenAs would write without touching them, the new
+// profile template and the configuration file with the profile appended
+func (s *Service) PreviewFreezeGivenAs(profileName, profileFileLocation string,
+	currentMonitors []*hypr.MonitorSpec,
+) ([]FileChange, error) {
+	cfg := s.cfg.Get()
+	profile, err := s.prepare(profileName, profileFileLocation, currentMonitors)
+	if err != nil {
+		return nil, fmt.Errorf("cant create a new profile: %w", err)
+	}
+
+	if err := s.validate(cfg, profileName, profile); err != nil {
+		return nil, fmt.Errorf("cant validate basic new profile properties: %w", err)
+	}
+
+	profileSpec, err := s.encode(profile)
+	if err != nil {
+		return nil, fmt.Errorf("cant encode new profile: %w", err)
+	}
+
+	rendered, err := s.renderMonitors(monitorsTemplate, currentMonitors)
+	if err != nil {
+		return nil, fmt.Errorf("cant render the new profile config: %w", err)
+	}
+
+	content, err := os.ReadFile(cfg.ConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("cant read the current config file: %w", err)
+	}
+
+	return []FileChange{
+		{Path: profile.ConfigFile, After: string(rendered)},
+		{Path: cfg.ConfigPath, Before: string(content), After: s.appended(string(content), profileSpec)},
+	}, nil
+}
+
 func (s *Service) EditExisting(profileName string, currentMonitors []*hypr.MonitorSpec) error {
 	cfg := s.cfg.Get()
 	profile, ok := cfg.Profiles[profileName]
@@ -83,37 +135,53 @@ func (s *Service) EditExisting(profileName string, currentMonitors []*hypr.Monit
 		return errors.New("profile not found")
 	}
 
-	tmpl, err := template.New("part").Parse(tuiTemplate)
+	rendered, err := s.renderMonitors(tuiTemplate, currentMonitors)
 	if err != nil {
-		return fmt.Errorf("failed to parse template: %w", err)
+		return err
 	}
 
-	templateData := map[string]any{
-		"Monitors":     currentMonitors,
-		"MonitorLines": s.ToHyprLines(currentMonitors),
+	err = s.updateConfigFileWithContent(profile.ConfigFile, string(rendered))
+	if err != nil {
+		return fmt.Errorf("failed to update config file: %w", err)
 	}
 
-	var rendered bytes.Buffer
-	if err := tmpl.Execute(&rendered, templateData); err != nil {
-		return fmt.Errorf("failed to execute template: %w", err)
+	return nil
+}
+
+// PreviewEditExisting returns the profile config file as EditExisting would leave it without
+// writing it
+func (s *Service) PreviewEditExisting(profileName string, currentMonitors []*hypr.MonitorSpec) (*FileChange, error) {
+	cfg := s.cfg.Get()
+	profile, ok := cfg.Profiles[profileName]
+	if !ok {
+		return nil, errors.New("profile not found")
 	}
 
-	err = s.updateConfigFileWithContent(profile.ConfigFile, rendered.String())
+	rendered, err := s.renderMonitors(tuiTemplate, currentMonitors)
 	if err != nil {
-		return fmt.Errorf("failed to update config file: %w", err)
+		return nil, err
 	}
 
-	return nil
+	// nolint:gosec
+	existingContent, err := os.ReadFile(profile.ConfigFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &FileChange{Path: profile.ConfigFile, After: string(rendered)}, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	content := string(existingContent)
+	return &FileChange{
+		Path:   profile.ConfigFile,
+		Before: content,
+		After:  s.newMethod(content, tuiStartMarker, tuiEndMarker, string(rendered)),
+	}, nil
 }
 
 // updateConfigFileWithContent reads the config file, finds TUI AUTO markers and replaces content between them,
 // or appends the content to the end if markers are not found
 func (s *Service) updateConfigFileWithContent(configFile, newContent string) error {
-	const (
-		startMarker = "# <<<<< TUI AUTO START"
-		endMarker   = "# <<<<< TUI AUTO END"
-	)
-
 	// nolint:gosec
 	existingContent, err := os.ReadFile(configFile)
 	if err != nil {
@@ -126,7 +194,7 @@ func (s *Service) updateConfigFileWithContent(configFile, newContent string) err
 	}
 
 	content := string(existingContent)
-	finalContent := s.newMethod(content, startMarker, endMarker, newContent)
+	finalContent := s.newMethod(content, tuiStartMarker, tuiEndMarker, newContent)
 	if err := utils.WriteAtomic(configFile, []byte(finalContent)); err != nil {
 		return fmt.Errorf("cant write new config: %w", err)
 	}
@@ -194,8 +262,7 @@ func (s *Service) append(profileSpec *bytes.Buffer, cfg *config.RawConfig) error
 	}
 	logrus.Debugf("Current config content %s", string(content))
 
-	appendContent := strings.Replace(profileSpec.String(), "[profiles]", "", 1)
-	newContent := fmt.Sprintf("%s\n%s", string(content), appendContent)
+	newContent := s.appended(string(content), profileSpec)
 	if err := utils.WriteAtomic(cfg.ConfigPath, []byte(newContent)); err != nil {
 		return fmt.Errorf("cant write the final config file: %w", err)
 	}
@@ -204,8 +271,29 @@ func (s *Service) append(profileSpec *bytes.Buffer, cfg *config.RawConfig) error
 	return nil
 }
 
+// appended returns the configuration file content with the encoded profile added at the end
+func (*Service) appended(content string, profileSpec *bytes.Buffer) string {
+	appendContent := strings.Replace(profileSpec.String(), "[profiles]", "", 1)
+	return fmt.Sprintf("%s\n%s", content, appendContent)
+}
+
 func (s *Service) render(currentMonitors hypr.MonitorSpecs, profile *config.Profile) (func() error, error) {
-	tmpl, err := template.New("config").Parse(monitorsTemplate)
+	renderedContent, err := s.renderMonitors(monitorsTemplate, currentMonitors)
+	if err != nil {
+		return nil, err
+	}
+	logrus.Debugf("Rendered data: %s", string(renderedContent))
+
+	if err := utils.WriteAtomic(profile.ConfigFile, renderedContent); err != nil {
+		return nil, fmt.Errorf("cant write to file: %w", err)
+	}
+	return func() error {
+		return os.Remove(profile.ConfigFile)
+	}, nil
+}
+
+func (s *Service) renderMonitors(templateText string, currentMonitors hypr.MonitorSpecs) ([]byte, error) {
+	tmpl, err := template.New("config").Parse(templateText)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse template: %w", err)
 	}
@@ -219,16 +307,7 @@ func (s *Service) render(currentMonitors hypr.MonitorSpecs, profile *config.Prof
 	if err := tmpl.Execute(&rendered, templateData); err != nil {
 		return nil, fmt.Errorf("failed to execute template: %w", err)
 	}
-
-	renderedContent := rendered.Bytes()
-	logrus.Debugf("Rendered data: %s", string(renderedContent))
-
-	if err := utils.WriteAtomic(profile.ConfigFile, renderedContent); err != nil {
-		return nil, fmt.Errorf("cant write to file: %w", err)
-	}
-	return func() error {
-		return os.Remove(profile.ConfigFile)
-	}, nil
+	return rendered.Bytes(), nil
 }
 
 func (*Service) encode(profile *config.Profile) (*bytes.Buffer, error) {
@@ -256,9 +335,6 @@ func (*Service) validate(cfg *config.RawConfig, profileName string, profile *con
 	if fi, _ := os.Stat(profile.ConfigFile); fi != nil {
 		return errors.New("template profile file already exists, pass another in --config-file-location")
 	}
-	if err := os.MkdirAll(filepath.Dir(profile.ConfigFile), 0o750); err != nil {
-		return fmt.Errorf("cant create directory: %w", err)
-	}
 	return nil
 }
 
@@ -206,3 +206,98 @@ func TestService_EditExisting(t *testing.T) {
 		})
 	}
 }
+
+func TestService_PreviewEditExisting(t *testing.T) {
+	monitors := []*hypr.MonitorSpec{
+		{
+			ID:          utils.IntPtr(1),
+			Name:        "monA",
+			Description: "New Monitor A",
+			Width:       2560,
+			Height:      1440,
+			RefreshRate: 120.0,
+			Scale:       1.5,
+		},
+	}
+	configFile := filepath.Join(t.TempDir(), "test_config.conf")
+	cfg := testutils.NewTestConfig(t).WithProfiles(map[string]*config.Profile{
+		"test-profile": {
+			Name:       "test-profile",
+			ConfigFile: configFile,
+			ConfigType: utils.JustPtr(config.Template),
+			Conditions: &config.ProfileCondition{
+				RequiredMonitors: []*config.RequiredMonitor{{Name: utils.StringPtr("monA")}},
+			},
+		},
+	}).Get()
+	input, err := os.ReadFile("testdata/existing_config_with_markers.conf")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(configFile, input, 0o600))
+
+	service := profilemaker.NewService(cfg, nil)
+	change, err := service.PreviewEditExisting("test-profile", monitors)
+	require.NoError(t, err)
+
+	contents, err := os.ReadFile(configFile)
+	require.NoError(t, err)
+	assert.Equal(t, string(input), string(contents), "preview should not write the file")
+	assert.Equal(t, configFile, change.Path)
+	assert.Equal(t, string(input), change.Before)
+
+	require.NoError(t, service.EditExisting("test-profile", monitors))
+	contents, err = os.ReadFile(configFile)
+	require.NoError(t, err)
+	assert.Equal(t, string(contents), change.After, "preview should match the written file")
+
+	_, err = service.PreviewEditExisting("non-existent-profile", monitors)
+	require.Error(t, err)
+	assert.Equal(t, "profile not found", err.Error())
+}
+
+func TestService_PreviewFreezeGivenAs(t *testing.T) {
+	monitors := []*hypr.MonitorSpec{
+		{
+			ID:          utils.IntPtr(0),
+			Name:        "eDP-1",
+			Description: "BOE NE135A1M-NY1",
+			Width:       2880,
+			Height:      1920,
+			RefreshRate: 120.0,
+			Scale:       2,
+		},
+	}
+	cfg := testutils.NewTestConfig(t).Get()
+	before, err := os.ReadFile(cfg.Get().ConfigPath)
+	require.NoError(t, err)
+
+	service := profilemaker.NewService(cfg, nil)
+	changes, err := service.PreviewFreezeGivenAs("laptop", "hyprconfigs/laptop.go.tmpl", monitors)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+
+	profileFile := filepath.Join(cfg.Get().ConfigDirPath, "hyprconfigs/laptop.go.tmpl")
+	assert.NoFileExists(t, profileFile, "preview should not write the profile file")
+	contents, err := os.ReadFile(cfg.Get().ConfigPath)
+	require.NoError(t, err)
+	assert.Equal(t, string(before), string(contents), "preview should not write the config")
+
+	assert.Equal(t, profileFile, changes[0].Path)
+	assert.Empty(t, changes[0].Before)
+	assert.Contains(t, changes[0].After, "monitor=desc:BOE NE135A1M-NY1,2880x1920@120.00000,0x0,2.00000000")
+	assert.Equal(t, cfg.Get().ConfigPath, changes[1].Path)
+	assert.Equal(t, string(before), changes[1].Before)
+	assert.Contains(t, changes[1].After, "[profiles.laptop]")
+
+	require.NoError(t, service.FreezeGivenAs("laptop", "hyprconfigs/laptop.go.tmpl", monitors))
+	for _, change := range changes {
+		contents, err := os.ReadFile(change.Path)
+		require.NoError(t, err)
+		assert.Equal(t, string(contents), change.After, "preview should match the written file")
+	}
+
+	require.NoError(t, cfg.Reload())
+	_, err = service.PreviewFreezeGivenAs("laptop", "hyprconfigs/other.go.tmpl", monitors)
+	require.Error(t, err)
+	assert.Equal(t, "cant validate basic new profile properties: a profile with this name already exists",
+		err.Error())
+}
@@ -6,8 +6,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 )
@@ -20,6 +22,10 @@ type ILidDetector interface {
 	Reload(context.Context) error
 }
 
+type IBatteryDetector interface {
+	Reload(context.Context) error
+}
+
 type IService interface {
 	UpdateOnce(context.Context) error
 }
@@ -30,48 +36,128 @@ type IFilewatcher interface {
 }
 
 type IGenerators interface {
-	ValidateTemplates() error
+	ValidateTemplatesOf(*config.RawConfig) error
+}
+
+type ISystemdNotifier interface {
+	Reloading() error
+	Ready() error
+}
+
+type INotifications interface {
+	NotifyInvalidConfig(error) error
+	NotifyConfigRecovered() error
 }
 
+// errInvalidConfig marks reload failures caused by the user configuration itself,
+// those are recoverable: the last good config stays active until the file is fixed
+var errInvalidConfig = errors.New("invalid configuration")
+
 type Service struct {
 	cfg                  *config.Config
 	filewatcher          IFilewatcher
 	powerDetector        IPowerDetector
 	lidDetector          ILidDetector
+	batteryDetector      IBatteryDetector
 	service              IService
 	gen                  IGenerators
+	notifications        INotifications
+	systemdNotifier      ISystemdNotifier
 	disableAutoHotReload *bool
+	invalid              bool
+	mu                   sync.Mutex
 }
 
 func NewService(cfg *config.Config, filewatcher IFilewatcher, powerDetector IPowerDetector,
-	service IService, disableAutoHotReload bool, lidDetector ILidDetector, gen IGenerators,
+	service IService, disableAutoHotReload bool, lidDetector ILidDetector, batteryDetector IBatteryDetector,
+	gen IGenerators, notifications INotifications, systemdNotifier ISystemdNotifier,
 ) *Service {
 	return &Service{
-		cfg,
-		filewatcher,
-		powerDetector,
-		lidDetector,
-		service,
-		gen,
-		&disableAutoHotReload,
+		cfg:                  cfg,
+		filewatcher:          filewatcher,
+		powerDetector:        powerDetector,
+		lidDetector:          lidDetector,
+		batteryDetector:      batteryDetector,
+		service:              service,
+		gen:                  gen,
+		notifications:        notifications,
+		systemdNotifier:      systemdNotifier,
+		disableAutoHotReload: &disableAutoHotReload,
 	}
 }
 
 func (s *Service) Handle(ctx context.Context) error {
-	return s.Reload(ctx)
+	return s.reloadOrNotify(ctx)
+}
+
+// reloadOrNotify reloads the application, an invalid configuration is not fatal:
+// it is logged and sent to the user, the daemon keeps running on the last good config
+func (s *Service) reloadOrNotify(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.Reload(ctx)
+	if err == nil {
+		if s.invalid {
+			s.invalid = false
+			logrus.WithFields(utils.NewLogrusEmptyFields().WithLogID(utils.ConfigRecoveredLogID)).
+				Info("Configuration is valid again")
+			if err := s.notifications.NotifyConfigRecovered(); err != nil {
+				logrus.WithError(err).Warn("cant notify about config recovery")
+			}
+		}
+		return nil
+	}
+
+	if !errors.Is(err, errInvalidConfig) {
+		return err
+	}
+
+	s.invalid = true
+	logrus.WithFields(utils.NewLogrusEmptyFields().WithLogID(utils.InvalidConfigLogID)).
+		WithError(err).Error("Invalid configuration, keeping the previous one")
+	if err := s.notifications.NotifyInvalidConfig(err); err != nil {
+		logrus.WithError(err).Warn("cant notify about invalid config")
+	}
+	return nil
+}
+
+func (s *Service) validateTemplates(cfg *config.RawConfig) error {
+	if err := s.gen.ValidateTemplatesOf(cfg); err != nil {
+		return fmt.Errorf("cant validate new templates: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) reloadConfig() error {
+	if err := s.cfg.Reload(s.validateTemplates); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidConfig, err)
+	}
+	return nil
 }
 
 func (s *Service) Reload(ctx context.Context) error {
+	if err := s.systemdNotifier.Reloading(); err != nil {
+		logrus.WithError(err).Warn("Cant notify systemd about the reload")
+	}
+	defer func() {
+		if err := s.systemdNotifier.Ready(); err != nil {
+			logrus.WithError(err).Warn("Cant notify systemd about readiness")
+		}
+	}()
+
 	updates := []struct {
 		Fun  func() error
 		Name string
 		Err  string
 	}{
-		{Fun: s.cfg.Reload, Name: "config reload", Err: "cant reload configuration"},
-		{Fun: s.gen.ValidateTemplates, Name: "generators template validation", Err: "cant validate new templates"},
+		{Fun: s.reloadConfig, Name: "config reload", Err: "cant reload configuration"},
 		{Fun: s.filewatcher.Update, Name: "update filewatcher", Err: "cant update filewatcher"},
 		{Fun: func() error { return s.powerDetector.Reload(ctx) }, Name: "power detector reload", Err: "cant reload powerDetector"},
 		{Fun: func() error { return s.lidDetector.Reload(ctx) }, Name: "lid detector reload", Err: "cant reload lidDetector"},
+		{
+			Fun:  func() error { return s.batteryDetector.Reload(ctx) },
+			Name: "battery detector reload", Err: "cant reload batteryDetector",
+		},
 		{
 			Fun:  func() error { return s.service.UpdateOnce(ctx) },
 			Name: "updating user configuration", Err: "cant update user service",
@@ -112,7 +198,7 @@ func (s *Service) Run(ctx context.Context) error {
 					return errors.New("watcher event channel closed")
 				}
 				logrus.Debug("Watcher event received")
-				if err := s.Reload(ctx); err != nil {
+				if err := s.reloadOrNotify(ctx); err != nil {
 					return fmt.Errorf("cant reload user configuration: %w", err)
 				}
 
@@ -3,9 +3,12 @@ package reloader_test
 import (
 	"context"
 	"errors"
+	"os"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/reloader"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/testutils"
 	"github.com/stretchr/testify/assert"
@@ -22,6 +25,16 @@ func (f *fakeLidDetector) Reload(ctx context.Context) error {
 	return f.reloadErr
 }
 
+type fakeBatteryDetector struct {
+	reloadErr   error
+	reloadCalls int
+}
+
+func (f *fakeBatteryDetector) Reload(ctx context.Context) error {
+	f.reloadCalls++
+	return f.reloadErr
+}
+
 type fakePowerDetector struct {
 	reloadErr   error
 	reloadCalls int
@@ -62,11 +75,52 @@ type fakeGenerator struct {
 	validateTemplatesCalls int
 }
 
-func (f *fakeGenerator) ValidateTemplates() error {
+func (f *fakeGenerator) ValidateTemplatesOf(*config.RawConfig) error {
 	f.validateTemplatesCalls++
 	return f.validateTemplatesErr
 }
 
+type fakeNotifications struct {
+	invalidErrs    []error
+	recoveredCalls int
+	mu             sync.Mutex
+}
+
+func (f *fakeNotifications) NotifyInvalidConfig(err error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.invalidErrs = append(f.invalidErrs, err)
+	return nil
+}
+
+func (f *fakeNotifications) NotifyConfigRecovered() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.recoveredCalls++
+	return nil
+}
+
+type fakeSystemdNotifier struct {
+	reloadingCalls int
+	readyCalls     int
+}
+
+func (f *fakeSystemdNotifier) Reloading() error {
+	f.reloadingCalls++
+	return nil
+}
+
+func (f *fakeSystemdNotifier) Ready() error {
+	f.readyCalls++
+	return nil
+}
+
+func (f *fakeNotifications) counts() (int, int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.invalidErrs), f.recoveredCalls
+}
+
 func TestService_Reload(t *testing.T) {
 	ctx := context.Background()
 	cfg := testutils.NewTestConfig(t).Get()
@@ -76,6 +130,7 @@ func TestService_Reload(t *testing.T) {
 		powerErr             error
 		serviceErr           error
 		lidErr               error
+		batteryErr           error
 		filewatcherErr       error
 		wantErr              bool
 		errContains          string
@@ -103,6 +158,12 @@ func TestService_Reload(t *testing.T) {
 			wantErr:     true,
 			errContains: "cant reload lidDetector",
 		},
+		{
+			name:        "battery detector reload fails",
+			batteryErr:  errors.New("battery detector error"),
+			wantErr:     true,
+			errContains: "cant reload batteryDetector",
+		},
 		{
 			name:        "service update fails",
 			serviceErr:  errors.New("service error"),
@@ -123,11 +184,17 @@ func TestService_Reload(t *testing.T) {
 			service := &fakeService{updateErr: tt.serviceErr}
 			filewatcher := &fakeFilewatcher{updateErr: tt.filewatcherErr}
 			lidDetector := &fakeLidDetector{reloadErr: tt.lidErr}
+			batteryDetector := &fakeBatteryDetector{reloadErr: tt.batteryErr}
 			generator := &fakeGenerator{validateTemplatesErr: tt.validateTemplatesErr}
 
-			reloaderService := reloader.NewService(cfg, filewatcher, powerDetector, service, false, lidDetector, generator)
+			systemdNotifier := &fakeSystemdNotifier{}
+
+			reloaderService := reloader.NewService(cfg, filewatcher, powerDetector, service, false, lidDetector, batteryDetector,
+				generator, &fakeNotifications{}, systemdNotifier)
 
 			err := reloaderService.Reload(ctx)
+			assert.Equal(t, 1, systemdNotifier.reloadingCalls, "reload should be announced")
+			assert.Equal(t, 1, systemdNotifier.readyCalls, "reload should always be followed by readiness")
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -137,6 +204,7 @@ func TestService_Reload(t *testing.T) {
 				assert.Equal(t, 1, filewatcher.updateCalls)
 				assert.Equal(t, 1, powerDetector.reloadCalls)
 				assert.Equal(t, 1, lidDetector.reloadCalls)
+				assert.Equal(t, 1, batteryDetector.reloadCalls)
 				assert.Equal(t, 1, service.updateCalls)
 				assert.Equal(t, 1, generator.validateTemplatesCalls)
 			}
@@ -186,7 +254,7 @@ func TestService_Run(t *testing.T) {
 			generator := &fakeGenerator{}
 
 			reloaderService := reloader.NewService(cfg, filewatcher, powerDetector,
-				service, tt.hotReloadDisabled, lidDetector, generator)
+				service, tt.hotReloadDisabled, lidDetector, &fakeBatteryDetector{}, generator, &fakeNotifications{}, &fakeSystemdNotifier{})
 
 			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
 			defer cancel()
@@ -221,3 +289,56 @@ func TestService_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestService_Run_InvalidConfig(t *testing.T) {
+	cfg := testutils.NewTestConfig(t).Get()
+	configPath := cfg.Get().ConfigPath
+	validContents, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	lastGood := cfg.Get()
+
+	powerDetector := &fakePowerDetector{}
+	service := &fakeService{}
+	channel := make(chan interface{}, 1)
+	filewatcher := &fakeFilewatcher{channel: channel}
+	lidDetector := &fakeLidDetector{}
+	notifications := &fakeNotifications{}
+
+	reloaderService := reloader.NewService(cfg, filewatcher, powerDetector,
+		service, false, lidDetector, &fakeBatteryDetector{}, &fakeGenerator{}, notifications, &fakeSystemdNotifier{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- reloaderService.Run(ctx)
+	}()
+
+	// nolint:gosec
+	require.NoError(t, os.WriteFile(configPath, []byte("[general]\ndestination = \n"), 0o644))
+	channel <- true
+	require.Eventually(t, func() bool {
+		invalid, _ := notifications.counts()
+		return invalid == 1
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Same(t, lastGood, cfg.Get(), "last good config should be kept")
+
+	// nolint:gosec
+	require.NoError(t, os.WriteFile(configPath, validContents, 0o644))
+	channel <- true
+	require.Eventually(t, func() bool {
+		_, recovered := notifications.counts()
+		return recovered == 1
+	}, time.Second, 10*time.Millisecond)
+
+	assert.NotSame(t, lastGood, cfg.Get(), "fixed config should be applied")
+
+	cancel()
+	err = <-errCh
+	assert.ErrorIs(t, err, context.Canceled)
+
+	assert.Contains(t, notifications.invalidErrs[0].Error(), configPath+":2:")
+	assert.Equal(t, 1, service.updateCalls, "only the valid config should reach the service")
+}
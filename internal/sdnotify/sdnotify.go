@@ -0,0 +1,148 @@
+// Package sdnotify implements the systemd notify protocol (sd_notify) over $NOTIFY_SOCKET,
+// it reports readiness, reloads, shutdown and liveness (watchdog) of the daemon
+package sdnotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	notifySocketEnv = "NOTIFY_SOCKET"
+	watchdogUsecEnv = "WATCHDOG_USEC"
+	watchdogPidEnv  = "WATCHDOG_PID"
+)
+
+// HealthCheck reports an error when a dependency of the daemon is not healthy
+type HealthCheck func() error
+
+// Notifier sends state updates to the service manager, all methods are no-ops
+// when the process is not started with $NOTIFY_SOCKET
+type Notifier struct {
+	socket string
+}
+
+func NewNotifier() *Notifier {
+	socket := os.Getenv(notifySocketEnv)
+	// abstract namespace sockets are prefixed with '@'
+	if strings.HasPrefix(socket, "@") {
+		socket = "\x00" + socket[1:]
+	}
+	return &Notifier{socket: socket}
+}
+
+func (n *Notifier) Enabled() bool {
+	return n != nil && n.socket != ""
+}
+
+// Notify sends the given newline separated assignments (e.g. READY=1) in a single datagram
+func (n *Notifier) Notify(states ...string) error {
+	if !n.Enabled() {
+		return nil
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: n.socket, Net: "unixgram"})
+	if err != nil {
+		return fmt.Errorf("cant connect to the notify socket: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte(strings.Join(states, "\n"))); err != nil {
+		return fmt.Errorf("cant write to the notify socket: %w", err)
+	}
+	return nil
+}
+
+func (n *Notifier) Ready() error {
+	return n.Notify("READY=1")
+}
+
+// Reloading tells the service manager a reload started, it has to be followed by Ready
+func (n *Notifier) Reloading() error {
+	var ts unix.Timespec
+	if err := unix.ClockGettime(unix.CLOCK_MONOTONIC, &ts); err != nil {
+		return fmt.Errorf("cant read the monotonic clock: %w", err)
+	}
+	usec := ts.Nano() / int64(time.Microsecond)
+	return n.Notify("RELOADING=1", "MONOTONIC_USEC="+strconv.FormatInt(usec, 10))
+}
+
+func (n *Notifier) Stopping() error {
+	return n.Notify("STOPPING=1")
+}
+
+func (n *Notifier) Status(status string) error {
+	// the protocol is line based, a newline would start a new assignment
+	return n.Notify("STATUS=" + strings.ReplaceAll(status, "\n", " "))
+}
+
+func (n *Notifier) Watchdog() error {
+	return n.Notify("WATCHDOG=1")
+}
+
+// WatchdogInterval returns the watchdog timeout requested by the service manager (WatchdogSec=)
+func (n *Notifier) WatchdogInterval() (time.Duration, bool) {
+	if !n.Enabled() {
+		return 0, false
+	}
+
+	usec, err := strconv.ParseInt(os.Getenv(watchdogUsecEnv), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0, false
+	}
+
+	if pid := os.Getenv(watchdogPidEnv); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0, false
+	}
+
+	return time.Duration(usec) * time.Microsecond, true
+}
+
+// RunWatchdog pings the service manager at half of the watchdog interval for as long as
+// all health checks pass, when a check fails the ping is skipped so a stuck daemon gets restarted
+func (n *Notifier) RunWatchdog(ctx context.Context, checks ...HealthCheck) error {
+	interval, ok := n.WatchdogInterval()
+	if !ok {
+		logrus.Debug("Watchdog is not enabled, waiting for ctx cancellation")
+		<-ctx.Done()
+		return context.Cause(ctx)
+	}
+
+	logrus.WithField("interval", interval).Info("Systemd watchdog enabled")
+	ticker := time.NewTicker(interval / 2)
+	defer ticker.Stop()
+
+	for {
+		if err := runHealthChecks(checks); err != nil {
+			logrus.WithError(err).Warn("Health check failed, not pinging the watchdog")
+		} else if err := n.Watchdog(); err != nil {
+			logrus.WithError(err).Warn("Cant ping the watchdog")
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			logrus.Debug("Watchdog context cancelled, shutting down")
+			return context.Cause(ctx)
+		}
+	}
+}
+
+func runHealthChecks(checks []HealthCheck) error {
+	errs := []error{}
+	for _, check := range checks {
+		if err := check(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,169 @@
+package sdnotify_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/sdnotify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listen(t *testing.T) *net.UnixConn {
+	socket := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	t.Setenv("NOTIFY_SOCKET", socket)
+	return conn
+}
+
+func read(t *testing.T, conn *net.UnixConn) string {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	buf := make([]byte, 4096)
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	return string(buf[:n])
+}
+
+func TestNotifier_States(t *testing.T) {
+	conn := listen(t)
+	notifier := sdnotify.NewNotifier()
+	require.True(t, notifier.Enabled())
+
+	require.NoError(t, notifier.Ready())
+	assert.Equal(t, "READY=1", read(t, conn))
+
+	require.NoError(t, notifier.Status("Active profile: laptop\nsecond line"))
+	assert.Equal(t, "STATUS=Active profile: laptop second line", read(t, conn))
+
+	require.NoError(t, notifier.Reloading())
+	reloading := strings.Split(read(t, conn), "\n")
+	require.Len(t, reloading, 2)
+	assert.Equal(t, "RELOADING=1", reloading[0])
+	assert.True(t, strings.HasPrefix(reloading[1], "MONOTONIC_USEC="))
+
+	require.NoError(t, notifier.Stopping())
+	assert.Equal(t, "STOPPING=1", read(t, conn))
+}
+
+func TestNotifier_Disabled(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+	notifier := sdnotify.NewNotifier()
+	assert.False(t, notifier.Enabled())
+	assert.NoError(t, notifier.Ready())
+
+	_, ok := notifier.WatchdogInterval()
+	assert.False(t, ok)
+}
+
+func TestNotifier_WatchdogInterval(t *testing.T) {
+	tests := []struct {
+		name             string
+		usec             string
+		pid              string
+		expectedInterval time.Duration
+		expectedOk       bool
+	}{
+		{
+			name:             "enabled",
+			usec:             "2000000",
+			expectedInterval: 2 * time.Second,
+			expectedOk:       true,
+		},
+		{
+			name:             "matching pid",
+			usec:             "2000000",
+			pid:              "self",
+			expectedInterval: 2 * time.Second,
+			expectedOk:       true,
+		},
+		{
+			name: "other pid",
+			usec: "2000000",
+			pid:  "1",
+		},
+		{
+			name: "not set",
+		},
+		{
+			name: "invalid",
+			usec: "abc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listen(t)
+			t.Setenv("WATCHDOG_USEC", tt.usec)
+			pid := tt.pid
+			if pid == "self" {
+				pid = strconv.Itoa(os.Getpid())
+			}
+			t.Setenv("WATCHDOG_PID", pid)
+
+			interval, ok := sdnotify.NewNotifier().WatchdogInterval()
+			assert.Equal(t, tt.expectedOk, ok)
+			assert.Equal(t, tt.expectedInterval, interval)
+		})
+	}
+}
+
+func TestNotifier_RunWatchdog(t *testing.T) {
+	tests := []struct {
+		name        string
+		healthErr   error
+		expectPings bool
+	}{
+		{
+			name:        "healthy",
+			expectPings: true,
+		},
+		{
+			name:      "unhealthy",
+			healthErr: errors.New("hyprland is gone"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := listen(t)
+			t.Setenv("WATCHDOG_USEC", "100000")
+			t.Setenv("WATCHDOG_PID", "")
+
+			ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+			defer cancel()
+
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- sdnotify.NewNotifier().RunWatchdog(ctx, func() error { return tt.healthErr })
+			}()
+
+			pings := 0
+			buf := make([]byte, 64)
+			require.NoError(t, conn.SetReadDeadline(time.Now().Add(200*time.Millisecond)))
+			for {
+				n, err := conn.Read(buf)
+				if err != nil {
+					break
+				}
+				assert.Equal(t, "WATCHDOG=1", string(buf[:n]))
+				pings++
+			}
+
+			assert.ErrorIs(t, <-errCh, context.DeadlineExceeded)
+			if tt.expectPings {
+				assert.GreaterOrEqual(t, pings, 3, "should ping at half of the interval")
+			} else {
+				assert.Equal(t, 0, pings)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package simulate
+
+import (
+	"sync"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/hypr"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/power"
+)
+
+// MonitorDetector is an in-memory userconfigupdater.IMonitorDetector
+type MonitorDetector struct {
+	mu             sync.RWMutex
+	monitors       hypr.MonitorSpecs
+	events         chan hypr.MonitorSpecs
+	reconnected    chan struct{}
+	configReloaded chan struct{}
+}
+
+func NewMonitorDetector(monitors hypr.MonitorSpecs) *MonitorDetector {
+	return &MonitorDetector{
+		monitors:       monitors,
+		events:         make(chan hypr.MonitorSpecs, 10),
+		reconnected:    make(chan struct{}),
+		configReloaded: make(chan struct{}),
+	}
+}
+
+func (m *MonitorDetector) Listen() <-chan hypr.MonitorSpecs {
+	return m.events
+}
+
+func (m *MonitorDetector) GetConnectedMonitors() hypr.MonitorSpecs {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.monitors
+}
+
+func (m *MonitorDetector) Reconnected() <-chan struct{} {
+	return m.reconnected
+}
+
+func (m *MonitorDetector) ConfigReloaded() <-chan struct{} {
+	return m.configReloaded
+}
+
+// Set replaces the connected monitors and emits a monitor event
+func (m *MonitorDetector) Set(monitors hypr.MonitorSpecs) {
+	m.mu.Lock()
+	m.monitors = monitors
+	m.mu.Unlock()
+	m.events <- monitors
+}
+
+// PowerDetector is an in-memory userconfigupdater.IPowerDetector
+type PowerDetector struct {
+	mu     sync.RWMutex
+	state  power.PowerState
+	events chan power.PowerEvent
+}
+
+func NewPowerDetector(state power.PowerState) *PowerDetector {
+	return &PowerDetector{state: state, events: make(chan power.PowerEvent, 10)}
+}
+
+func (p *PowerDetector) GetCurrentState() power.PowerState {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.state
+}
+
+func (p *PowerDetector) Listen() <-chan power.PowerEvent {
+	return p.events
+}
+
+// Set emits a power event when the state changes
+func (p *PowerDetector) Set(state power.PowerState) {
+	p.mu.Lock()
+	changed := p.state != state
+	p.state = state
+	p.mu.Unlock()
+	if changed {
+		p.events <- power.PowerEvent{State: state}
+	}
+}
+
+// LidDetector is an in-memory userconfigupdater.ILidDetector
+type LidDetector struct {
+	mu     sync.RWMutex
+	state  power.LidState
+	events chan power.LidEvent
+}
+
+func NewLidDetector(state power.LidState) *LidDetector {
+	return &LidDetector{state: state, events: make(chan power.LidEvent, 10)}
+}
+
+func (l *LidDetector) GetCurrentState() power.LidState {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.state
+}
+
+func (l *LidDetector) Listen() <-chan power.LidEvent {
+	return l.events
+}
+
+// Set emits a lid event when the state changes
+func (l *LidDetector) Set(state power.LidState) {
+	l.mu.Lock()
+	changed := l.state != state
+	l.state = state
+	l.mu.Unlock()
+	if changed {
+		l.events <- power.LidEvent{State: state}
+	}
+}
+
+// BatteryDetector is an in-memory userconfigupdater.IBatteryDetector, it applies the same
+// threshold hysteresis as the D-Bus detector
+type BatteryDetector struct {
+	cfg    *config.Config
+	mu     sync.RWMutex
+	state  power.BatteryState
+	events chan power.BatteryEvent
+}
+
+func NewBatteryDetector(cfg *config.Config) *BatteryDetector {
+	return &BatteryDetector{cfg: cfg, events: make(chan power.BatteryEvent, 10)}
+}
+
+func (b *BatteryDetector) GetCurrentState() power.BatteryState {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.state
+}
+
+func (b *BatteryDetector) Listen() <-chan power.BatteryEvent {
+	return b.events
+}
+
+// Set updates the battery state and emits an event when a threshold is crossed or the profile changes
+func (b *BatteryDetector) Set(percentage float64, profile power.PowerProfile) {
+	previous, next := b.update(percentage, profile)
+	if !next.SameThresholds(previous) {
+		b.events <- power.BatteryEvent{State: next}
+	}
+}
+
+// Init sets the initial battery state without emitting an event
+func (b *BatteryDetector) Init(percentage float64, profile power.PowerProfile) {
+	b.update(percentage, profile)
+}
+
+func (b *BatteryDetector) update(percentage float64, profile power.PowerProfile) (power.BatteryState, power.BatteryState) {
+	cfg := b.cfg.Get()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	previous := b.state
+	b.state = previous.Update(percentage, profile, cfg.BatteryThresholds(), *cfg.BatteryEvents.HysteresisPercent)
+	return previous, b.state
+}
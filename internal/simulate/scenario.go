@@ -0,0 +1,189 @@
+// Package simulate replays scripted monitor/power/lid scenarios through the
+// profile matching and config generation pipeline without real hardware
+package simulate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/hypr"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/power"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+)
+
+// Scenario is a list of steps, each step changes part of the simulated world at its offset
+type Scenario struct {
+	Steps []*Step `json:"steps"`
+}
+
+// Step describes the world at a point in time, fields that are not set carry over from the previous step
+type Step struct {
+	OffsetMs          int             `json:"offset_ms"`
+	Monitors          json.RawMessage `json:"monitors"`
+	MonitorsFile      string          `json:"monitors_file"`
+	PowerState        string          `json:"power_state"`
+	LidState          string          `json:"lid_state"`
+	BatteryPercentage *float64        `json:"battery_percentage"`
+	PowerProfile      string          `json:"power_profile"`
+
+	monitors     hypr.MonitorSpecs
+	hasMonitors  bool
+	powerState   power.PowerState
+	lidState     power.LidState
+	powerProfile power.PowerProfile
+}
+
+// LoadScenario reads a JSON scenario, monitors_file paths are relative to the scenario file
+func LoadScenario(path string) (*Scenario, error) {
+	//nolint:gosec
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cant read scenario file: %w", err)
+	}
+
+	scenario := &Scenario{}
+	if err := utils.UnmarshalResponse(contents, scenario); err != nil {
+		return nil, fmt.Errorf("cant parse scenario file: %w", err)
+	}
+
+	if err := scenario.Validate(filepath.Dir(path)); err != nil {
+		return nil, fmt.Errorf("scenario %s is invalid: %w", path, err)
+	}
+	return scenario, nil
+}
+
+func (s *Scenario) Validate(dir string) error {
+	if len(s.Steps) == 0 {
+		return errors.New("at least one step is required")
+	}
+	if s.Steps[0].OffsetMs != 0 {
+		return errors.New("the first step needs to start at offset_ms 0")
+	}
+
+	for i, step := range s.Steps {
+		if i > 0 && step.OffsetMs < s.Steps[i-1].OffsetMs {
+			return fmt.Errorf("step %d: offset_ms needs to be non-decreasing", i+1)
+		}
+		if err := step.Validate(dir); err != nil {
+			return fmt.Errorf("step %d: %w", i+1, err)
+		}
+	}
+
+	if !s.Steps[0].hasMonitors {
+		return errors.New("the first step needs to define the monitors")
+	}
+
+	hasBattery := false
+	for i, step := range s.Steps {
+		hasBattery = hasBattery || step.BatteryPercentage != nil
+		if step.PowerProfile != "" && !hasBattery {
+			return fmt.Errorf("step %d: power_profile requires battery_percentage in this or an earlier step", i+1)
+		}
+	}
+	return nil
+}
+
+func (s *Step) Validate(dir string) error {
+	if s.OffsetMs < 0 {
+		return errors.New("offset_ms cant be negative")
+	}
+	if len(s.Monitors) > 0 && s.MonitorsFile != "" {
+		return errors.New("only one of monitors and monitors_file can be set")
+	}
+
+	monitorsJSON := []byte(s.Monitors)
+	if s.MonitorsFile != "" {
+		path := s.MonitorsFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		//nolint:gosec
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("cant read monitors file: %w", err)
+		}
+		monitorsJSON = contents
+	}
+	if len(monitorsJSON) > 0 {
+		if err := utils.UnmarshalResponse(monitorsJSON, &s.monitors); err != nil {
+			return fmt.Errorf("cant parse monitors: %w", err)
+		}
+		for _, monitor := range s.monitors {
+			if err := monitor.Validate(); err != nil {
+				return fmt.Errorf("invalid monitor: %w", err)
+			}
+		}
+		s.hasMonitors = true
+	}
+
+	switch s.PowerState {
+	case "":
+	case power.ACPowerState.String():
+		s.powerState = power.ACPowerState
+	case power.BatteryPowerState.String():
+		s.powerState = power.BatteryPowerState
+	default:
+		return fmt.Errorf("invalid power_state %q, expecting AC or BAT", s.PowerState)
+	}
+
+	switch s.LidState {
+	case "":
+	case power.OpenedLidState.String():
+		s.lidState = power.OpenedLidState
+	case power.ClosedLidState.String():
+		s.lidState = power.ClosedLidState
+	default:
+		return fmt.Errorf("invalid lid_state %q, expecting Opened or Closed", s.LidState)
+	}
+
+	if s.BatteryPercentage != nil && (*s.BatteryPercentage < 0 || *s.BatteryPercentage > 100) {
+		return fmt.Errorf("battery_percentage %v needs to be within [0, 100]", *s.BatteryPercentage)
+	}
+
+	if s.PowerProfile != "" {
+		profile, ok := power.ParsePowerProfile(s.PowerProfile)
+		if !ok {
+			return fmt.Errorf("invalid power_profile %q", s.PowerProfile)
+		}
+		s.powerProfile = profile
+	}
+
+	return nil
+}
+
+func (s *Step) Offset() time.Duration {
+	return time.Duration(s.OffsetMs) * time.Millisecond
+}
+
+// Describe summarizes what the step changes
+func (s *Step) Describe() string {
+	changes := []string{}
+	if s.hasMonitors {
+		names := []string{}
+		for _, monitor := range s.monitors {
+			names = append(names, monitor.Name)
+		}
+		changes = append(changes, "monitors=["+strings.Join(names, ",")+"]")
+	}
+	if s.PowerState != "" {
+		changes = append(changes, "power="+s.PowerState)
+	}
+	if s.LidState != "" {
+		changes = append(changes, "lid="+s.LidState)
+	}
+	if s.BatteryPercentage != nil {
+		changes = append(changes, fmt.Sprintf("battery=%v%%", *s.BatteryPercentage))
+	}
+	if s.PowerProfile != "" {
+		changes = append(changes, "power_profile="+s.PowerProfile)
+	}
+	if len(changes) == 0 {
+		return "no changes"
+	}
+	return strings.Join(changes, " ")
+}
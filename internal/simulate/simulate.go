@@ -0,0 +1,181 @@
+package simulate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/generators"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/matchers"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/notifications"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/power"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/userconfigupdater"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+	"golang.org/x/sync/errgroup"
+)
+
+// settleMargin is waited on top of the longest debounce after the last step
+const settleMargin = 200 * time.Millisecond
+
+var errScenarioFinished = errors.New("scenario finished")
+
+// PrepareConfig redirects the output to destination and turns off side effects,
+// desktop notifications are never sent and callbacks only run when requested
+func PrepareConfig(cfg *config.Config, destination string, runCallbacks bool) {
+	raw := cfg.Get()
+	raw.General.Destination = utils.StringPtr(destination)
+	raw.Notifications.Disabled = utils.BoolPtr(true)
+	if runCallbacks {
+		return
+	}
+	raw.General.PreApplyExec = nil
+	raw.General.PostApplyExec = nil
+	for _, profile := range raw.Profiles {
+		profile.PreApplyExec = nil
+		profile.PostApplyExec = nil
+	}
+	if raw.FallbackProfile != nil {
+		raw.FallbackProfile.PreApplyExec = nil
+		raw.FallbackProfile.PostApplyExec = nil
+	}
+}
+
+// output serializes the lines written by the driver and the service
+type output struct {
+	mu    sync.Mutex
+	w     io.Writer
+	start time.Time
+}
+
+func (o *output) printf(format string, args ...any) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	elapsed := time.Since(o.start).Milliseconds()
+	_, _ = fmt.Fprintf(o.w, "[%6dms] "+format+"\n", append([]any{elapsed}, args...)...)
+}
+
+// statusRecorder captures the profile status the service reports to systemd
+type statusRecorder struct {
+	out *output
+}
+
+func (s *statusRecorder) Ready() error {
+	return nil
+}
+
+func (s *statusRecorder) Status(status string) error {
+	s.out.printf("  -> %s", status)
+	return nil
+}
+
+// lidSwitchInhibitor records the inhibitor state instead of talking to logind
+type lidSwitchInhibitor struct {
+	out  *output
+	held bool
+}
+
+func (l *lidSwitchInhibitor) Set(_ context.Context, inhibit bool) error {
+	if l.held != inhibit {
+		l.out.printf("  -> lid switch inhibitor held: %t", inhibit)
+	}
+	l.held = inhibit
+	return nil
+}
+
+// Run replays the scenario through the profile matching and config generation pipeline,
+// every step and every profile evaluation is printed to out with its offset
+func Run(ctx context.Context, cfg *config.Config, scenario *Scenario, out io.Writer) error {
+	generator, err := generators.NewConfigGenerator(cfg)
+	if err != nil {
+		return fmt.Errorf("cant create config generator: %w", err)
+	}
+
+	first := scenario.Steps[0]
+	powerState := power.ACPowerState
+	if first.PowerState != "" {
+		powerState = first.powerState
+	}
+	monitorDetector := NewMonitorDetector(first.monitors)
+	powerDetector := NewPowerDetector(powerState)
+	lidDetector := NewLidDetector(first.lidState)
+	batteryDetector := NewBatteryDetector(cfg)
+	profile := first.powerProfile
+	if first.BatteryPercentage != nil {
+		batteryDetector.Init(*first.BatteryPercentage, profile)
+	}
+
+	o := &output{w: out, start: time.Now()}
+	svc := userconfigupdater.NewService(cfg, monitorDetector, powerDetector, &userconfigupdater.Config{},
+		matchers.NewMatcher(), generator, notifications.NewService(cfg), lidDetector, batteryDetector,
+		&statusRecorder{out: o}, &lidSwitchInhibitor{out: o})
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(context.Canceled)
+	eg, ctx := errgroup.WithContext(ctx)
+
+	o.printf("step 1/%d: %s", len(scenario.Steps), first.Describe())
+	eg.Go(func() error {
+		return svc.Run(ctx)
+	})
+
+	eg.Go(func() error {
+		percentage := first.BatteryPercentage
+		for i, step := range scenario.Steps[1:] {
+			if err := sleepUntil(ctx, o.start.Add(step.Offset())); err != nil {
+				return err
+			}
+			o.printf("step %d/%d: %s", i+2, len(scenario.Steps), step.Describe())
+			if step.hasMonitors {
+				monitorDetector.Set(step.monitors)
+			}
+			if step.PowerState != "" {
+				powerDetector.Set(step.powerState)
+			}
+			if step.LidState != "" {
+				lidDetector.Set(step.lidState)
+			}
+			if step.BatteryPercentage != nil {
+				percentage = step.BatteryPercentage
+			}
+			if step.PowerProfile != "" {
+				profile = step.powerProfile
+			}
+			if step.BatteryPercentage != nil || step.PowerProfile != "" {
+				batteryDetector.Set(*percentage, profile)
+			}
+		}
+
+		if err := sleepUntil(ctx, time.Now().Add(settleTime(cfg))); err != nil {
+			return err
+		}
+		cancel(errScenarioFinished)
+		return nil
+	})
+
+	if err := eg.Wait(); err != nil && !errors.Is(err, errScenarioFinished) {
+		return fmt.Errorf("simulation failed: %w", err)
+	}
+	return nil
+}
+
+// settleTime is how long the last step needs to be fully processed by the debouncer
+func settleTime(cfg *config.Config) time.Duration {
+	general := cfg.Get().General
+	longest := max(*general.MonitorDebounceTimeMs, *general.PowerDebounceTimeMs, *general.LidDebounceTimeMs)
+	return time.Duration(longest)*time.Millisecond + settleMargin
+}
+
+func sleepUntil(ctx context.Context, deadline time.Time) error {
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
@@ -0,0 +1,155 @@
+package simulate_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/simulate"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/testutils"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	laptopOnly = `[{"id": 0, "name": "eDP-1", "description": "BOE NE135A1M-NY1"}]`
+	docked     = `[{"id": 0, "name": "eDP-1", "description": "BOE NE135A1M-NY1"},
+		{"id": 1, "name": "DP-1", "description": "LG Electronics LG SDQHD"}]`
+)
+
+func writeScenario(t *testing.T, contents string) string {
+	path := filepath.Join(t.TempDir(), "scenario.json")
+	// nolint:gosec
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o644))
+	return path
+}
+
+func TestRun(t *testing.T) {
+	cfg := testutils.NewTestConfig(t).WithProfiles(map[string]*config.Profile{
+		"laptop": {
+			Name: "laptop",
+			Conditions: &config.ProfileCondition{
+				RequiredMonitors: []*config.RequiredMonitor{{Name: utils.StringPtr("eDP-1")}},
+			},
+		},
+		"docked": {
+			Name:      "docked",
+			Clamshell: utils.BoolPtr(true),
+			Conditions: &config.ProfileCondition{
+				LidState: utils.JustPtr(config.ClosedLidStateType),
+				RequiredMonitors: []*config.RequiredMonitor{
+					{Name: utils.StringPtr("eDP-1")},
+					{Name: utils.StringPtr("DP-1")},
+				},
+			},
+		},
+	}).WithServiceDebounceTime(10).Get()
+
+	destination := filepath.Join(t.TempDir(), "monitors.conf")
+	simulate.PrepareConfig(cfg, destination, false)
+
+	scenario, err := simulate.LoadScenario(writeScenario(t, `{"steps": [
+		{"offset_ms": 0, "monitors": `+laptopOnly+`, "lid_state": "Opened"},
+		{"offset_ms": 100, "monitors": `+docked+`, "lid_state": "Closed"},
+		{"offset_ms": 200, "monitors": `+laptopOnly+`, "lid_state": "Opened"}
+	]}`))
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, simulate.Run(context.Background(), cfg, scenario, out))
+
+	expected := []string{
+		"step 1/3: monitors=[eDP-1] lid=Opened",
+		"-> Active profile: laptop",
+		"step 2/3: monitors=[eDP-1,DP-1] lid=Closed",
+		"-> Active profile: docked",
+		"-> lid switch inhibitor held: true",
+		"step 3/3: monitors=[eDP-1] lid=Opened",
+		"-> Active profile: laptop",
+		"-> lid switch inhibitor held: false",
+	}
+	remaining := out.String()
+	for _, line := range expected {
+		idx := strings.Index(remaining, line)
+		require.NotEqual(t, -1, idx, "expected %q in order, got:\n%s", line, out.String())
+		remaining = remaining[idx+len(line):]
+	}
+	assert.FileExists(t, destination)
+}
+
+func TestLoadScenario_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		scenario string
+		errMsg   string
+	}{
+		{
+			name:     "no steps",
+			scenario: `{"steps": []}`,
+			errMsg:   "at least one step is required",
+		},
+		{
+			name:     "first step offset",
+			scenario: `{"steps": [{"offset_ms": 10, "monitors": ` + laptopOnly + `}]}`,
+			errMsg:   "offset_ms 0",
+		},
+		{
+			name:     "first step without monitors",
+			scenario: `{"steps": [{"offset_ms": 0, "power_state": "AC"}]}`,
+			errMsg:   "needs to define the monitors",
+		},
+		{
+			name: "decreasing offsets",
+			scenario: `{"steps": [{"offset_ms": 0, "monitors": ` + laptopOnly + `},
+				{"offset_ms": 100}, {"offset_ms": 50}]}`,
+			errMsg: "step 3: offset_ms needs to be non-decreasing",
+		},
+		{
+			name:     "invalid power state",
+			scenario: `{"steps": [{"offset_ms": 0, "monitors": ` + laptopOnly + `, "power_state": "DC"}]}`,
+			errMsg:   "invalid power_state",
+		},
+		{
+			name:     "invalid lid state",
+			scenario: `{"steps": [{"offset_ms": 0, "monitors": ` + laptopOnly + `, "lid_state": "Ajar"}]}`,
+			errMsg:   "invalid lid_state",
+		},
+		{
+			name:     "power profile without battery",
+			scenario: `{"steps": [{"offset_ms": 0, "monitors": ` + laptopOnly + `, "power_profile": "power-saver"}]}`,
+			errMsg:   "requires battery_percentage",
+		},
+		{
+			name:     "missing monitors file",
+			scenario: `{"steps": [{"offset_ms": 0, "monitors_file": "missing.json"}]}`,
+			errMsg:   "cant read monitors file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := simulate.LoadScenario(writeScenario(t, tt.scenario))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestLoadScenario_MonitorsFile(t *testing.T) {
+	dir := t.TempDir()
+	// nolint:gosec
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "docked.json"), []byte(docked), 0o644))
+	path := filepath.Join(dir, "scenario.json")
+	// nolint:gosec
+	require.NoError(t, os.WriteFile(path, []byte(`{"steps": [{"offset_ms": 0, "monitors_file": "docked.json"}]}`), 0o644))
+
+	scenario, err := simulate.LoadScenario(path)
+	require.NoError(t, err)
+	require.Len(t, scenario.Steps, 1)
+	assert.Equal(t, "monitors=[eDP-1,DP-1]", scenario.Steps[0].Describe())
+}
@@ -19,8 +19,10 @@ const (
 	testInterface  = "com.test.PowerDetector.Interface"
 	testSignalName = "com.test.PowerDetector.Interface.PowerChanged"
 	testProperty   = "TestProperty"
-	testMethodName = "org.freedesktop.DBus.Properties.Get"
-	testMemberName = "PowerChanged"
+	// testPercentageProperty is the battery percentage, a double like the UPower one
+	testPercentageProperty = "Percentage"
+	testMethodName         = "org.freedesktop.DBus.Properties.Get"
+	testMemberName         = "PowerChanged"
 )
 
 func GenerateTestBusName() string {
@@ -36,6 +38,7 @@ func GenerateTestObjectPath() string {
 type TestDbusService struct {
 	conn           *dbus.Conn
 	propertyValue  bool
+	percentage     float64
 	testObjectPath string
 	t              *testing.T
 }
@@ -54,6 +57,9 @@ func (s *TestDbusService) Get(interfaceName, propertyName string) (dbus.Variant,
 	if interfaceName == testInterface && propertyName == testProperty {
 		return dbus.MakeVariant(s.propertyValue), nil
 	}
+	if interfaceName == testInterface && propertyName == testPercentageProperty {
+		return dbus.MakeVariant(s.percentage), nil
+	}
 	return dbus.MakeVariant(false), dbus.NewError("org.freedesktop.DBus.Error.InvalidArgs", nil)
 }
 
@@ -79,6 +85,10 @@ func (s *TestDbusService) SetProperty(value power.PowerState) {
 	}
 }
 
+func (s *TestDbusService) SetBatteryPercentage(percentage float64) {
+	s.percentage = percentage
+}
+
 func (s *TestDbusService) EmitSignal() error {
 	if err := s.conn.Emit(dbus.ObjectPath(s.testObjectPath), testSignalName); err != nil {
 		return fmt.Errorf("cant emit signal: %w", err)
@@ -136,6 +146,41 @@ func CreatePowerConfig(busName, objectPath string) *config.PowerSection {
 	}
 }
 
+// CreateBatteryConfig queries the percentage from the test service, the power profile query
+// fails so the profile stays unknown
+func CreateBatteryConfig(busName, objectPath string) *config.BatterySection {
+	return &config.BatterySection{
+		DbusSignalMatchRules: []*config.DbusSignalMatchRule{
+			{
+				Interface:  utils.StringPtr(testInterface),
+				Member:     utils.StringPtr(testMemberName),
+				ObjectPath: utils.StringPtr(objectPath),
+			},
+		},
+		DbusSignalReceiveFilters: []*config.DbusSignalReceiveFilter{
+			{Name: utils.StringPtr(testSignalName)},
+		},
+		PercentageQueryObject: &config.DbusQueryObject{
+			Destination: busName,
+			Path:        objectPath,
+			Method:      testMethodName,
+			Args: []config.DbusQueryObjectArg{
+				{Arg: testInterface},
+				{Arg: testPercentageProperty},
+			},
+		},
+		PowerProfileQueryObject: &config.DbusQueryObject{
+			Destination: busName,
+			Path:        objectPath,
+			Method:      testMethodName,
+			Args: []config.DbusQueryObjectArg{
+				{Arg: testInterface},
+				{Arg: "ActiveProfile"},
+			},
+		},
+	}
+}
+
 func CreateTestLidConfig(t *testing.T, busName, objectPath string) *config.Config {
 	return NewTestConfig(t).WithLidSection(CreateLidConfig(busName, objectPath)).Get()
 }
@@ -38,6 +38,14 @@ func (t *TestConfig) WithThemeFile(file string) *TestConfig {
 	return t
 }
 
+func (t *TestConfig) WithKeys(keys map[string][]string) *TestConfig {
+	if t.cfg.TUISection == nil {
+		t.cfg.TUISection = &config.TUISection{}
+	}
+	t.cfg.TUISection.Keys = keys
+	return t
+}
+
 func (t *TestConfig) WithProfiles(profiles map[string]*config.Profile) *TestConfig {
 	t.cfg.Profiles = profiles
 	for _, profile := range t.cfg.Profiles {
@@ -118,6 +126,11 @@ func (t *TestConfig) WithLidSection(ps *config.LidSection) *TestConfig {
 	return t
 }
 
+func (t *TestConfig) WithBatterySection(bs *config.BatterySection) *TestConfig {
+	t.cfg.BatteryEvents = bs
+	return t
+}
+
 func (t *TestConfig) WithPowerSection(ps *config.PowerSection) *TestConfig {
 	t.cfg.PowerEvents = ps
 	return t
@@ -128,6 +141,16 @@ func (t *TestConfig) WithHotReload(h *config.HotReloadSection) *TestConfig {
 	return t
 }
 
+func (t *TestConfig) WithHyprIPC(h *config.HyprIPCSection) *TestConfig {
+	t.cfg.HyprIPC = h
+	return t
+}
+
+func (t *TestConfig) WithLayoutChecks(l *config.LayoutChecksSection) *TestConfig {
+	t.cfg.LayoutChecks = l
+	return t
+}
+
 func (t *TestConfig) WithStaticTemplateValues(s map[string]string) *TestConfig {
 	t.cfg.StaticTemplateValues = s
 	return t
@@ -1,10 +1,14 @@
 package tui
 
 import (
+	"strings"
+
+	udiff "github.com/aymanbagabas/go-udiff"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/profilemaker"
 	"github.com/sirupsen/logrus"
 )
 
@@ -12,6 +16,8 @@ type confirmKeyMap struct {
 	Accept key.Binding
 	Reject key.Binding
 	Back   key.Binding
+	Up     key.Binding
+	Down   key.Binding
 }
 
 func (c *confirmKeyMap) Help() []key.Binding {
@@ -22,6 +28,16 @@ func (c *confirmKeyMap) Help() []key.Binding {
 	}
 }
 
+func (c *confirmKeyMap) DiffHelp() []key.Binding {
+	return []key.Binding{
+		c.Accept,
+		c.Reject,
+		c.Back,
+		c.Up,
+		c.Down,
+	}
+}
+
 type ConfirmationPrompt struct {
 	accepted tea.Cmd
 	rejected tea.Cmd
@@ -30,31 +46,47 @@ type ConfirmationPrompt struct {
 	help     help.Model
 	width    int
 	height   int
+	// diff holds the rendered lines of the changes to confirm, offset is the first visible one
+	diff   []string
+	offset int
 }
 
-func NewConfirmationPrompt(title string, accepted, rejected tea.Cmd) *ConfirmationPrompt {
+func newConfirmKeyMap(keys actionKeys) confirmKeyMap {
+	return confirmKeyMap{
+		Accept: keys.binding("confirm", "Y", "yes"),
+		Reject: keys.binding("reject", "n/N", "no"),
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back"),
+		),
+		Up:   keys.binding("scroll_up", "↑/k", "scroll up"),
+		Down: keys.binding("scroll_down", "↓/j", "scroll down"),
+	}
+}
+
+func NewConfirmationPrompt(title string, accepted, rejected tea.Cmd, keys *KeyBindings) *ConfirmationPrompt {
 	return &ConfirmationPrompt{
 		title:    title,
 		accepted: accepted,
 		rejected: rejected,
-		keys: confirmKeyMap{
-			Accept: key.NewBinding(
-				key.WithKeys("Y"),
-				key.WithHelp("Y", "yes"),
-			),
-			Reject: key.NewBinding(
-				key.WithKeys("n", "N"),
-				key.WithHelp("n/N", "no"),
-			),
-			Back: key.NewBinding(
-				key.WithKeys("esc"),
-				key.WithHelp("esc", "back"),
-			),
-		},
-		help: help.New(),
+		keys:     keys.confirm,
+		help:     help.New(),
 	}
 }
 
+// NewDiffConfirmationPrompt shows the rendered diff of the files a write would change under the
+// title, the diff scrolls when it does not fit
+func NewDiffConfirmationPrompt(title, diff string, accepted, rejected tea.Cmd, keys *KeyBindings) *ConfirmationPrompt {
+	prompt := NewConfirmationPrompt(title, accepted, rejected, keys)
+	prompt.diff = strings.Split(diff, "\n")
+	return prompt
+}
+
+// HasDiff tells if the prompt shows a diff and needs more room
+func (c *ConfirmationPrompt) HasDiff() bool {
+	return c.diff != nil
+}
+
 func (c *ConfirmationPrompt) Update(msg tea.Msg) tea.Cmd {
 	cmds := []tea.Cmd{}
 	// nolint:gocritic
@@ -72,6 +104,12 @@ func (c *ConfirmationPrompt) Update(msg tea.Msg) tea.Cmd {
 		case key.Matches(msg, c.keys.Back):
 			logrus.Debug("Confirmation prompt back")
 			cmds = append(cmds, c.rejected)
+
+		case c.HasDiff() && key.Matches(msg, c.keys.Up):
+			c.offset = max(c.offset-1, 0)
+
+		case c.HasDiff() && key.Matches(msg, c.keys.Down):
+			c.offset = min(c.offset+1, c.maxOffset())
 		}
 	}
 	return tea.Batch(cmds...)
@@ -85,10 +123,75 @@ func (c *ConfirmationPrompt) SetWidth(width int) {
 	c.width = width
 }
 
+// diffHeight is the number of diff lines that fit between the title and the help
+func (c *ConfirmationPrompt) diffHeight() int {
+	return max(c.height-4, 1)
+}
+
+func (c *ConfirmationPrompt) maxOffset() int {
+	return max(len(c.diff)-c.diffHeight(), 0)
+}
+
 func (c *ConfirmationPrompt) View() string {
+	if c.HasDiff() {
+		return c.diffView()
+	}
+
 	title := c.title
 	help := c.help.ShortHelpView(c.keys.Help())
 	view := lipgloss.JoinVertical(lipgloss.Top, title, help)
 
 	return lipgloss.Place(c.width, c.height, lipgloss.Center, lipgloss.Center, view)
 }
+
+func (c *ConfirmationPrompt) diffView() string {
+	offset := min(c.offset, c.maxOffset())
+	end := min(offset+c.diffHeight(), len(c.diff))
+	body := lipgloss.NewStyle().MaxWidth(c.width).Height(c.diffHeight()).Render(
+		strings.Join(c.diff[offset:end], "\n"))
+	help := c.help.ShortHelpView(c.keys.DiffHelp())
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.PlaceHorizontal(c.width, lipgloss.Center, c.title),
+		"",
+		body,
+		"",
+		lipgloss.PlaceHorizontal(c.width, lipgloss.Center, help))
+}
+
+// renderDiff renders the changes as unified diffs colored by line, files that would stay the same
+// are skipped
+func renderDiff(changes []profilemaker.FileChange, colors *ColorsManager, label func(string) string) string {
+	sections := []string{}
+	for _, change := range changes {
+		if change.Before == change.After {
+			continue
+		}
+		name := label(change.Path)
+		diff := udiff.Unified(name, name, change.Before, change.After)
+
+		lines := []string{}
+		for i, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+			style := lipgloss.NewStyle()
+			switch {
+			case i < 2:
+				style = colors.TitleStyle()
+			case strings.HasPrefix(line, "@@"):
+				style = colors.InfoStyle()
+			case strings.HasPrefix(line, "+"):
+				style = colors.DiffAddedStyle()
+			case strings.HasPrefix(line, "-"):
+				style = colors.DiffRemovedStyle()
+			case strings.HasPrefix(line, "\\"):
+				style = colors.MutedStyle()
+			}
+			lines = append(lines, style.Render(line))
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+
+	if len(sections) == 0 {
+		return colors.MutedItalicStyle().Render("No changes, the files are already up to date")
+	}
+	return strings.Join(sections, "\n\n")
+}
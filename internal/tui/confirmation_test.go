@@ -1,9 +1,12 @@
 package tui
 
 import (
+	"path/filepath"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/profilemaker"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/testutils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -45,7 +48,7 @@ func TestConfirmationPrompt_Update(t *testing.T) {
 			acceptedCalled = false
 			rejectedCalled = false
 
-			prompt := NewConfirmationPrompt("Test", acceptedCmd, rejectedCmd)
+			prompt := NewConfirmationPrompt("Test", acceptedCmd, rejectedCmd, NewKeyBindings(nil))
 			cmd := prompt.Update(tt.msg)
 
 			if cmd != nil {
@@ -57,3 +60,54 @@ func TestConfirmationPrompt_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestConfirmationPrompt_DiffScroll(t *testing.T) {
+	prompt := NewDiffConfirmationPrompt("Test", "1\n2\n3\n4\n5\n6\n7", nil, nil, NewKeyBindings(nil))
+	prompt.SetWidth(20)
+	prompt.SetHeight(7)
+
+	down := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}}
+	up := tea.KeyMsg{Type: tea.KeyUp}
+
+	assert.True(t, prompt.HasDiff())
+	assert.Contains(t, prompt.View(), "3")
+	assert.NotContains(t, prompt.View(), "4")
+
+	for range 10 {
+		prompt.Update(down)
+	}
+	assert.Equal(t, 4, prompt.offset, "should stop at the last line")
+	assert.Contains(t, prompt.View(), "7")
+	assert.NotContains(t, prompt.View(), "4")
+
+	prompt.Update(up)
+	assert.Equal(t, 3, prompt.offset)
+	assert.False(t, NewConfirmationPrompt("Test", nil, nil, NewKeyBindings(nil)).HasDiff())
+}
+
+func TestRenderDiff(t *testing.T) {
+	colors := NewColorsManager(testutils.NewTestConfig(t).Get())
+
+	diff := renderDiff([]profilemaker.FileChange{
+		{Path: "/tmp/same.conf", Before: "a\n", After: "a\n"},
+		{Path: "/tmp/file.conf", Before: "a\nb\n", After: "a\nc\n"},
+		{Path: "/tmp/new.conf", After: "new\n"},
+	}, colors, filepath.Base)
+
+	assert.Equal(t, `--- file.conf
++++ file.conf
+@@ -1,2 +1,2 @@
+ a
+-b
++c
+
+--- new.conf
++++ new.conf
+@@ -0,0 +1 @@
++new`, diff)
+
+	diff = renderDiff([]profilemaker.FileChange{
+		{Path: "/tmp/same.conf", Before: "a\n", After: "a\n"},
+	}, colors, filepath.Base)
+	assert.Equal(t, "No changes, the files are already up to date", diff)
+}
@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"strings"
+
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 )
@@ -30,3 +32,22 @@ func (m *CustomHelp) FullHelpView(groups [][]key.Binding) string {
 	model.Styles.FullSeparator = m.colors.HelpSeparatorStyle()
 	return model.FullHelpView(groups)
 }
+
+// keysHelp is the help label of remapped keys, arrows are shown as symbols like the defaults
+func keysHelp(keys []string) string {
+	symbols := map[string]string{
+		"up":     "↑",
+		"down":   "↓",
+		"left":   "←",
+		"right":  "→",
+		"pgdown": "pgdn",
+	}
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		if symbol, ok := symbols[k]; ok {
+			k = symbol
+		}
+		labels[i] = k
+	}
+	return strings.Join(labels, "/")
+}
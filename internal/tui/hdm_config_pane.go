@@ -45,8 +45,17 @@ type HDMConfigPane struct {
 	colors        *ColorsManager
 }
 
+func newHDMKeyMap(keys actionKeys) hdmKeyMap {
+	return hdmKeyMap{
+		RenderProfile: keys.binding("render_profile", "R", "render profile to config.general.destination"),
+		NewProfile:    keys.binding("new_profile", "n", "new profile"),
+		ApplyProfile:  keys.binding("update_profile", "a", "apply monitors to existing profile"),
+		EditorEdit:    keys.binding("edit_profile", "e", "edit manually"),
+	}
+}
+
 func NewHDMConfigPane(cfg *config.Config, matcher *matchers.Matcher, monitors []*MonitorSpec,
-	powerState power.PowerState, lidState power.LidState, colors *ColorsManager,
+	powerState power.PowerState, lidState power.LidState, colors *ColorsManager, keys *KeyBindings,
 ) *HDMConfigPane {
 	return &HDMConfigPane{
 		cfg:        cfg,
@@ -56,24 +65,7 @@ func NewHDMConfigPane(cfg *config.Config, matcher *matchers.Matcher, monitors []
 		lidState:   lidState,
 		help:       NewCustomHelp(colors),
 		colors:     colors,
-		keymap: &hdmKeyMap{
-			RenderProfile: key.NewBinding(
-				key.WithKeys("R"),
-				key.WithHelp("R", "render profile to config.general.destination"),
-			),
-			NewProfile: key.NewBinding(
-				key.WithKeys("n"),
-				key.WithHelp("n", "new profile"),
-			),
-			ApplyProfile: key.NewBinding(
-				key.WithKeys("a"),
-				key.WithHelp("a", "apply monitors to existing profile"),
-			),
-			EditorEdit: key.NewBinding(
-				key.WithKeys("e"),
-				key.WithHelp("e", "edit manually"),
-			),
-		},
+		keymap:     &keys.hdm,
 	}
 }
 
@@ -104,7 +96,7 @@ func (h *HDMConfigPane) Update(msg tea.Msg) tea.Cmd {
 		if err != nil {
 			cmds = append(cmds, OperationStatusCmd(OperationNameMatchingProfile, err))
 		} else {
-			ok, profile, err := h.matcher.Match(h.cfg.Get(), mons, h.powerState, h.lidState)
+			ok, profile, err := h.matcher.Match(h.cfg.Get(), mons, h.powerState, h.lidState, power.BatteryState{})
 			cmds = append(cmds, OperationStatusCmd(OperationNameMatchingProfile, err))
 			if ok {
 				h.profile = profile
@@ -127,7 +119,7 @@ func (h *HDMConfigPane) Update(msg tea.Msg) tea.Cmd {
 		case key.Matches(msg, h.keymap.EditorEdit):
 			cmds = append(cmds, openEditor(h.profile.Profile.ConfigFile))
 		case key.Matches(msg, h.keymap.RenderProfile):
-			cmds = append(cmds, RenderHDMConfigCmd(h.profile, h.lidState, h.powerState))
+			cmds = append(cmds, renderHDMConfigConfirmationCmd(h.profile, h.lidState, h.powerState))
 		}
 	}
 	return tea.Batch(cmds...)
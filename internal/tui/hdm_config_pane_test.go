@@ -96,7 +96,7 @@ func TestHDMConfigPane_Update(t *testing.T) {
 
 			colors := tui.NewColorsManager(cfg)
 
-			pane := tui.NewHDMConfigPane(cfg, matcher, monitors, tt.initialPowerState, tt.initialLidState, colors)
+			pane := tui.NewHDMConfigPane(cfg, matcher, monitors, tt.initialPowerState, tt.initialLidState, colors, tui.NewKeyBindings(cfg))
 
 			cmd := pane.Update(nil)
 			// nolint:gocritic
@@ -9,6 +9,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/generators"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/matchers"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/power"
 	"github.com/sirupsen/logrus"
@@ -17,6 +18,7 @@ import (
 type HDMProfilePreview struct {
 	cfg              *config.Config
 	matcher          *matchers.Matcher
+	generator        *generators.ConfigGenerator
 	pulled           bool
 	profile          *matchers.MatchedProfile
 	monitors         []*MonitorSpec
@@ -27,15 +29,19 @@ type HDMProfilePreview struct {
 	runningUnderTest bool
 	lidState         power.LidState
 	colors           *ColorsManager
+	// the profile is rendered instead of shown as written while a state is simulated
+	simulatedPower bool
+	simulatedLid   bool
 }
 
 func NewHDMProfilePreview(cfg *config.Config,
-	matcher *matchers.Matcher, monitors []*MonitorSpec, powerState power.PowerState,
-	runningUnderTest bool, lidState power.LidState, colors *ColorsManager,
+	matcher *matchers.Matcher, generator *generators.ConfigGenerator, monitors []*MonitorSpec,
+	powerState power.PowerState, runningUnderTest bool, lidState power.LidState, colors *ColorsManager,
 ) *HDMProfilePreview {
 	ta := textarea.New()
 	return &HDMProfilePreview{
 		cfg:              cfg,
+		generator:        generator,
 		powerState:       powerState,
 		lidState:         lidState,
 		matcher:          matcher,
@@ -70,12 +76,14 @@ func (h *HDMProfilePreview) Update(msg tea.Msg) tea.Cmd {
 		logrus.Debug("Overriding the current power state")
 		h.pulled = false
 		h.powerState = msg.state
+		h.simulatedPower = msg.simulated
 		h.profile = nil
 		h.textarea.SetValue("")
 	case LidStateChanged:
 		logrus.Debug("Overriding the current lid state")
 		h.pulled = false
 		h.lidState = msg.state
+		h.simulatedLid = msg.simulated
 		h.profile = nil
 		h.textarea.SetValue("")
 	}
@@ -87,18 +95,14 @@ func (h *HDMProfilePreview) Update(msg tea.Msg) tea.Cmd {
 		if err != nil {
 			cmds = append(cmds, OperationStatusCmd(OperationNameMatchingProfile, err))
 		} else {
-			ok, profile, err := h.matcher.Match(h.cfg.Get(), mons, h.powerState, h.lidState)
+			ok, profile, err := h.matcher.Match(h.cfg.Get(), mons, h.powerState, h.lidState, power.BatteryState{})
 			cmds = append(cmds, OperationStatusCmd(OperationNameMatchingProfile, err))
 			if ok {
 				h.profile = profile
 			}
 		}
 		if h.profile != nil {
-			contents, err := os.ReadFile(h.profile.Profile.ConfigFile)
-			text := "Can't pull config"
-			if err == nil {
-				text = string(contents)
-			}
+			text := h.pullText()
 			logrus.Debugf("Textarea text: %s", text)
 			h.textarea.SetValue(text)
 		}
@@ -108,6 +112,32 @@ func (h *HDMProfilePreview) Update(msg tea.Msg) tea.Cmd {
 	return tea.Batch(cmds...)
 }
 
+// pullText reads the profile config, it is rendered for the simulated state when there is one
+func (h *HDMProfilePreview) pullText() string {
+	if !h.Simulated() {
+		contents, err := os.ReadFile(h.profile.Profile.ConfigFile)
+		if err != nil {
+			return "Can't pull config"
+		}
+		return string(contents)
+	}
+
+	mons, err := ConvertToHyprMonitors(h.monitors)
+	if err != nil {
+		return "Can't render config: " + err.Error()
+	}
+	rendered, err := h.generator.Render(h.cfg.Get(), h.profile, mons, h.powerState, h.lidState,
+		power.BatteryState{})
+	if err != nil {
+		return "Can't render config: " + err.Error()
+	}
+	return string(rendered)
+}
+
+func (h *HDMProfilePreview) Simulated() bool {
+	return h.simulatedPower || h.simulatedLid
+}
+
 func (h *HDMProfilePreview) View() string {
 	if h.profile == nil {
 		return "No profile config"
@@ -116,8 +146,12 @@ func (h *HDMProfilePreview) View() string {
 	sections := []string{}
 	availableHeight := h.height
 
+	kind := h.profile.Profile.ConfigType.Value()
+	if h.Simulated() {
+		kind += fmt.Sprintf(", rendered for power=%s lid=%s", h.powerState, h.lidState)
+	}
 	title := h.colors.TitleStyle().Margin(0, 0, 0, 0).Render(
-		fmt.Sprintf("Profile Config Preview (%s)", h.profile.Profile.ConfigType.Value()))
+		fmt.Sprintf("Profile Config Preview (%s)", kind))
 	availableHeight -= lipgloss.Height(title)
 	sections = append(sections, title)
 
@@ -5,6 +5,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/generators"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/matchers"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/power"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/testutils"
@@ -72,7 +73,10 @@ func TestHDMProfilePreview_Update(t *testing.T) {
 			}
 			colors := tui.NewColorsManager(cfg)
 
-			preview := tui.NewHDMProfilePreview(cfg, matcher, monitors,
+			generator, err := generators.NewConfigGenerator(cfg)
+			require.NoError(t, err)
+
+			preview := tui.NewHDMProfilePreview(cfg, matcher, generator, monitors,
 				tt.initialPowerState, true, tt.initialLidState, colors)
 			oldProfile := preview.GetProfile()
 
@@ -23,6 +23,7 @@ type Header struct {
 	lastUpdate     time.Time
 	clearAfter     time.Duration
 	colors         *ColorsManager
+	history        HistoryDepth
 }
 
 func NewHeader(title string, availableViews []ViewMode, version string, colors *ColorsManager) *Header {
@@ -53,6 +54,8 @@ func (h *Header) Update(msg tea.Msg) tea.Cmd {
 		h.state = msg.State
 	case ViewChanged:
 		h.currentView = msg.view
+	case HistoryChanged:
+		h.history = msg.Depth
 	case ClearStatusMsgNow:
 		h.success = ""
 	case OperationStatus:
@@ -100,6 +103,12 @@ func (h *Header) View() string {
 		availableSpace -= lipgloss.Width(mode) + 1
 	}
 
+	var history string
+	if h.history.String() != "" && h.currentView == MonitorsListView {
+		history = h.colors.HeaderIndicatorStyle().Render(h.history.String())
+		availableSpace -= lipgloss.Width(history) + 1
+	}
+
 	var statusError string
 	if h.err != "" {
 		statusError = h.colors.ErrorStyle().Render(h.err)
@@ -125,6 +134,11 @@ func (h *Header) View() string {
 		sections = append(sections, statusError)
 	}
 
+	if history != "" {
+		sections = append(sections, " ")
+		sections = append(sections, history)
+	}
+
 	if h.mode != "" {
 		sections = append(sections, " ")
 		sections = append(sections, mode)
@@ -162,6 +176,10 @@ func (h *Header) GetCurrentView() ViewMode {
 	return h.currentView
 }
 
+func (h *Header) GetHistory() HistoryDepth {
+	return h.history
+}
+
 func (h *Header) GetError() string {
 	return h.err
 }
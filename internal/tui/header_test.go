@@ -83,3 +83,14 @@ func TestHeader_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestHeader_History(t *testing.T) {
+	cfg := testutils.NewTestConfig(t).Get()
+	header := tui.NewHeader("test", []tui.ViewMode{tui.MonitorsListView}, "1.0.0", tui.NewColorsManager(cfg))
+	header.SetWidth(120)
+	assert.NotContains(t, header.View(), "History")
+
+	header.Update(tui.HistoryChangedCmd(tui.HistoryDepth{Undo: 2, Redo: 1})())
+	assert.Equal(t, tui.HistoryDepth{Undo: 2, Redo: 1}, header.GetHistory())
+	assert.Contains(t, header.View(), "History 2/3")
+}
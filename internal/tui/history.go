@@ -0,0 +1,176 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrNothingToUndo = errors.New("nothing to undo")
+	ErrNothingToRedo = errors.New("nothing to redo")
+)
+
+// monitorState holds every MonitorSpec field the editor can change
+type monitorState struct {
+	X             int
+	Y             int
+	Width         int
+	Height        int
+	RefreshRate   float64
+	Scale         float64
+	Transform     int
+	Flipped       bool
+	Vrr           bool
+	Disabled      bool
+	Mirror        string
+	Bitdepth      Bitdepth
+	ColorPreset   ColorPreset
+	SdrBrightness float64
+	SdrSaturation float64
+}
+
+func snapshotMonitor(monitor *MonitorSpec) monitorState {
+	return monitorState{
+		X:             monitor.X,
+		Y:             monitor.Y,
+		Width:         monitor.Width,
+		Height:        monitor.Height,
+		RefreshRate:   monitor.RefreshRate,
+		Scale:         monitor.Scale,
+		Transform:     monitor.Transform,
+		Flipped:       monitor.Flipped,
+		Vrr:           monitor.Vrr,
+		Disabled:      monitor.Disabled,
+		Mirror:        monitor.Mirror,
+		Bitdepth:      monitor.Bitdepth,
+		ColorPreset:   monitor.ColorPreset,
+		SdrBrightness: monitor.SdrBrightness,
+		SdrSaturation: monitor.SdrSaturation,
+	}
+}
+
+// restore writes the state back in place, the other components hold the same pointers
+func (s monitorState) restore(monitor *MonitorSpec) {
+	if monitor.Width != s.Width || monitor.Height != s.Height {
+		monitor.ValidScalesCache = make(map[float64]struct{})
+	}
+	monitor.X = s.X
+	monitor.Y = s.Y
+	monitor.Width = s.Width
+	monitor.Height = s.Height
+	monitor.RefreshRate = s.RefreshRate
+	monitor.Scale = s.Scale
+	monitor.Transform = s.Transform
+	monitor.Flipped = s.Flipped
+	monitor.Vrr = s.Vrr
+	monitor.Disabled = s.Disabled
+	monitor.Mirror = s.Mirror
+	monitor.Bitdepth = s.Bitdepth
+	monitor.ColorPreset = s.ColorPreset
+	monitor.SdrBrightness = s.SdrBrightness
+	monitor.SdrSaturation = s.SdrSaturation
+}
+
+type monitorChange struct {
+	monitorID int
+	before    monitorState
+	after     monitorState
+}
+
+// editCommand is a single undoable editor operation, reset changes every monitor at once
+type editCommand struct {
+	operation OperationName
+	changes   []monitorChange
+}
+
+// HistoryDepth is how many operations can be undone and redone
+type HistoryDepth struct {
+	Undo int
+	Redo int
+}
+
+func (h HistoryDepth) String() string {
+	if h.Undo == 0 && h.Redo == 0 {
+		return ""
+	}
+	return fmt.Sprintf("History %d/%d", h.Undo, h.Undo+h.Redo)
+}
+
+// History is the undo/redo stack of the monitor editor, previews of the same operation on the
+// same monitor (e.g. scrolling through modes before confirming) collapse into one command
+type History struct {
+	undo      []editCommand
+	redo      []editCommand
+	mergeable bool
+	maxDepth  int
+}
+
+func NewHistory(maxDepth int) *History {
+	return &History{
+		undo:     []editCommand{},
+		redo:     []editCommand{},
+		maxDepth: maxDepth,
+	}
+}
+
+func (h *History) Depth() HistoryDepth {
+	return HistoryDepth{Undo: len(h.undo), Redo: len(h.redo)}
+}
+
+// push records a command, it is dropped when nothing changed
+func (h *History) push(command editCommand, merge bool) {
+	if merge && h.mergeable && len(h.undo) > 0 {
+		last := &h.undo[len(h.undo)-1]
+		if last.operation == command.operation && len(last.changes) == 1 && len(command.changes) == 1 &&
+			last.changes[0].monitorID == command.changes[0].monitorID {
+			last.changes[0].after = command.changes[0].after
+			if last.changes[0].before == last.changes[0].after {
+				h.undo = h.undo[:len(h.undo)-1]
+				h.mergeable = false
+			}
+			return
+		}
+	}
+
+	command.changes = slices.DeleteFunc(command.changes, func(change monitorChange) bool {
+		return change.before == change.after
+	})
+	if len(command.changes) == 0 {
+		return
+	}
+
+	h.undo = append(h.undo, command)
+	if h.maxDepth > 0 && len(h.undo) > h.maxDepth {
+		h.undo = h.undo[len(h.undo)-h.maxDepth:]
+	}
+	h.redo = []editCommand{}
+	h.mergeable = merge
+}
+
+// seal keeps the next command from merging into the last one
+func (h *History) seal() {
+	h.mergeable = false
+}
+
+func (h *History) popUndo() (editCommand, bool) {
+	if len(h.undo) == 0 {
+		return editCommand{}, false
+	}
+	command := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.redo = append(h.redo, command)
+	h.mergeable = false
+	return command, true
+}
+
+func (h *History) popRedo() (editCommand, bool) {
+	if len(h.redo) == 0 {
+		return editCommand{}, false
+	}
+	command := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.undo = append(h.undo, command)
+	h.mergeable = false
+	return command, true
+}
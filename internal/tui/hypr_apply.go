@@ -3,11 +3,14 @@ package tui
 import (
 	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/generators"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/hypr"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/matchers"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/power"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/profilemaker"
@@ -49,6 +52,15 @@ func (h *HyprApply) CreateProfile(monitors []*MonitorSpec, name, file string) te
 	return OperationStatusCmd(OperationNameCreateProfile, err)
 }
 
+// PreviewCreateProfile returns the files CreateProfile would write, nothing is written
+func (h *HyprApply) PreviewCreateProfile(monitors []*MonitorSpec, name, file string) ([]profilemaker.FileChange, error) {
+	hyprMonitors, err := ConvertToHyprMonitors(monitors)
+	if err != nil {
+		return nil, err
+	}
+	return h.profileMaker.PreviewFreezeGivenAs(name, file, hyprMonitors)
+}
+
 func (h *HyprApply) EditProfile(monitors []*MonitorSpec, name string) tea.Cmd {
 	hyprMonitors, err := ConvertToHyprMonitors(monitors)
 	if err != nil {
@@ -58,6 +70,82 @@ func (h *HyprApply) EditProfile(monitors []*MonitorSpec, name string) tea.Cmd {
 	return OperationStatusCmd(OperationNameEditProfile, err)
 }
 
+// PreviewEditProfile returns the profile config file as EditProfile would leave it
+func (h *HyprApply) PreviewEditProfile(monitors []*MonitorSpec, name string) ([]profilemaker.FileChange, error) {
+	hyprMonitors, err := ConvertToHyprMonitors(monitors)
+	if err != nil {
+		return nil, err
+	}
+	change, err := h.profileMaker.PreviewEditExisting(name, hyprMonitors)
+	if err != nil {
+		return nil, err
+	}
+	return []profilemaker.FileChange{*change}, nil
+}
+
+// DuplicateProfile copies the profile together with its config file so both can be edited apart
+func (h *HyprApply) DuplicateProfile(from, to string) tea.Cmd {
+	err := h.profileMaker.Duplicate(from, to, profilemaker.ConfigFileAction{Enabled: true})
+	return OperationStatusCmd(OperationNameDuplicateProfile, err)
+}
+
+func (h *HyprApply) RenameProfile(from, to string) tea.Cmd {
+	err := h.profileMaker.Rename(from, to, profilemaker.ConfigFileAction{})
+	return OperationStatusCmd(OperationNameRenameProfile, err)
+}
+
+func (h *HyprApply) DeleteProfile(name string) tea.Cmd {
+	err := h.profileMaker.Delete(name, false)
+	return OperationStatusCmd(OperationNameDeleteProfile, err)
+}
+
+func (h *HyprApply) EditConditions(name string, conditions *profilemaker.ConditionsUpdate) tea.Cmd {
+	err := h.profileMaker.SetConditions(name, *conditions)
+	return OperationStatusCmd(OperationNameEditConditions, err)
+}
+
+// SetTheme points [tui.colors] source at the theme file
+func (h *HyprApply) SetTheme(themeFile string) tea.Cmd {
+	err := h.profileMaker.SetThemeSource(themeFile)
+	return OperationStatusCmd(OperationNameSetTheme, err)
+}
+
+// ApplyProfile renders the profile and applies its monitor lines through hyprctl, nothing is
+// written to the destination so the daemon overrides it on the next event
+func (h *HyprApply) ApplyProfile(cfg *config.Config, profile *matchers.MatchedProfile,
+	monitors []*MonitorSpec, powerState power.PowerState, lidState power.LidState,
+) tea.Cmd {
+	hyprMonitors, err := ConvertToHyprMonitors(monitors)
+	if err != nil {
+		return OperationStatusCmd(OperationNameApplyProfile, err)
+	}
+	rendered, err := h.generator.Render(cfg.Get(), profile, hyprMonitors, powerState, lidState, power.BatteryState{})
+	if err != nil {
+		return OperationStatusCmd(OperationNameApplyProfile, err)
+	}
+
+	var lastError error
+	applied := 0
+	for _, line := range strings.Split(string(rendered), "\n") {
+		value, ok := hypr.MonitorLineValue(line)
+		if !ok {
+			continue
+		}
+		applied++
+		// the value comes from the rendered templates, pass it as an argument and not through a shell
+		// nolint:gosec,noctx
+		if err := exec.Command("hyprctl", "keyword", "monitor", value).Run(); err != nil {
+			lastError = err
+			logrus.WithError(err).Error("cant apply hypr settings")
+		}
+	}
+	if applied == 0 {
+		lastError = errors.New("the profile has no monitor lines")
+	}
+
+	return OperationStatusCmd(OperationNameApplyProfile, lastError)
+}
+
 func (h *HyprApply) GenerateThroughHDM(cfg *config.Config, profile *matchers.MatchedProfile,
 	monitors []*MonitorSpec, powerState power.PowerState, lidState power.LidState,
 ) tea.Cmd {
@@ -69,6 +157,34 @@ func (h *HyprApply) GenerateThroughHDM(cfg *config.Config, profile *matchers.Mat
 		return OperationStatusCmd(OperationNameHydrate, err)
 	}
 	destination := *cfg.Get().General.Destination
-	_, err = h.generator.GenerateConfig(cfg.Get(), profile, hyprMonitors, powerState, lidState, destination, false)
+	_, err = h.generator.GenerateConfig(cfg.Get(), profile, hyprMonitors, powerState, lidState,
+		power.BatteryState{}, destination, false)
 	return OperationStatusCmd(OperationNameHydrate, err)
 }
+
+// PreviewGenerate returns the destination as GenerateThroughHDM would leave it, nothing is written
+func (h *HyprApply) PreviewGenerate(cfg *config.Config, profile *matchers.MatchedProfile,
+	monitors []*MonitorSpec, powerState power.PowerState, lidState power.LidState,
+) ([]profilemaker.FileChange, error) {
+	if profile == nil {
+		return nil, errors.New("profile is nil")
+	}
+	hyprMonitors, err := ConvertToHyprMonitors(monitors)
+	if err != nil {
+		return nil, err
+	}
+	rendered, err := h.generator.Render(cfg.Get(), profile, hyprMonitors, powerState, lidState, power.BatteryState{})
+	if err != nil {
+		return nil, err
+	}
+
+	destination := *cfg.Get().General.Destination
+	// nolint:gosec
+	current, err := os.ReadFile(destination)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("cant read the destination: %w", err)
+	}
+	return []profilemaker.FileChange{
+		{Path: destination, Before: string(current), After: string(rendered)},
+	}, nil
+}
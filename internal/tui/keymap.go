@@ -1,6 +1,161 @@
 package tui
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+)
+
+func init() {
+	config.RegisterTUIKeysValidator(ValidateKeys)
+}
+
+// keyScope is a part of the TUI, the key actions of one scope are active at the same time
+type keyScope int
+
+const (
+	monitorsKeyScope keyScope = iota
+	profileKeyScope
+	profilesKeyScope
+	confirmationKeyScope
+	scaleKeyScope
+	colorPickerKeyScope
+)
+
+// keyAction is a binding that can be remapped by its name in [tui.keys]
+type keyAction struct {
+	keys   []string
+	scopes []keyScope
+}
+
+func keyActions() map[string]keyAction {
+	monitors := []keyScope{monitorsKeyScope}
+	profile := []keyScope{profileKeyScope}
+	profiles := []keyScope{profilesKeyScope}
+	views := []keyScope{monitorsKeyScope, profileKeyScope, profilesKeyScope}
+	previews := []keyScope{monitorsKeyScope, profilesKeyScope}
+	simulation := []keyScope{profileKeyScope, profilesKeyScope}
+	// the theme picker opens from any view, unless a picker or a prompt has taken over the keys
+	theme := views
+	confirmation := []keyScope{confirmationKeyScope}
+	scale := []keyScope{scaleKeyScope}
+	colorPicker := []keyScope{colorPickerKeyScope}
+	// the pickers take over the keys of the view, only these stay active
+	global := append(slices.Clone(views), scaleKeyScope, colorPickerKeyScope)
+
+	return map[string]keyAction{
+		"switch_view":           {[]string{"tab"}, global},
+		"quit":                  {[]string{"q", "ctrl+c"}, append(slices.Clone(global), confirmationKeyScope)},
+		"up":                    {[]string{"up", "k"}, views},
+		"down":                  {[]string{"down", "j"}, views},
+		"left":                  {[]string{"left", "h"}, views},
+		"right":                 {[]string{"right", "l"}, views},
+		"pan":                   {[]string{"p"}, monitors},
+		"fullscreen":            {[]string{"F"}, monitors},
+		"follow_monitor":        {[]string{"o"}, monitors},
+		"center":                {[]string{"c"}, monitors},
+		"toggle_snapping":       {[]string{"S"}, monitors},
+		"apply":                 {[]string{"A"}, monitors},
+		"expand_hypr_preview":   {[]string{"H"}, monitors},
+		"undo":                  {[]string{"u"}, monitors},
+		"redo":                  {[]string{"ctrl+r"}, monitors},
+		"reset_edits":           {[]string{"X"}, monitors},
+		"rotate":                {[]string{"r"}, monitors},
+		"scale":                 {[]string{"s"}, monitors},
+		"change_mode":           {[]string{"m"}, monitors},
+		"toggle_vrr":            {[]string{"v"}, monitors},
+		"toggle_monitor":        {[]string{"e"}, monitors},
+		"mirror":                {[]string{"i"}, monitors},
+		"color":                 {[]string{"C"}, monitors},
+		"flip":                  {[]string{"L"}, monitors},
+		"align":                 {[]string{"a"}, monitors},
+		"exact":                 {[]string{"x"}, monitors},
+		"zoom_in":               {[]string{"+"}, previews},
+		"zoom_out":              {[]string{"-"}, previews},
+		"reset_zoom":            {[]string{"R"}, previews},
+		"fit_monitors":          {[]string{"T"}, previews},
+		"edit_config":           {[]string{"C"}, profile},
+		"edit_generated_config": {[]string{"E"}, profile},
+		"new_profile":           {[]string{"n"}, profile},
+		"update_profile":        {[]string{"a"}, profile},
+		"edit_profile":          {[]string{"e"}, profile},
+		"render_profile":        {[]string{"R"}, profile},
+		"simulate_power":        {[]string{"P"}, simulation},
+		"simulate_lid":          {[]string{"L"}, simulation},
+		"pick_theme":            {[]string{"ctrl+t"}, theme},
+		"open_profile":          {[]string{"enter", "e"}, profiles},
+		"duplicate":             {[]string{"d"}, profiles},
+		"rename":                {[]string{"r"}, profiles},
+		"delete":                {[]string{"D"}, profiles},
+		"apply_profile":         {[]string{"A"}, profiles},
+		"conditions":            {[]string{"c"}, profiles},
+		"confirm":               {[]string{"Y"}, confirmation},
+		"reject":                {[]string{"n", "N"}, confirmation},
+		"scroll_up":             {[]string{"up", "k"}, confirmation},
+		"scroll_down":           {[]string{"down", "j"}, confirmation},
+		"scale_up":              {[]string{"up", "k"}, scale},
+		"scale_down":            {[]string{"down", "j"}, scale},
+		"scale_snapping":        {[]string{"e"}, scale},
+		"scale_one":             {[]string{"1"}, scale},
+		"scale_two":             {[]string{"2"}, scale},
+		"scale_custom":          {[]string{"C"}, scale},
+		"next_bitdepth":         {[]string{"b"}, colorPicker},
+		"previous_preset":       {[]string{"up", "k"}, colorPicker},
+		"next_preset":           {[]string{"down", "j"}, colorPicker},
+		"sdr_brightness_up":     {[]string{"r"}, colorPicker},
+		"sdr_brightness_down":   {[]string{"R"}, colorPicker},
+		"sdr_saturation_up":     {[]string{"t"}, colorPicker},
+		"sdr_saturation_down":   {[]string{"T"}, colorPicker},
+	}
+}
+
+// ValidateKeys fails on unknown actions and on two actions sharing a key in the same scope
+func ValidateKeys(remapped map[string][]string) error {
+	actions := keyActions()
+	for _, name := range slices.Sorted(maps.Keys(remapped)) {
+		action, ok := actions[name]
+		if !ok {
+			return fmt.Errorf("unknown tui key action: %s", name)
+		}
+		keys := remapped[name]
+		if len(keys) == 0 {
+			return fmt.Errorf("tui key binding %s needs at least one key", name)
+		}
+		if slices.Contains(keys, "") {
+			return fmt.Errorf("tui key binding %s has an empty key", name)
+		}
+		action.keys = keys
+		actions[name] = action
+	}
+
+	names := slices.Sorted(maps.Keys(actions))
+	for i, name := range names {
+		for _, other := range names[i+1:] {
+			if err := keyConflict(name, actions[name], other, actions[other]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func keyConflict(name string, action keyAction, otherName string, other keyAction) error {
+	if !slices.ContainsFunc(action.scopes, func(scope keyScope) bool {
+		return slices.Contains(other.scopes, scope)
+	}) {
+		return nil
+	}
+	for _, k := range action.keys {
+		if slices.Contains(other.keys, k) {
+			return fmt.Errorf("tui key actions %s and %s are both bound to %s", name, otherName, k)
+		}
+	}
+	return nil
+}
 
 type keyMap struct {
 	Tab                     key.Binding
@@ -27,103 +182,117 @@ type keyMap struct {
 	ResetZoom               key.Binding
 	EditHyprGeneratedConfig key.Binding
 	FitMonitors             key.Binding
+	Undo                    key.Binding
+	Redo                    key.Binding
+	ResetEdits              key.Binding
+	SimulatePower           key.Binding
+	SimulateLid             key.Binding
+	PickTheme               key.Binding
+}
+
+// KeyBindings holds the bindings of every component after the [tui.keys] remapping, one per model
+type KeyBindings struct {
+	root           keyMap
+	monitor        MonitorListKeyMap
+	hdm            hdmKeyMap
+	profileBrowser profileBrowserKeyMap
+	confirm        confirmKeyMap
+	scale          scaleSelectorKeyMap
+	colorPicker    colorPickerKepMap
+}
+
+func NewKeyBindings(cfg *config.Config) *KeyBindings {
+	var keys actionKeys
+	if cfg != nil {
+		keys = cfg.Get().TUISection.Keys
+	}
+	return &KeyBindings{
+		root:           newRootKeyMap(keys),
+		monitor:        newMonitorListKeyMap(keys),
+		hdm:            newHDMKeyMap(keys),
+		profileBrowser: newProfileBrowserKeyMap(keys),
+		confirm:        newConfirmKeyMap(keys),
+		scale:          newScaleSelectorKeyMap(keys),
+		colorPicker:    newColorPickerKeyMap(keys),
+	}
+}
+
+// listKeyMap moves the cursor of the lists with the up and down actions
+func (k *KeyBindings) listKeyMap() list.KeyMap {
+	keys := list.DefaultKeyMap()
+	keys.CursorUp = k.root.Up
+	keys.CursorDown = k.root.Down
+	return keys
+}
+
+// actionKeys are the remapped keys by the action name, checked by ValidateKeys when the config
+// is validated
+type actionKeys map[string][]string
+
+// binding binds the action to its keys, the help shows helpKey unless the action is remapped,
+// an action missing from keyActions is a programming error
+func (a actionKeys) binding(action, helpKey, desc string) key.Binding {
+	defaults, ok := keyActions()[action]
+	if !ok {
+		panic("unknown tui key action: " + action)
+	}
+	keys := slices.Clone(defaults.keys)
+	if remapped, ok := a[action]; ok {
+		keys = remapped
+		helpKey = keysHelp(remapped)
+	}
+	return key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(helpKey, desc),
+	)
 }
 
-var rootKeyMap = keyMap{
-	ToggleSnapping: key.NewBinding(
-		key.WithKeys("S"),
-		key.WithHelp("S", "toggle snapping"),
-	),
-	ApplyHypr: key.NewBinding(
-		key.WithKeys("A"),
-		key.WithHelp("A", "apply (ephemeral)"),
-	),
-	Tab: key.NewBinding(
-		key.WithKeys("tab"),
-		key.WithHelp("tab", "switch view"),
-	),
-	Enter: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "select"),
-	),
-	Quit: key.NewBinding(
-		key.WithKeys("q", "ctrl+c"),
-		key.WithHelp("q", "quit"),
-	),
-	Up: key.NewBinding(
-		key.WithKeys("up", "k"),
-		key.WithHelp("↑/k", "up"),
-	),
-	Down: key.NewBinding(
-		key.WithKeys("down", "j"),
-		key.WithHelp("↓/j", "down"),
-	),
-	Left: key.NewBinding(
-		key.WithKeys("left", "h"),
-		key.WithHelp("←/h", "left"),
-	),
-	Right: key.NewBinding(
-		key.WithKeys("right", "l"),
-		key.WithHelp("→/l", "right"),
-	),
-	ShowFullHelp: key.NewBinding(
-		key.WithKeys("?"),
-		key.WithHelp("?", "more"),
-	),
-	CloseFullHelp: key.NewBinding(
-		key.WithKeys("?"),
-		key.WithHelp("?", "close help"),
-	),
-	Pan: key.NewBinding(
-		key.WithKeys("p"),
-		key.WithHelp("p", "pan (move freely on the grid)"),
-	),
-	Fullscreen: key.NewBinding(
-		key.WithKeys("F"),
-		key.WithHelp("F", "fullscreen the preview"),
-	),
-	Center: key.NewBinding(
-		key.WithKeys("c"),
-		key.WithHelp("c", "moves the grid to 0, 0"),
-	),
-	ZoomIn: key.NewBinding(
-		key.WithKeys("+"),
-		key.WithHelp("+", "zoom in"),
-	),
-	ZoomOut: key.NewBinding(
-		key.WithKeys("-"),
-		key.WithHelp("-", "zoom out"),
-	),
-	NextPage: key.NewBinding(
-		key.WithKeys("right", "l", "pgdown", "f", "d"),
-		key.WithHelp("→/l/pgdn", "next page"),
-	),
-	Back: key.NewBinding(
-		key.WithKeys("esc"),
-		key.WithHelp("esc", "close"),
-	),
-	EditHDMConfig: key.NewBinding(
-		key.WithKeys("C"),
-		key.WithHelp("C", "edit HyprDynamicMonitors config"),
-	),
-	FollowMonitor: key.NewBinding(
-		key.WithKeys("o"),
-		key.WithHelp("o", "follow monitor"),
-	),
-	ExpandHyprPreview: key.NewBinding(
-		key.WithKeys("H"),
-		key.WithHelp("H", "expand/collapse hypr preview"),
-	),
-	ResetZoom: key.NewBinding(
-		key.WithKeys("R"),
-		key.WithHelp("R", "reset zoom"),
-	),
-	EditHyprGeneratedConfig: key.NewBinding(
-		key.WithKeys("E"),
-		key.WithHelp("E", "edit hypr generated config (ephemeral)"),
-	),
-	FitMonitors: key.NewBinding(
-		key.WithKeys("T"),
-		key.WithHelp("T", "auto fit monitors preview"),
-	),
+func newRootKeyMap(keys actionKeys) keyMap {
+	return keyMap{
+		ToggleSnapping: keys.binding("toggle_snapping", "S", "toggle snapping"),
+		ApplyHypr:      keys.binding("apply", "A", "apply (ephemeral)"),
+		Tab:            keys.binding("switch_view", "tab", "switch view"),
+		Enter: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "select"),
+		),
+		Quit:  keys.binding("quit", "q", "quit"),
+		Up:    keys.binding("up", "↑/k", "up"),
+		Down:  keys.binding("down", "↓/j", "down"),
+		Left:  keys.binding("left", "←/h", "left"),
+		Right: keys.binding("right", "→/l", "right"),
+		ShowFullHelp: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "more"),
+		),
+		CloseFullHelp: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "close help"),
+		),
+		Pan:        keys.binding("pan", "p", "pan (move freely on the grid)"),
+		Fullscreen: keys.binding("fullscreen", "F", "fullscreen the preview"),
+		Center:     keys.binding("center", "c", "moves the grid to 0, 0"),
+		ZoomIn:     keys.binding("zoom_in", "+", "zoom in"),
+		ZoomOut:    keys.binding("zoom_out", "-", "zoom out"),
+		NextPage: key.NewBinding(
+			key.WithKeys("right", "l", "pgdown", "f", "d"),
+			key.WithHelp("→/l/pgdn", "next page"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "close"),
+		),
+		EditHDMConfig:           keys.binding("edit_config", "C", "edit HyprDynamicMonitors config"),
+		FollowMonitor:           keys.binding("follow_monitor", "o", "follow monitor"),
+		ExpandHyprPreview:       keys.binding("expand_hypr_preview", "H", "expand/collapse hypr preview"),
+		ResetZoom:               keys.binding("reset_zoom", "R", "reset zoom"),
+		EditHyprGeneratedConfig: keys.binding("edit_generated_config", "E", "edit hypr generated config (ephemeral)"),
+		FitMonitors:             keys.binding("fit_monitors", "T", "auto fit monitors preview"),
+		Undo:                    keys.binding("undo", "u", "undo"),
+		Redo:                    keys.binding("redo", "ctrl+r", "redo"),
+		ResetEdits:              keys.binding("reset_edits", "X", "reset all edits"),
+		SimulatePower:           keys.binding("simulate_power", "P", "simulate power state"),
+		SimulateLid:             keys.binding("simulate_lid", "L", "simulate lid state"),
+		PickTheme:               keys.binding("pick_theme", "ctrl+t", "theme"),
+	}
 }
@@ -0,0 +1,192 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/testutils"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKeyBindings_Defaults(t *testing.T) {
+	keys := NewKeyBindings(nil)
+	bindings := keys.monitor.ShortHelp(AppState{EditingMonitor: true})
+	for _, keyMap := range []any{keys.root, keys.hdm, keys.profileBrowser, keys.confirm, keys.scale, keys.colorPicker} {
+		fields := reflect.ValueOf(keyMap)
+		for i := range fields.NumField() {
+			bindings = append(bindings, fields.Field(i).Interface().(key.Binding))
+		}
+	}
+
+	for _, binding := range bindings {
+		assert.NotEmpty(t, binding.Keys(), "%s has no keys, is the action known to the config?", binding.Help().Desc)
+		assert.NotEmpty(t, binding.Help().Key, "%s has no help", binding.Help().Desc)
+	}
+}
+
+func TestNewKeyBindings_Remapped(t *testing.T) {
+	cfg := testutils.NewTestConfig(t).WithKeys(map[string][]string{
+		"rotate":            {"t"},
+		"up":                {"up", "w"},
+		"duplicate":         {"y"},
+		"confirm":           {"enter"},
+		"sdr_brightness_up": {"x"},
+	}).Get()
+	keys := NewKeyBindings(cfg)
+
+	tests := []struct {
+		name         string
+		binding      key.Binding
+		expectedKeys []string
+		expectedHelp string
+	}{
+		{"monitor edit", keys.monitor.rotate, []string{"t"}, "t"},
+		{"root", keys.root.Up, []string{"up", "w"}, "↑/w"},
+		{"profile browser", keys.profileBrowser.Duplicate, []string{"y"}, "y"},
+		{"confirmation", keys.confirm.Accept, []string{"enter"}, "enter"},
+		{"color picker help pair", keys.colorPicker.AdjustSdrBrightness, []string{"x", "R"}, "x/R"},
+		{"not remapped", keys.scale.Custom, []string{"C"}, "C"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedKeys, tt.binding.Keys())
+			assert.Equal(t, tt.expectedHelp, tt.binding.Help().Key)
+		})
+	}
+
+	defaults := NewKeyBindings(nil)
+	assert.Equal(t, []string{"r"}, defaults.monitor.rotate.Keys(), "bindings are not shared between models")
+}
+
+func TestNewKeyBindings_Help(t *testing.T) {
+	cfg := testutils.NewTestConfig(t).WithKeys(map[string][]string{
+		"rotate": {"t"},
+		"up":     {"up", "w"},
+	}).Get()
+
+	monitors := []*MonitorSpec{{Name: "eDP-1", ID: utils.IntPtr(0)}}
+	monitorList := NewMonitorList(monitors, NewColorsManager(cfg), NewKeyBindings(cfg))
+	monitorList.SetWidth(200)
+	monitorList.Update(StateChanged{State: AppState{EditingMonitor: true}})
+
+	help := monitorList.ShortHelp()
+	assert.Contains(t, help, "t rotate")
+	assert.Contains(t, help, "↑/w up")
+	assert.NotContains(t, help, "r rotate")
+	require.NotContains(t, NewMonitorList(monitors, NewColorsManager(cfg), NewKeyBindings(nil)).ShortHelp(),
+		"↑/w up")
+}
+
+func TestActionKeys_UnknownAction(t *testing.T) {
+	assert.PanicsWithValue(t, "unknown tui key action: teleport", func() {
+		actionKeys{}.binding("teleport", "t", "teleport")
+	})
+}
+
+func TestValidateKeys(t *testing.T) {
+	tests := []struct {
+		name        string
+		keys        map[string][]string
+		expectError string
+	}{
+		{
+			name: "no keys",
+		},
+		{
+			name: "remapped keys",
+			keys: map[string][]string{"undo": {"U"}, "up": {"up", "w"}},
+		},
+		{
+			name: "swapped keys",
+			keys: map[string][]string{"rotate": {"u"}, "undo": {"r"}},
+		},
+		{
+			name: "same key in different views",
+			keys: map[string][]string{"edit_config": {"u"}},
+		},
+		{
+			name: "pickers take over the view keys",
+			keys: map[string][]string{"scale_custom": {"p"}, "next_bitdepth": {"d"}, "scale_up": {"ctrl+t"}},
+		},
+		{
+			name:        "action without keys",
+			keys:        map[string][]string{"undo": {}},
+			expectError: "tui key binding undo needs at least one key",
+		},
+		{
+			name:        "empty key",
+			keys:        map[string][]string{"undo": {""}},
+			expectError: "tui key binding undo has an empty key",
+		},
+		{
+			name:        "unknown action",
+			keys:        map[string][]string{"teleport": {"t"}},
+			expectError: "unknown tui key action: teleport",
+		},
+		{
+			name:        "conflict in the same view",
+			keys:        map[string][]string{"undo": {"r"}},
+			expectError: "tui key actions rotate and undo are both bound to r",
+		},
+		{
+			name:        "conflict with a global action",
+			keys:        map[string][]string{"simulate_lid": {"q"}},
+			expectError: "tui key actions quit and simulate_lid are both bound to q",
+		},
+		{
+			name:        "conflict with a profiles browser action",
+			keys:        map[string][]string{"zoom_in": {"d"}},
+			expectError: "tui key actions duplicate and zoom_in are both bound to d",
+		},
+		{
+			name:        "conflict with a profile view action",
+			keys:        map[string][]string{"edit_config": {"n"}},
+			expectError: "tui key actions edit_config and new_profile are both bound to n",
+		},
+		{
+			name:        "theme picker opens from the monitors view",
+			keys:        map[string][]string{"pan": {"ctrl+t"}},
+			expectError: "tui key actions pan and pick_theme are both bound to ctrl+t",
+		},
+		{
+			name:        "conflict in the confirmation prompt",
+			keys:        map[string][]string{"confirm": {"q"}},
+			expectError: "tui key actions confirm and quit are both bound to q",
+		},
+		{
+			name:        "conflict in the scale selector",
+			keys:        map[string][]string{"scale_one": {"2"}},
+			expectError: "tui key actions scale_one and scale_two are both bound to 2",
+		},
+		{
+			name:        "conflict in the color picker",
+			keys:        map[string][]string{"next_bitdepth": {"tab"}},
+			expectError: "tui key actions next_bitdepth and switch_view are both bound to tab",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateKeys(tt.keys)
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.expectError, err.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateKeys_RunByTheConfig(t *testing.T) {
+	section := &config.TUISection{Keys: map[string][]string{"zoom_in": {"d"}}}
+	err := section.Validate(t.TempDir())
+	require.Error(t, err)
+	assert.Equal(t, "tui keys validation failed: tui key actions duplicate and zoom_in are both bound to d", err.Error())
+}
@@ -90,3 +90,12 @@ func (l *Layout) PromptWidth() int {
 func (l *Layout) PromptHeight() int {
 	return l.AvailableHeight() / 3
 }
+
+// DiffPromptWidth is wider than the plain prompt so the diff lines fit
+func (l *Layout) DiffPromptWidth() int {
+	return 3 * l.AvailableWidth() / 4
+}
+
+func (l *Layout) DiffPromptHeight() int {
+	return 3 * l.AvailableHeight() / 4
+}
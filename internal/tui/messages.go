@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
 	"slices"
@@ -9,6 +10,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/matchers"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/power"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/profilemaker"
 )
 
 type MonitorBeingEdited struct {
@@ -18,6 +20,8 @@ type MonitorBeingEdited struct {
 	ModesEditor    bool
 	MirroringMode  bool
 	ColorSelection bool
+	AlignSelection bool
+	ExactInput     bool
 }
 
 type MonitorUnselected struct{}
@@ -46,28 +50,136 @@ func ViewChangedCmd(view ViewMode) tea.Cmd {
 	}
 }
 
+// LidStateChanged carries the detected lid state, or the simulated one when simulated is set
 type LidStateChanged struct {
-	state power.LidState
+	state     power.LidState
+	simulated bool
 }
 
 func LidStateChangedCmd(state power.LidState) tea.Msg {
 	return LidStateChanged{
-		state,
+		state: state,
 	}
 }
 
+func simulatedLidStateCmd(state power.LidState, simulated bool) tea.Cmd {
+	return func() tea.Msg {
+		return LidStateChanged{
+			state:     state,
+			simulated: simulated,
+		}
+	}
+}
+
+// PowerStateChanged carries the detected power state, or the simulated one when simulated is set
 type PowerStateChanged struct {
-	state power.PowerState
+	state     power.PowerState
+	simulated bool
 }
 
 func PowerStateChangedCmd(state power.PowerState) tea.Msg {
 	return PowerStateChanged{
-		state,
+		state: state,
+	}
+}
+
+func simulatedPowerStateCmd(state power.PowerState, simulated bool) tea.Cmd {
+	return func() tea.Msg {
+		return PowerStateChanged{
+			state:     state,
+			simulated: simulated,
+		}
 	}
 }
 
 type ConfigReloaded struct{}
 
+type HistoryChanged struct {
+	Depth HistoryDepth
+}
+
+func HistoryChangedCmd(depth HistoryDepth) tea.Cmd {
+	return func() tea.Msg {
+		return HistoryChanged{
+			Depth: depth,
+		}
+	}
+}
+
+type ResetEditsCommand struct{}
+
+func resetEditsCmd() tea.Cmd {
+	return func() tea.Msg {
+		return ResetEditsCommand{}
+	}
+}
+
+type ProfileAction int
+
+const (
+	ProfileActionDuplicate ProfileAction = iota
+	ProfileActionRename
+	ProfileActionDelete
+	ProfileActionApply
+	ProfileActionEditConditions
+)
+
+func (p ProfileAction) Operation() OperationName {
+	switch p {
+	case ProfileActionDuplicate:
+		return OperationNameDuplicateProfile
+	case ProfileActionRename:
+		return OperationNameRenameProfile
+	case ProfileActionDelete:
+		return OperationNameDeleteProfile
+	case ProfileActionApply:
+		return OperationNameApplyProfile
+	case ProfileActionEditConditions:
+		return OperationNameEditConditions
+	}
+	return OperationNameNone
+}
+
+// ProfileActionCommand is an action picked in the profile browser, profile and the states are
+// only set for apply and conditions only when editing them
+type ProfileActionCommand struct {
+	action     ProfileAction
+	name       string
+	newName    string
+	profile    *matchers.MatchedProfile
+	powerState power.PowerState
+	lidState   power.LidState
+	conditions *profilemaker.ConditionsUpdate
+}
+
+// Prompt is the question asked before the destructive actions
+func (p ProfileActionCommand) Prompt() string {
+	switch p.action {
+	case ProfileActionDelete:
+		return fmt.Sprintf("Delete %s profile? Its config file is kept.", p.name)
+	case ProfileActionApply:
+		return fmt.Sprintf("Apply %s profile now (ephemeral)?", p.name)
+	default:
+		return ""
+	}
+}
+
+func profileActionCmd(command ProfileActionCommand) tea.Cmd {
+	return func() tea.Msg {
+		return command
+	}
+}
+
+type ProfileActionConfirmationCommand struct {
+	command ProfileActionCommand
+}
+
+func profileActionConfirmationCmd(command ProfileActionCommand) tea.Cmd {
+	return func() tea.Msg {
+		return ProfileActionConfirmationCommand{command}
+	}
+}
+
 type ProfileNameToggled struct{}
 
 func profileNameToogled() tea.Cmd {
@@ -119,6 +231,20 @@ const (
 	OperationNameFlipMonitor
 	OperationNameFindClosestScale
 	OperationNameTypeScale
+	OperationNameUndo
+	OperationNameRedo
+	OperationNameResetEdits
+	OperationNameDuplicateProfile
+	OperationNameRenameProfile
+	OperationNameDeleteProfile
+	OperationNameApplyProfile
+	OperationNameEditConditions
+	OperationNameAlign
+	OperationNameArrange
+	OperationNameNormalize
+	OperationNameSetPosition
+	OperationNameCustomMode
+	OperationNameSetTheme
 )
 
 type OperationStatus struct {
@@ -180,6 +306,34 @@ func (o OperationStatus) String() string {
 		operationName = "Find Closest Valid Scale"
 	case OperationNameTypeScale:
 		operationName = "Set Custom Scale"
+	case OperationNameUndo:
+		operationName = "Undo"
+	case OperationNameRedo:
+		operationName = "Redo"
+	case OperationNameResetEdits:
+		operationName = "Reset Edits"
+	case OperationNameDuplicateProfile:
+		operationName = "Duplicate Profile"
+	case OperationNameRenameProfile:
+		operationName = "Rename Profile"
+	case OperationNameDeleteProfile:
+		operationName = "Delete Profile"
+	case OperationNameApplyProfile:
+		operationName = "Apply Profile"
+	case OperationNameEditConditions:
+		operationName = "Edit Conditions"
+	case OperationNameAlign:
+		operationName = "Align Monitor"
+	case OperationNameArrange:
+		operationName = "Arrange Monitors"
+	case OperationNameNormalize:
+		operationName = "Normalize Layout"
+	case OperationNameSetPosition:
+		operationName = "Set Position"
+	case OperationNameCustomMode:
+		operationName = "Set Custom Mode"
+	case OperationNameSetTheme:
+		operationName = "Set Theme"
 	default:
 		operationName = "Operation"
 	}
@@ -213,6 +367,20 @@ func OperationStatusCmd(name OperationName, err error) tea.Cmd {
 		OperationNameReloadHyprDestination,
 		OperationNameFlipMonitor,
 		OperationNameTypeScale,
+		OperationNameUndo,
+		OperationNameRedo,
+		OperationNameResetEdits,
+		OperationNameDuplicateProfile,
+		OperationNameRenameProfile,
+		OperationNameDeleteProfile,
+		OperationNameApplyProfile,
+		OperationNameEditConditions,
+		OperationNameAlign,
+		OperationNameArrange,
+		OperationNameNormalize,
+		OperationNameSetPosition,
+		OperationNameCustomMode,
+		OperationNameSetTheme,
 	}
 	showSuccessToUser := slices.Contains(criticalOperations, name)
 	return func() tea.Msg {
@@ -233,6 +401,52 @@ const (
 	DeltaLess
 )
 
+// AlignKind is what the align list does with the edited monitor, or with the whole layout
+type AlignKind int
+
+const (
+	AlignTop AlignKind = iota
+	AlignBottom
+	AlignMiddle
+	CenterAbove
+	CenterBelow
+	CenterLeftOf
+	CenterRightOf
+	ArrangeLeftToRight
+	ArrangeTopToBottom
+	NormalizeLayout
+)
+
+// AlignAction moves the MonitorID monitor relative to the TargetID one, arranging and
+// normalizing ignore both
+type AlignAction struct {
+	Kind      AlignKind
+	MonitorID int
+	TargetID  int
+}
+
+// ExactField is the value typed in the exact input prompt
+type ExactField int
+
+const (
+	ExactPosition ExactField = iota
+	ExactScale
+	ExactMode
+)
+
+func (e ExactField) String() string {
+	switch e {
+	case ExactPosition:
+		return "Position"
+	case ExactScale:
+		return "Scale"
+	case ExactMode:
+		return "Mode"
+	default:
+		return "Unknown"
+	}
+}
+
 type PreviewScaleMonitorCommand struct {
 	monitorID int
 	scale     float64
@@ -263,6 +477,39 @@ type MoveMonitorCommand struct {
 	StepY     Delta
 }
 
+// SelectMonitorCommand selects a monitor for editing as if enter was hit on it in the list
+type SelectMonitorCommand struct {
+	ListIndex int
+}
+
+// DragMonitorCommand places a monitor at X, Y (before snapping), NewDrag is set on the first
+// move of a drag so that every drag is a single undo step
+type DragMonitorCommand struct {
+	MonitorID int
+	X         int
+	Y         int
+	NewDrag   bool
+}
+
+// SetMonitorPositionCommand places a monitor at exactly X, Y, no snapping applies
+type SetMonitorPositionCommand struct {
+	MonitorID int
+	X         int
+	Y         int
+}
+
+// SetMonitorScaleCommand sets a typed scale, already validated against the monitor mode
+type SetMonitorScaleCommand struct {
+	MonitorID int
+	Scale     float64
+}
+
+// SetMonitorModeCommand sets a typed mode, it does not have to be one of the available modes
+type SetMonitorModeCommand struct {
+	MonitorID int
+	Mode      string
+}
+
 type ToggleMonitorVRRCommand struct {
 	MonitorID int
 }
@@ -303,6 +550,11 @@ type NextBitdepthCommand struct {
 	MonitorID int
 }
 
+type CreateNewProfileConfirmationCommand struct {
+	name string
+	file string
+}
+
 type CreateNewProfileCommand struct {
 	name string
 	file string
@@ -331,12 +583,46 @@ type CloseColorPickerCommand struct{}
 
 type CloseMonitorModeListCommand struct{}
 
+type CloseMonitorAlignListCommand struct{}
+
+type CloseExactInputCommand struct{}
+
+type CloseThemePickerCommand struct{}
+
+// ApplyThemeCommand writes the theme picked in the theme picker to the config
+type ApplyThemeCommand struct {
+	Path string
+}
+
+// AlignMonitorsCommand runs an alignment picked in the align list
+type AlignMonitorsCommand struct {
+	Action AlignAction
+}
+
+type RenderHDMConfigConfirmationCommand struct {
+	profile    *matchers.MatchedProfile
+	lidState   power.LidState
+	powerState power.PowerState
+}
+
 type RenderHDMConfigCommand struct {
 	profile    *matchers.MatchedProfile
 	lidState   power.LidState
 	powerState power.PowerState
 }
 
+func renderHDMConfigConfirmationCmd(profile *matchers.MatchedProfile, lidState power.LidState,
+	powerState power.PowerState,
+) tea.Cmd {
+	return func() tea.Msg {
+		return RenderHDMConfigConfirmationCommand{
+			profile,
+			lidState,
+			powerState,
+		}
+	}
+}
+
 func RenderHDMConfigCmd(profile *matchers.MatchedProfile, lidState power.LidState, powerState power.PowerState) tea.Cmd {
 	return func() tea.Msg {
 		return RenderHDMConfigCommand{
@@ -421,6 +707,66 @@ func CloseMonitorMirrorListCmd() tea.Cmd {
 	}
 }
 
+func CloseMonitorAlignListCmd() tea.Cmd {
+	return func() tea.Msg {
+		return CloseMonitorAlignListCommand{}
+	}
+}
+
+func CloseExactInputCmd() tea.Cmd {
+	return func() tea.Msg {
+		return CloseExactInputCommand{}
+	}
+}
+
+func CloseThemePickerCmd() tea.Cmd {
+	return func() tea.Msg {
+		return CloseThemePickerCommand{}
+	}
+}
+
+func applyThemeCmd(path string) tea.Cmd {
+	return func() tea.Msg {
+		return ApplyThemeCommand{Path: path}
+	}
+}
+
+func setMonitorPositionCmd(monitorID, x, y int) tea.Cmd {
+	return func() tea.Msg {
+		return SetMonitorPositionCommand{
+			MonitorID: monitorID,
+			X:         x,
+			Y:         y,
+		}
+	}
+}
+
+func setMonitorScaleCmd(monitorID int, scale float64) tea.Cmd {
+	return func() tea.Msg {
+		return SetMonitorScaleCommand{
+			MonitorID: monitorID,
+			Scale:     scale,
+		}
+	}
+}
+
+func setMonitorModeCmd(monitorID int, mode string) tea.Cmd {
+	return func() tea.Msg {
+		return SetMonitorModeCommand{
+			MonitorID: monitorID,
+			Mode:      mode,
+		}
+	}
+}
+
+func AlignMonitorsCmd(action AlignAction) tea.Cmd {
+	return func() tea.Msg {
+		return AlignMonitorsCommand{
+			Action: action,
+		}
+	}
+}
+
 func nextBitdepthCmd(monitorID int) tea.Cmd {
 	return func() tea.Msg {
 		return NextBitdepthCommand{
@@ -457,6 +803,15 @@ func editProfileCmd(profile string) tea.Cmd {
 	}
 }
 
+func createNewProfileConfirmationCmd(profile, file string) tea.Cmd {
+	return func() tea.Msg {
+		return CreateNewProfileConfirmationCommand{
+			name: profile,
+			file: file,
+		}
+	}
+}
+
 func createNewProfileCmd(profile, file string) tea.Cmd {
 	return func() tea.Msg {
 		return CreateNewProfileCommand{
@@ -558,3 +913,22 @@ func MoveMonitorCmd(monitorID int, stepX, stepY Delta) tea.Cmd {
 		}
 	}
 }
+
+func selectMonitorCmd(listIndex int) tea.Cmd {
+	return func() tea.Msg {
+		return SelectMonitorCommand{
+			ListIndex: listIndex,
+		}
+	}
+}
+
+func dragMonitorCmd(monitorID, x, y int, newDrag bool) tea.Cmd {
+	return func() tea.Msg {
+		return DragMonitorCommand{
+			MonitorID: monitorID,
+			X:         x,
+			Y:         y,
+			NewDrag:   newDrag,
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sirupsen/logrus"
+)
+
+type AlignItem struct {
+	label  string
+	action AlignAction
+}
+
+type AlignList struct {
+	L        list.Model
+	monitors []*MonitorSpec
+	help     *CustomHelp
+	colors   *ColorsManager
+	keys     *keyMap
+}
+
+func (a AlignItem) FilterValue() string {
+	return a.label
+}
+
+func (a AlignItem) View() string {
+	return a.label
+}
+
+type AlignDelegate struct {
+	colors *ColorsManager
+}
+
+func NewAlignDelegate(colors *ColorsManager) AlignDelegate {
+	return AlignDelegate{
+		colors: colors,
+	}
+}
+
+func (d AlignDelegate) Height() int {
+	return 1
+}
+
+func (d AlignDelegate) Spacing() int {
+	return 0
+}
+
+func (d AlignDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
+	item, ok := m.SelectedItem().(AlignItem)
+	if !ok {
+		logrus.Warning("Align delegate called with an item that is not an AlignItem")
+		return nil
+	}
+	// nolint:gocritic
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		// nolint:gocritic
+		switch msg.String() {
+		case "enter":
+			logrus.Debugf("Aligning: %s", item.label)
+			return AlignMonitorsCmd(item.action)
+		}
+	}
+	return nil
+}
+
+func (d AlignDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	alignItem, ok := item.(AlignItem)
+	if !ok {
+		return
+	}
+
+	var style lipgloss.Style
+	var prefix string
+	switch {
+	case index == m.Index():
+		style = d.colors.ListItemSelected()
+		prefix = "► "
+	default:
+		style = d.colors.ListItemUnselected()
+	}
+
+	fmt.Fprintf(w, "%s", style.Render(prefix+alignItem.View()))
+}
+
+func NewAlignList(monitors []*MonitorSpec, colors *ColorsManager, keys *KeyBindings) *AlignList {
+	delegate := NewAlignDelegate(colors)
+	alignList := list.New([]list.Item{}, delegate, 0, 0)
+	alignList.SetShowStatusBar(false)
+	alignList.SetFilteringEnabled(false)
+	alignList.SetShowHelp(false)
+	alignList.SetShowTitle(false)
+	alignList.KeyMap = keys.listKeyMap()
+
+	return &AlignList{
+		L:        alignList,
+		monitors: monitors,
+		help:     NewCustomHelp(colors),
+		colors:   colors,
+		keys:     &keys.root,
+	}
+}
+
+// SetItems lists the alignments against every other enabled monitor, then the ones
+// rearranging the whole layout
+func (a *AlignList) SetItems(monitor *MonitorSpec) tea.Cmd {
+	relative := []struct {
+		kind   AlignKind
+		format string
+	}{
+		{AlignTop, "Align top with %s"},
+		{AlignBottom, "Align bottom with %s"},
+		{AlignMiddle, "Align vertical center with %s"},
+		{CenterRightOf, "Center on the right of %s"},
+		{CenterLeftOf, "Center on the left of %s"},
+		{CenterAbove, "Center above %s"},
+		{CenterBelow, "Center below %s"},
+	}
+
+	items := []list.Item{}
+	for _, other := range a.monitors {
+		if other.Disabled || *other.ID == *monitor.ID {
+			continue
+		}
+		for _, r := range relative {
+			items = append(items, AlignItem{
+				label:  fmt.Sprintf(r.format, other.Name),
+				action: AlignAction{Kind: r.kind, MonitorID: *monitor.ID, TargetID: *other.ID},
+			})
+		}
+	}
+	items = append(items,
+		AlignItem{label: "Arrange all left to right", action: AlignAction{Kind: ArrangeLeftToRight}},
+		AlignItem{label: "Arrange all top to bottom", action: AlignAction{Kind: ArrangeTopToBottom}},
+		AlignItem{label: "Move the top-left corner to 0,0", action: AlignAction{Kind: NormalizeLayout}},
+	)
+
+	cmd := a.L.SetItems(items)
+	a.L.Select(0)
+	return cmd
+}
+
+func (a *AlignList) ClearItems() tea.Cmd {
+	cmd := a.L.SetItems([]list.Item{})
+	a.L.ResetSelected()
+	return cmd
+}
+
+func (a *AlignList) Update(msg tea.Msg) tea.Cmd {
+	// nolint:gocritic
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		// nolint:gocritic
+		switch msg.String() {
+		case "esc":
+			logrus.Debug("Close monitor align list")
+			return CloseMonitorAlignListCmd()
+		}
+	}
+	var cmd tea.Cmd
+	a.L, cmd = a.L.Update(msg)
+	return cmd
+}
+
+func (a *AlignList) View() string {
+	sections := []string{}
+	availHeight := a.L.Height()
+
+	title := a.colors.TitleStyle().Margin(0, 0, 1, 0).Render("Align the monitor")
+	availHeight -= lipgloss.Height(title)
+	sections = append(sections, title)
+
+	help := a.help.ShortHelpView([]key.Binding{
+		a.keys.Up, a.keys.Down, a.keys.Enter, a.keys.Back,
+	})
+	availHeight -= lipgloss.Height(help)
+
+	a.L.SetHeight(availHeight)
+	content := lipgloss.NewStyle().Height(availHeight).Render(a.L.View())
+	sections = append(sections, content)
+
+	sections = append(sections, help)
+
+	return lipgloss.JoinVertical(lipgloss.Top, sections...)
+}
+
+func (a *AlignList) SetHeight(height int) {
+	a.L.SetHeight(height)
+}
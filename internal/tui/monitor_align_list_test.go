@@ -0,0 +1,78 @@
+package tui_test
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/testutils"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/tui"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlignList_Update(t *testing.T) {
+	tests := []struct {
+		name        string
+		keys        []tea.KeyMsg
+		setupItems  bool
+		expectedMsg tea.Msg
+	}{
+		{
+			name:        "esc key closes align list",
+			keys:        []tea.KeyMsg{{Type: tea.KeyEsc}},
+			expectedMsg: tui.CloseMonitorAlignListCommand{},
+		},
+		{
+			name:       "enter aligns with the first monitor",
+			keys:       []tea.KeyMsg{{Type: tea.KeyEnter}},
+			setupItems: true,
+			expectedMsg: tui.AlignMonitorsCommand{
+				Action: tui.AlignAction{Kind: tui.AlignTop, MonitorID: 0, TargetID: 1},
+			},
+		},
+		{
+			name: "disabled monitors are skipped",
+			keys: []tea.KeyMsg{
+				{Type: tea.KeyRunes, Runes: []rune{'j'}},
+				{Type: tea.KeyRunes, Runes: []rune{'j'}},
+				{Type: tea.KeyRunes, Runes: []rune{'j'}},
+				{Type: tea.KeyRunes, Runes: []rune{'j'}},
+				{Type: tea.KeyRunes, Runes: []rune{'j'}},
+				{Type: tea.KeyRunes, Runes: []rune{'j'}},
+				{Type: tea.KeyRunes, Runes: []rune{'j'}},
+				{Type: tea.KeyEnter},
+			},
+			setupItems: true,
+			expectedMsg: tui.AlignMonitorsCommand{
+				Action: tui.AlignAction{Kind: tui.ArrangeLeftToRight},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			monitors := []*tui.MonitorSpec{
+				{ID: utils.IntPtr(0), Name: "eDP-1"},
+				{ID: utils.IntPtr(1), Name: "HDMI-1"},
+				{ID: utils.IntPtr(2), Name: "DP-1", Disabled: true},
+			}
+			cfg := testutils.NewTestConfig(t).Get()
+			alignList := tui.NewAlignList(monitors, tui.NewColorsManager(cfg), tui.NewKeyBindings(cfg))
+			alignList.SetHeight(40)
+
+			if tt.setupItems {
+				alignList.SetItems(monitors[0])
+				assert.Len(t, alignList.L.Items(), 10)
+			}
+
+			var cmd tea.Cmd
+			for _, key := range tt.keys {
+				cmd = alignList.Update(key)
+			}
+
+			require.NotNil(t, cmd)
+			assert.Equal(t, tt.expectedMsg, cmd())
+		})
+	}
+}
@@ -25,11 +25,13 @@ func (m colorPresetItem) View() string {
 
 type colorPresetDelegate struct {
 	colors *ColorsManager
+	keys   *colorPickerKepMap
 }
 
-func NewColorPresetDelegate(colors *ColorsManager) colorPresetDelegate {
+func NewColorPresetDelegate(colors *ColorsManager, keys *KeyBindings) colorPresetDelegate {
 	return colorPresetDelegate{
 		colors: colors,
+		keys:   &keys.colorPicker,
 	}
 }
 
@@ -51,11 +53,11 @@ func (d colorPresetDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
 	// nolint:gocritic
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "up", "k", "down", "j":
+		switch {
+		case key.Matches(msg, d.keys.Up, d.keys.Down):
 			logrus.Debugf("Setting color preset to: %s", item.preset.Value())
 			cmds = append(cmds, ChangeColorPresetCmd(item.preset))
-		case "enter":
+		case msg.String() == "enter":
 			logrus.Debugf("Setting final preset to: %s", item.preset.Value())
 			cmds = append(cmds, ChangeColorPresetFinalCmd(item.preset))
 		}
@@ -85,15 +87,53 @@ func (d colorPresetDelegate) Render(w io.Writer, m list.Model, index int, item l
 }
 
 type colorPickerKepMap struct {
-	FlipBitdepth        key.Binding
-	Back                key.Binding
-	Accept              key.Binding
-	Up                  key.Binding
-	Down                key.Binding
+	FlipBitdepth          key.Binding
+	Back                  key.Binding
+	Accept                key.Binding
+	Up                    key.Binding
+	Down                  key.Binding
+	IncreaseSdrBrightness key.Binding
+	DecreaseSdrBrightness key.Binding
+	IncreaseSdrSaturation key.Binding
+	DecreaseSdrSaturation key.Binding
+	// AdjustSdrBrightness and AdjustSdrSaturation show the increase and decrease keys as one help entry
 	AdjustSdrBrightness key.Binding
 	AdjustSdrSaturation key.Binding
 }
 
+func newColorPickerKeyMap(keys actionKeys) colorPickerKepMap {
+	keyMap := colorPickerKepMap{
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back"),
+		),
+		Accept: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "select"),
+		),
+		FlipBitdepth:          keys.binding("next_bitdepth", "b", "next bitdepth"),
+		Up:                    keys.binding("previous_preset", "up/k", "previous preset"),
+		Down:                  keys.binding("next_preset", "down/j", "next preset"),
+		IncreaseSdrBrightness: keys.binding("sdr_brightness_up", "r", "inc sdr brightness"),
+		DecreaseSdrBrightness: keys.binding("sdr_brightness_down", "R", "dec sdr brightness"),
+		IncreaseSdrSaturation: keys.binding("sdr_saturation_up", "t", "inc sdr saturation"),
+		DecreaseSdrSaturation: keys.binding("sdr_saturation_down", "T", "dec sdr saturation"),
+	}
+	keyMap.AdjustSdrBrightness = pairHelp(keyMap.IncreaseSdrBrightness, keyMap.DecreaseSdrBrightness,
+		"inc/dec sdr brightness")
+	keyMap.AdjustSdrSaturation = pairHelp(keyMap.IncreaseSdrSaturation, keyMap.DecreaseSdrSaturation,
+		"inc/dec sdr saturation")
+	return keyMap
+}
+
+// pairHelp is a help only binding of two opposite actions, e.g. r/R
+func pairHelp(inc, dec key.Binding, desc string) key.Binding {
+	return key.NewBinding(
+		key.WithKeys(append(inc.Keys(), dec.Keys()...)...),
+		key.WithHelp(inc.Help().Key+"/"+dec.Help().Key, desc),
+	)
+}
+
 func (s *colorPickerKepMap) Help(sdr bool) []key.Binding {
 	keys := []key.Binding{
 		s.FlipBitdepth,
@@ -121,14 +161,16 @@ type ColorPicker struct {
 	colors        *ColorsManager
 }
 
-func NewColorPicker(colors *ColorsManager) *ColorPicker {
+func NewColorPicker(colors *ColorsManager, keys *KeyBindings) *ColorPicker {
 	items := []list.Item{}
-	delegate := NewColorPresetDelegate(colors)
+	delegate := NewColorPresetDelegate(colors, keys)
 	list := list.New(items, delegate, 0, 0)
 	list.SetShowStatusBar(false)
 	list.SetFilteringEnabled(false)
 	list.SetShowHelp(false)
 	list.SetShowTitle(false)
+	list.KeyMap.CursorUp = keys.colorPicker.Up
+	list.KeyMap.CursorDown = keys.colorPicker.Down
 
 	return &ColorPicker{
 		colorPreset:   list,
@@ -136,36 +178,7 @@ func NewColorPicker(colors *ColorsManager) *ColorPicker {
 		sdrBrightness: NewNumberAdjuster(0.0, 2.0, 0.5, 0.01),
 		sdrSaturation: NewNumberAdjuster(0.0, 2.0, 0.5, 0.01),
 		colors:        colors,
-		keyMap: &colorPickerKepMap{
-			Back: key.NewBinding(
-				key.WithKeys("esc"),
-				key.WithHelp("esc", "back"),
-			),
-			Accept: key.NewBinding(
-				key.WithKeys("enter"),
-				key.WithHelp("enter", "select"),
-			),
-			FlipBitdepth: key.NewBinding(
-				key.WithKeys("b"),
-				key.WithHelp("b", "next bitdepth"),
-			),
-			Up: key.NewBinding(
-				key.WithKeys("up", "k"),
-				key.WithHelp("up/k", "previous preset"),
-			),
-			Down: key.NewBinding(
-				key.WithKeys("down", "j"),
-				key.WithHelp("down/j", "next preset"),
-			),
-			AdjustSdrBrightness: key.NewBinding(
-				key.WithKeys("r", "R"),
-				key.WithHelp("r/R", "inc/dec sdr brightness"),
-			),
-			AdjustSdrSaturation: key.NewBinding(
-				key.WithKeys("t", "T"),
-				key.WithHelp("t/T", "inc/dec sdr saturation"),
-			),
-		},
+		keyMap:        &keys.colorPicker,
 	}
 }
 
@@ -174,34 +187,29 @@ func (m *ColorPicker) Update(msg tea.Msg) tea.Cmd {
 	// nolint:gocritic
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		// nolint:gocritic
 		switch {
 		case key.Matches(msg, m.keyMap.FlipBitdepth):
 			logrus.Debug("FlipBitdepth requested")
 			cmds = append(cmds, nextBitdepthCmd(*m.monitor.ID))
-		}
-
-		// nolint:gocritic
-		switch msg.String() {
-		case "esc":
+		case key.Matches(msg, m.keyMap.Back):
 			logrus.Debug("Close color picker")
 			return CloseColorPickerCmd()
-		case "r":
+		case key.Matches(msg, m.keyMap.IncreaseSdrBrightness):
 			if m.monitor.ColorPreset.CanAdjustSdr() {
 				m.sdrBrightness.Increase()
 				cmds = append(cmds, AdjustSdrBrightnessCmd(*m.monitor.ID, m.sdrBrightness.Value()))
 			}
-		case "R":
+		case key.Matches(msg, m.keyMap.DecreaseSdrBrightness):
 			if m.monitor.ColorPreset.CanAdjustSdr() {
 				m.sdrBrightness.Decrease()
 				cmds = append(cmds, AdjustSdrBrightnessCmd(*m.monitor.ID, m.sdrBrightness.Value()))
 			}
-		case "t":
+		case key.Matches(msg, m.keyMap.IncreaseSdrSaturation):
 			if m.monitor.ColorPreset.CanAdjustSdr() {
 				m.sdrSaturation.Increase()
 				cmds = append(cmds, AdjustSdrSaturationCmd(*m.monitor.ID, m.sdrSaturation.Value()))
 			}
-		case "T":
+		case key.Matches(msg, m.keyMap.DecreaseSdrSaturation):
 			if m.monitor.ColorPreset.CanAdjustSdr() {
 				m.sdrSaturation.Decrease()
 				cmds = append(cmds, AdjustSdrSaturationCmd(*m.monitor.ID, m.sdrSaturation.Value()))
@@ -94,7 +94,7 @@ func TestColorPicker_Update(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			cfg := testutils.NewTestConfig(t).Get()
 			colors := tui.NewColorsManager(cfg)
-			colorPicker := tui.NewColorPicker(colors)
+			colorPicker := tui.NewColorPicker(colors, tui.NewKeyBindings(cfg))
 			colorPicker.SetMonitor(tt.setupMonitor)
 
 			cmd := colorPicker.Update(tt.key)
@@ -171,7 +171,7 @@ func TestColorPicker_SdrAdjustment(t *testing.T) {
 
 			cfg := testutils.NewTestConfig(t).Get()
 			colors := tui.NewColorsManager(cfg)
-			colorPicker := tui.NewColorPicker(colors)
+			colorPicker := tui.NewColorPicker(colors, tui.NewKeyBindings(cfg))
 			colorPicker.SetMonitor(monitor)
 			colorPicker.SetItems(monitor)
 
@@ -195,7 +195,7 @@ func TestColorPicker_SetMonitor(t *testing.T) {
 
 	cfg := testutils.NewTestConfig(t).Get()
 	colors := tui.NewColorsManager(cfg)
-	colorPicker := tui.NewColorPicker(colors)
+	colorPicker := tui.NewColorPicker(colors, tui.NewKeyBindings(cfg))
 	colorPicker.SetMonitor(monitor)
 
 	// Verify that the monitor was set by checking Update works
@@ -212,7 +212,7 @@ func TestColorPicker_Unset(t *testing.T) {
 
 	cfg := testutils.NewTestConfig(t).Get()
 	colors := tui.NewColorsManager(cfg)
-	colorPicker := tui.NewColorPicker(colors)
+	colorPicker := tui.NewColorPicker(colors, tui.NewKeyBindings(cfg))
 	colorPicker.SetMonitor(monitor)
 
 	cmd := colorPicker.Unset()
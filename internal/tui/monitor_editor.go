@@ -1,7 +1,10 @@
 package tui
 
 import (
+	"cmp"
 	"errors"
+	"fmt"
+	"slices"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
@@ -17,16 +20,90 @@ type MonitorEditorStore struct {
 	scaleStep    float64
 	snapDistance int
 	snapping     bool
+	history      *History
+	initial      map[int]monitorState
 }
 
 func NewMonitorEditor(monitors []*MonitorSpec) *MonitorEditorStore {
+	initial := make(map[int]monitorState, len(monitors))
+	for _, monitor := range monitors {
+		initial[*monitor.ID] = snapshotMonitor(monitor)
+	}
 	return &MonitorEditorStore{
 		monitors:     monitors,
 		positionStep: 50,
 		scaleStep:    0.1,
 		snapDistance: 50,
 		snapping:     true,
+		history:      NewHistory(100),
+		initial:      initial,
+	}
+}
+
+func (e *MonitorEditorStore) HistoryDepth() HistoryDepth {
+	return e.history.Depth()
+}
+
+// track snapshots the monitor before an operation, the returned func records the command once
+// the operation is done, merge collapses consecutive previews of the same operation
+func (e *MonitorEditorStore) track(operation OperationName, monitor *MonitorSpec, merge bool) func() {
+	before := snapshotMonitor(monitor)
+	return func() {
+		e.history.push(editCommand{
+			operation: operation,
+			changes: []monitorChange{{
+				monitorID: *monitor.ID,
+				before:    before,
+				after:     snapshotMonitor(monitor),
+			}},
+		}, merge)
+	}
+}
+
+func (e *MonitorEditorStore) Undo() tea.Cmd {
+	command, ok := e.history.popUndo()
+	if !ok {
+		return OperationStatusCmd(OperationNameUndo, ErrNothingToUndo)
+	}
+	for _, change := range command.changes {
+		if monitor, _, err := e.FindByID(change.monitorID); err == nil {
+			change.before.restore(monitor)
+		}
+	}
+	return OperationStatusCmd(OperationNameUndo, nil)
+}
+
+func (e *MonitorEditorStore) Redo() tea.Cmd {
+	command, ok := e.history.popRedo()
+	if !ok {
+		return OperationStatusCmd(OperationNameRedo, ErrNothingToRedo)
+	}
+	for _, change := range command.changes {
+		if monitor, _, err := e.FindByID(change.monitorID); err == nil {
+			change.after.restore(monitor)
+		}
 	}
+	return OperationStatusCmd(OperationNameRedo, nil)
+}
+
+// ResetToInitial brings every monitor back to what Hyprland reported at startup, the reset
+// itself can be undone
+func (e *MonitorEditorStore) ResetToInitial() tea.Cmd {
+	command := editCommand{operation: OperationNameResetEdits}
+	for _, monitor := range e.monitors {
+		initial, ok := e.initial[*monitor.ID]
+		if !ok {
+			continue
+		}
+		command.changes = append(command.changes, monitorChange{
+			monitorID: *monitor.ID,
+			before:    snapshotMonitor(monitor),
+			after:     initial,
+		})
+		initial.restore(monitor)
+	}
+	e.history.push(command, false)
+	return OperationStatusCmd(OperationNameResetEdits, nil)
 }
 
 func (e *MonitorEditorStore) SetSnapping(snap bool) {
@@ -57,6 +134,8 @@ func (e *MonitorEditorStore) MoveMonitor(monitorID int, dx, dy Delta) tea.Cmd {
 		return OperationStatusCmd(OperationNameMove, ErrMonitorDisabled)
 	}
 
+	defer e.track(OperationNameMove, monitor, false)()
+
 	dxValue := e.GetMoveDelta(dx)
 	dyValue := e.GetMoveDelta(dy)
 	newX := monitor.X + dxValue
@@ -75,6 +154,166 @@ func (e *MonitorEditorStore) MoveMonitor(monitorID int, dx, dy Delta) tea.Cmd {
 	return cmd
 }
 
+// DragMonitor places the monitor at x, y with the same snapping as MoveMonitor, the moves of a
+// single drag collapse into one history command
+func (e *MonitorEditorStore) DragMonitor(monitorID, x, y int, newDrag bool) tea.Cmd {
+	monitor, index, err := e.FindByID(monitorID)
+	if err != nil {
+		return OperationStatusCmd(OperationNameMove, err)
+	}
+
+	if monitor.Disabled {
+		return OperationStatusCmd(OperationNameMove, ErrMonitorDisabled)
+	}
+
+	if newDrag {
+		e.history.seal()
+	}
+	defer e.track(OperationNameMove, monitor, true)()
+
+	if !e.snapping {
+		monitor.X = x
+		monitor.Y = y
+		return nil
+	}
+
+	snappedX, snappedY, cmd := e.snapToEdges(index, x, y)
+	monitor.X = snappedX
+	monitor.Y = snappedY
+
+	return cmd
+}
+
+// trackAll is track for operations that move several monitors at once
+func (e *MonitorEditorStore) trackAll(operation OperationName) func() {
+	before := make([]monitorState, len(e.monitors))
+	for i, monitor := range e.monitors {
+		before[i] = snapshotMonitor(monitor)
+	}
+	return func() {
+		command := editCommand{operation: operation}
+		for i, monitor := range e.monitors {
+			command.changes = append(command.changes, monitorChange{
+				monitorID: *monitor.ID,
+				before:    before[i],
+				after:     snapshotMonitor(monitor),
+			})
+		}
+		e.history.push(command, false)
+	}
+}
+
+// Align runs an action picked in the align list, sizes are logical so scale and rotation
+// are respected
+func (e *MonitorEditorStore) Align(action AlignAction) tea.Cmd {
+	switch action.Kind {
+	case ArrangeLeftToRight, ArrangeTopToBottom:
+		return e.arrange(action.Kind == ArrangeLeftToRight)
+	case NormalizeLayout:
+		return e.normalize()
+	}
+
+	monitor, _, err := e.FindByID(action.MonitorID)
+	if err != nil {
+		return OperationStatusCmd(OperationNameAlign, err)
+	}
+	target, _, err := e.FindByID(action.TargetID)
+	if err != nil {
+		return OperationStatusCmd(OperationNameAlign, err)
+	}
+	if monitor.Disabled || target.Disabled {
+		return OperationStatusCmd(OperationNameAlign, ErrMonitorDisabled)
+	}
+
+	defer e.track(OperationNameAlign, monitor, false)()
+
+	width, height := e.getMonitorDimensions(monitor)
+	targetWidth, targetHeight := e.getMonitorDimensions(target)
+	centeredX := target.X + (targetWidth-width)/2
+	centeredY := target.Y + (targetHeight-height)/2
+
+	switch action.Kind {
+	case AlignTop:
+		monitor.Y = target.Y
+	case AlignBottom:
+		monitor.Y = target.Y + targetHeight - height
+	case AlignMiddle:
+		monitor.Y = centeredY
+	case CenterAbove:
+		monitor.X, monitor.Y = centeredX, target.Y-height
+	case CenterBelow:
+		monitor.X, monitor.Y = centeredX, target.Y+targetHeight
+	case CenterLeftOf:
+		monitor.X, monitor.Y = target.X-width, centeredY
+	case CenterRightOf:
+		monitor.X, monitor.Y = target.X+targetWidth, centeredY
+	}
+
+	return OperationStatusCmd(OperationNameAlign, nil)
+}
+
+// positioned are the monitors whose position matters, disabled and mirroring ones are skipped
+func (e *MonitorEditorStore) positioned() []*MonitorSpec {
+	monitors := []*MonitorSpec{}
+	for _, monitor := range e.monitors {
+		if monitor.Disabled || (monitor.Mirror != "" && monitor.Mirror != "none") {
+			continue
+		}
+		monitors = append(monitors, monitor)
+	}
+	return monitors
+}
+
+// arrange puts the monitors next to each other in their current order, starting at 0,0 with
+// the top (or left) edges lined up
+func (e *MonitorEditorStore) arrange(leftToRight bool) tea.Cmd {
+	defer e.trackAll(OperationNameArrange)()
+
+	monitors := e.positioned()
+	slices.SortStableFunc(monitors, func(a, b *MonitorSpec) int {
+		if leftToRight {
+			return cmp.Or(cmp.Compare(a.X, b.X), cmp.Compare(a.Y, b.Y))
+		}
+		return cmp.Or(cmp.Compare(a.Y, b.Y), cmp.Compare(a.X, b.X))
+	})
+
+	offset := 0
+	for _, monitor := range monitors {
+		width, height := e.getMonitorDimensions(monitor)
+		if leftToRight {
+			monitor.X, monitor.Y = offset, 0
+			offset += width
+			continue
+		}
+		monitor.X, monitor.Y = 0, offset
+		offset += height
+	}
+
+	return OperationStatusCmd(OperationNameArrange, nil)
+}
+
+// normalize shifts the layout so that its top-left corner is at 0,0
+func (e *MonitorEditorStore) normalize() tea.Cmd {
+	monitors := e.positioned()
+	if len(monitors) == 0 {
+		return OperationStatusCmd(OperationNameNormalize, nil)
+	}
+
+	defer e.trackAll(OperationNameNormalize)()
+
+	minX, minY := monitors[0].X, monitors[0].Y
+	for _, monitor := range monitors {
+		minX = min(minX, monitor.X)
+		minY = min(minY, monitor.Y)
+	}
+	for _, monitor := range monitors {
+		monitor.X -= minX
+		monitor.Y -= minY
+	}
+
+	return OperationStatusCmd(OperationNameNormalize, nil)
+}
+
 func (e *MonitorEditorStore) AdjustSdrSaturation(monitorID int, value float64) tea.Cmd {
 	monitor, _, err := e.FindByID(monitorID)
 	if err != nil {
@@ -85,6 +324,8 @@ func (e *MonitorEditorStore) AdjustSdrSaturation(monitorID int, value float64) t
 		return OperationStatusCmd(OperationNameAdjustSdrSaturation, ErrMonitorDisabled)
 	}
 
+	defer e.track(OperationNameAdjustSdrSaturation, monitor, true)()
+
 	monitor.SdrSaturation = value
 
 	return OperationStatusCmd(OperationNameAdjustSdrSaturation, nil)
@@ -100,6 +341,8 @@ func (e *MonitorEditorStore) AdjustSdrBrightness(monitorID int, value float64) t
 		return OperationStatusCmd(OperationNameAdjustSdrBrightness, ErrMonitorDisabled)
 	}
 
+	defer e.track(OperationNameAdjustSdrBrightness, monitor, true)()
+
 	monitor.SdrBrightness = value
 
 	return OperationStatusCmd(OperationNameAdjustSdrBrightness, nil)
@@ -115,6 +358,8 @@ func (e *MonitorEditorStore) ScaleMonitor(monitorID int, newScale float64) tea.C
 		return OperationStatusCmd(OperationNameScale, ErrMonitorDisabled)
 	}
 
+	defer e.track(OperationNameScale, monitor, true)()
+
 	if newScale >= e.scaleStep {
 		monitor.Scale = newScale
 	}
@@ -127,6 +372,63 @@ func (e *MonitorEditorStore) ScaleMonitor(monitorID int, newScale float64) tea.C
 	return OperationStatusCmd(OperationNameScale, nil)
 }
 
+// SetPosition places the monitor at exactly x, y, typed positions are not snapped
+func (e *MonitorEditorStore) SetPosition(monitorID, x, y int) tea.Cmd {
+	monitor, _, err := e.FindByID(monitorID)
+	if err != nil {
+		return OperationStatusCmd(OperationNameSetPosition, err)
+	}
+
+	if monitor.Disabled {
+		return OperationStatusCmd(OperationNameSetPosition, ErrMonitorDisabled)
+	}
+
+	defer e.track(OperationNameSetPosition, monitor, false)()
+
+	monitor.X = x
+	monitor.Y = y
+
+	return OperationStatusCmd(OperationNameSetPosition, nil)
+}
+
+// SetScale applies a typed scale as a single undo step, unlike the previews of ScaleMonitor
+func (e *MonitorEditorStore) SetScale(monitorID int, scale float64) tea.Cmd {
+	monitor, _, err := e.FindByID(monitorID)
+	if err != nil {
+		return OperationStatusCmd(OperationNameTypeScale, err)
+	}
+
+	if monitor.Disabled {
+		return OperationStatusCmd(OperationNameTypeScale, ErrMonitorDisabled)
+	}
+
+	if scale < e.scaleStep {
+		return OperationStatusCmd(OperationNameTypeScale, fmt.Errorf("scale must be at least %.1f", e.scaleStep))
+	}
+
+	defer e.track(OperationNameTypeScale, monitor, false)()
+
+	monitor.Scale = scale
+
+	return OperationStatusCmd(OperationNameTypeScale, nil)
+}
+
+// SetCustomMode sets a typed mode, which might not be one of the modes the monitor reports
+func (e *MonitorEditorStore) SetCustomMode(monitorID int, mode string) tea.Cmd {
+	monitor, _, err := e.FindByID(monitorID)
+	if err != nil {
+		return OperationStatusCmd(OperationNameCustomMode, err)
+	}
+
+	if monitor.Disabled {
+		return OperationStatusCmd(OperationNameCustomMode, ErrMonitorDisabled)
+	}
+
+	defer e.track(OperationNameCustomMode, monitor, false)()
+
+	return OperationStatusCmd(OperationNameCustomMode, monitor.SetMode(mode))
+}
+
 func (e *MonitorEditorStore) RotateMonitor(monitorID int) tea.Cmd {
 	monitor, _, err := e.FindByID(monitorID)
 	if err != nil {
@@ -137,6 +439,8 @@ func (e *MonitorEditorStore) RotateMonitor(monitorID int) tea.Cmd {
 		return OperationStatusCmd(OperationNameRotate, ErrMonitorDisabled)
 	}
 
+	defer e.track(OperationNameRotate, monitor, false)()
+
 	monitor.Rotate()
 
 	return OperationStatusCmd(OperationNameRotate, nil)
@@ -152,6 +456,8 @@ func (e *MonitorEditorStore) FlipMonitor(monitorID int) tea.Cmd {
 		return OperationStatusCmd(OperationNameFlipMonitor, ErrMonitorDisabled)
 	}
 
+	defer e.track(OperationNameFlipMonitor, monitor, false)()
+
 	monitor.ToggleFlip()
 
 	return OperationStatusCmd(OperationNameFlipMonitor, nil)
@@ -167,6 +473,8 @@ func (e *MonitorEditorStore) ToggleVRR(monitorID int) tea.Cmd {
 		return OperationStatusCmd(OperationNameToggleVRR, ErrMonitorDisabled)
 	}
 
+	defer e.track(OperationNameToggleVRR, monitor, false)()
+
 	monitor.ToggleVRR()
 
 	return OperationStatusCmd(OperationNameToggleVRR, nil)
@@ -194,6 +502,7 @@ func (e *MonitorEditorStore) ToggleDisable(monitorID int) tea.Cmd {
 		return OperationStatusCmd(OperationNameToggleMonitor, errors.New("only one monitor left"))
 	}
 
+	defer e.track(OperationNameToggleMonitor, monitor, false)()
 	monitor.ToggleMonitor()
 	return OperationStatusCmd(OperationNameToggleMonitor, nil)
 }
@@ -208,6 +517,8 @@ func (e *MonitorEditorStore) SetColorPreset(monitorID int, preset ColorPreset) t
 		return OperationStatusCmd(OperationNameNextBitdepth, ErrMonitorDisabled)
 	}
 
+	defer e.track(OperationNameSetColorPreset, monitor, true)()
+
 	monitor.SetPreset(preset)
 
 	return OperationStatusCmd(OperationNameSetColorPreset, nil)
@@ -223,6 +534,8 @@ func (e *MonitorEditorStore) NextBitdepth(monitorID int) tea.Cmd {
 		return OperationStatusCmd(OperationNameNextBitdepth, ErrMonitorDisabled)
 	}
 
+	defer e.track(OperationNameNextBitdepth, monitor, false)()
+
 	monitor.NextBitdepth()
 
 	return OperationStatusCmd(OperationNameNextBitdepth, nil)
@@ -263,6 +576,7 @@ func (e *MonitorEditorStore) SetMirror(monitorID int, mirrorOf string) tea.Cmd {
 		return OperationStatusCmd(OperationNamePreviewMirror, errors.New("would create mirror loop"))
 	}
 
+	defer e.track(OperationNamePreviewMirror, monitor, true)()
 	monitor.SetMirror(mirrorOf)
 
 	return OperationStatusCmd(OperationNamePreviewMirror, err)
@@ -334,6 +648,8 @@ func (e *MonitorEditorStore) SetMode(monitorID int, mode string) tea.Cmd {
 		return OperationStatusCmd(OperationNamePreviewMode, ErrMonitorDisabled)
 	}
 
+	defer e.track(OperationNamePreviewMode, monitor, true)()
+
 	err = monitor.SetMode(mode)
 	if err != nil {
 		return OperationStatusCmd(OperationNamePreviewMode, err)
@@ -356,8 +672,9 @@ func (e *MonitorEditorStore) getMonitorCenter(monitor *MonitorSpec, x, y int) (i
 }
 
 func (e *MonitorEditorStore) getMonitorDimensions(monitor *MonitorSpec) (int, int) {
-	scaledWidth := int(float64(monitor.Width) / monitor.Scale)
-	scaledHeight := int(float64(monitor.Height) / monitor.Scale)
+	logicalWidth, logicalHeight := monitor.LogicalSize(monitor.Scale)
+	scaledWidth := int(logicalWidth)
+	scaledHeight := int(logicalHeight)
 
 	if monitor.NeedsDimensionsSwap() {
 		return scaledHeight, scaledWidth
@@ -438,3 +438,252 @@ func findMonitorByID(monitors []*tui.MonitorSpec, id int) *tui.MonitorSpec {
 	}
 	return nil
 }
+
+func TestMonitorEditor_UndoRedo(t *testing.T) {
+	monitors, err := tui.LoadMonitorsFromJSON("testdata/two.json")
+	require.NoError(t, err)
+	editor := tui.NewMonitorEditor(monitors)
+	editor.SetSnapping(false)
+	monitor := monitors[0]
+	startX, startScale, startTransform := monitor.X, monitor.Scale, monitor.Transform
+
+	editor.MoveMonitor(*monitor.ID, tui.DeltaMore, tui.DeltaNone)
+	editor.MoveMonitor(*monitor.ID, tui.DeltaMore, tui.DeltaNone)
+	editor.RotateMonitor(*monitor.ID)
+	// previews of the same operation collapse into a single history entry
+	editor.ScaleMonitor(*monitor.ID, 2)
+	editor.ScaleMonitor(*monitor.ID, 1.5)
+	assert.Equal(t, tui.HistoryDepth{Undo: 4}, editor.HistoryDepth())
+
+	editor.Undo()
+	assert.Equal(t, startScale, monitor.Scale)
+	editor.Undo()
+	assert.Equal(t, startTransform, monitor.Transform)
+	editor.Undo()
+	assert.Equal(t, startX+50, monitor.X)
+	assert.Equal(t, tui.HistoryDepth{Undo: 1, Redo: 3}, editor.HistoryDepth())
+
+	editor.Redo()
+	assert.Equal(t, startX+100, monitor.X)
+
+	// a new edit drops what could be redone
+	editor.ToggleVRR(*monitor.ID)
+	assert.Equal(t, tui.HistoryDepth{Undo: 3}, editor.HistoryDepth())
+	msg := editor.Redo()()
+	status, ok := msg.(tui.OperationStatus)
+	require.True(t, ok)
+	assert.True(t, status.IsError())
+}
+
+func TestMonitorEditor_ResetToInitial(t *testing.T) {
+	monitors, err := tui.LoadMonitorsFromJSON("testdata/two.json")
+	require.NoError(t, err)
+	editor := tui.NewMonitorEditor(monitors)
+	editor.SetSnapping(false)
+	first, second := monitors[0], monitors[1]
+	startFirst, startSecond := *first, *second
+
+	editor.MoveMonitor(*first.ID, tui.DeltaLess, tui.DeltaMore)
+	editor.RotateMonitor(*second.ID)
+	editor.ToggleVRR(*second.ID)
+
+	editor.ResetToInitial()
+	assert.Equal(t, startFirst.X, first.X)
+	assert.Equal(t, startFirst.Y, first.Y)
+	assert.Equal(t, startSecond.Transform, second.Transform)
+	assert.Equal(t, startSecond.Vrr, second.Vrr)
+	assert.Equal(t, tui.HistoryDepth{Undo: 4}, editor.HistoryDepth())
+
+	editor.Undo()
+	assert.NotEqual(t, startSecond.Transform, second.Transform)
+	assert.NotEqual(t, startSecond.Vrr, second.Vrr)
+}
+
+func TestMonitorEditor_DragMonitor(t *testing.T) {
+	monitors, err := tui.LoadMonitorsFromJSON("testdata/two.json")
+	require.NoError(t, err)
+	editor := tui.NewMonitorEditor(monitors)
+	editor.SetSnapping(false)
+	monitor := monitors[1]
+	startX, startY := monitor.X, monitor.Y
+
+	// every move of a single drag is one history command
+	editor.DragMonitor(*monitor.ID, startX+100, startY, true)
+	editor.DragMonitor(*monitor.ID, startX+200, startY+10, false)
+	editor.DragMonitor(*monitor.ID, startX+300, startY+20, false)
+	assert.Equal(t, startX+300, monitor.X)
+	assert.Equal(t, startY+20, monitor.Y)
+	assert.Equal(t, tui.HistoryDepth{Undo: 1}, editor.HistoryDepth())
+
+	// a new drag does not merge into the previous one
+	editor.DragMonitor(*monitor.ID, startX+400, startY+20, true)
+	assert.Equal(t, tui.HistoryDepth{Undo: 2}, editor.HistoryDepth())
+
+	editor.Undo()
+	assert.Equal(t, startX+300, monitor.X)
+	editor.Undo()
+	assert.Equal(t, startX, monitor.X)
+	assert.Equal(t, startY, monitor.Y)
+}
+
+func TestMonitorEditor_DragMonitorSnaps(t *testing.T) {
+	monitors, err := tui.LoadMonitorsFromJSON("testdata/two.json")
+	require.NoError(t, err)
+	editor := tui.NewMonitorEditor(monitors)
+	dragged, other := monitors[0], monitors[1]
+	otherWidth := int(float64(other.Width) / other.Scale)
+
+	cmd := editor.DragMonitor(*dragged.ID, other.X+otherWidth+20, other.Y+15, true)
+	assert.Equal(t, other.X+otherWidth, dragged.X)
+	assert.Equal(t, other.Y, dragged.Y)
+	require.NotNil(t, cmd)
+	_, ok := cmd().(tui.ShowGridLineCommand)
+	assert.True(t, ok, "snapping should show the grid lines")
+}
+
+func TestMonitorEditor_Align(t *testing.T) {
+	type position struct{ X, Y int }
+	// eDP-1 is 1440x960 logically, DP-1 is 3072x1728
+	tests := []struct {
+		name      string
+		action    tui.AlignAction
+		transform int
+		expected  map[string]position
+	}{
+		{
+			name:     "align top",
+			action:   tui.AlignAction{Kind: tui.AlignTop, MonitorID: 0, TargetID: 1},
+			expected: map[string]position{"eDP-1": {1920, 0}, "DP-1": {0, 0}},
+		},
+		{
+			name:     "align bottom",
+			action:   tui.AlignAction{Kind: tui.AlignBottom, MonitorID: 0, TargetID: 1},
+			expected: map[string]position{"eDP-1": {1920, 768}, "DP-1": {0, 0}},
+		},
+		{
+			name:     "align vertical center",
+			action:   tui.AlignAction{Kind: tui.AlignMiddle, MonitorID: 0, TargetID: 1},
+			expected: map[string]position{"eDP-1": {1920, 384}, "DP-1": {0, 0}},
+		},
+		{
+			name:     "center below",
+			action:   tui.AlignAction{Kind: tui.CenterBelow, MonitorID: 0, TargetID: 1},
+			expected: map[string]position{"eDP-1": {816, 1728}, "DP-1": {0, 0}},
+		},
+		{
+			name:     "center above",
+			action:   tui.AlignAction{Kind: tui.CenterAbove, MonitorID: 0, TargetID: 1},
+			expected: map[string]position{"eDP-1": {816, -960}, "DP-1": {0, 0}},
+		},
+		{
+			name:     "center on the right",
+			action:   tui.AlignAction{Kind: tui.CenterRightOf, MonitorID: 0, TargetID: 1},
+			expected: map[string]position{"eDP-1": {3072, 384}, "DP-1": {0, 0}},
+		},
+		{
+			name:      "center on the right of a rotated monitor",
+			action:    tui.AlignAction{Kind: tui.CenterRightOf, MonitorID: 0, TargetID: 1},
+			transform: 1,
+			expected:  map[string]position{"eDP-1": {3072, 144}, "DP-1": {0, 0}},
+		},
+		{
+			name:     "center on the left of a larger monitor",
+			action:   tui.AlignAction{Kind: tui.CenterLeftOf, MonitorID: 1, TargetID: 0},
+			expected: map[string]position{"eDP-1": {1920, 1080}, "DP-1": {-1152, 696}},
+		},
+		{
+			name:     "arrange left to right",
+			action:   tui.AlignAction{Kind: tui.ArrangeLeftToRight},
+			expected: map[string]position{"eDP-1": {3072, 0}, "DP-1": {0, 0}},
+		},
+		{
+			name:     "arrange top to bottom",
+			action:   tui.AlignAction{Kind: tui.ArrangeTopToBottom},
+			expected: map[string]position{"eDP-1": {0, 1728}, "DP-1": {0, 0}},
+		},
+		{
+			name:     "normalize keeps the layout",
+			action:   tui.AlignAction{Kind: tui.NormalizeLayout},
+			expected: map[string]position{"eDP-1": {1920, 1080}, "DP-1": {0, 0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			monitors, err := tui.LoadMonitorsFromJSON("testdata/two.json")
+			require.NoError(t, err)
+			monitors[0].Transform = tt.transform
+			editor := tui.NewMonitorEditor(monitors)
+
+			status, ok := editor.Align(tt.action)().(tui.OperationStatus)
+			require.True(t, ok)
+			assert.False(t, status.IsError())
+
+			for _, monitor := range monitors {
+				assert.Equal(t, tt.expected[monitor.Name], position{monitor.X, monitor.Y}, monitor.Name)
+			}
+		})
+	}
+}
+
+func TestMonitorEditor_AlignNormalizeAndUndo(t *testing.T) {
+	monitors, err := tui.LoadMonitorsFromJSON("testdata/two.json")
+	require.NoError(t, err)
+	editor := tui.NewMonitorEditor(monitors)
+	laptop, external := monitors[0], monitors[1]
+
+	editor.Align(tui.AlignAction{Kind: tui.CenterAbove, MonitorID: 0, TargetID: 1})
+	editor.Align(tui.AlignAction{Kind: tui.NormalizeLayout})
+	assert.Equal(t, []int{816, 0}, []int{laptop.X, laptop.Y})
+	assert.Equal(t, []int{0, 960}, []int{external.X, external.Y})
+	assert.Equal(t, tui.HistoryDepth{Undo: 2}, editor.HistoryDepth())
+
+	// normalizing moves both monitors in a single step
+	editor.Undo()
+	assert.Equal(t, []int{816, -960}, []int{laptop.X, laptop.Y})
+	assert.Equal(t, []int{0, 0}, []int{external.X, external.Y})
+
+	// disabled monitors are left alone
+	external.Disabled = true
+	status, ok := editor.Align(tui.AlignAction{Kind: tui.AlignTop, MonitorID: 0, TargetID: 1})().(tui.OperationStatus)
+	require.True(t, ok)
+	assert.True(t, status.IsError())
+}
+
+func TestMonitorEditor_SetExactValues(t *testing.T) {
+	monitors, err := tui.LoadMonitorsFromJSON("testdata/two.json")
+	require.NoError(t, err)
+	editor := tui.NewMonitorEditor(monitors)
+	monitor := monitors[0]
+	start := *monitor
+
+	// typed positions are not snapped, even right next to the other monitor
+	editor.SetPosition(*monitor.ID, 3077, -3)
+	assert.Equal(t, []int{3077, -3}, []int{monitor.X, monitor.Y})
+
+	editor.SetScale(*monitor.ID, 1.5)
+	assert.InEpsilon(t, 1.5, monitor.Scale, 1e-9)
+
+	status, ok := editor.SetCustomMode(*monitor.ID, "2560x1600@75.00Hz")().(tui.OperationStatus)
+	require.True(t, ok)
+	assert.False(t, status.IsError())
+	assert.Equal(t, "2560x1600@75.00000", monitor.Mode())
+
+	// every typed value is its own undo step
+	assert.Equal(t, tui.HistoryDepth{Undo: 3}, editor.HistoryDepth())
+	editor.Undo()
+	assert.Equal(t, start.Mode(), monitor.Mode())
+	editor.Undo()
+	assert.InEpsilon(t, start.Scale, monitor.Scale, 1e-9)
+	editor.Undo()
+	assert.Equal(t, []int{start.X, start.Y}, []int{monitor.X, monitor.Y})
+
+	status, ok = editor.SetScale(*monitor.ID, 0.01)().(tui.OperationStatus)
+	require.True(t, ok)
+	assert.True(t, status.IsError())
+
+	monitor.Disabled = true
+	status, ok = editor.SetPosition(*monitor.ID, 0, 0)().(tui.OperationStatus)
+	require.True(t, ok)
+	assert.True(t, status.IsError())
+}
@@ -0,0 +1,256 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sirupsen/logrus"
+)
+
+type exactInputKeyMap struct {
+	Apply key.Binding
+	Next  key.Binding
+	Back  key.Binding
+}
+
+func (e *exactInputKeyMap) Help() []key.Binding {
+	return []key.Binding{
+		e.Apply,
+		e.Next,
+		e.Back,
+	}
+}
+
+// ExactInput types the position, scale or mode of the edited monitor instead of stepping
+// through them
+type ExactInput struct {
+	input   textinput.Model
+	field   ExactField
+	monitor *MonitorSpec
+	warning string
+	width   int
+	height  int
+	help    *CustomHelp
+	keyMap  *exactInputKeyMap
+	colors  *ColorsManager
+}
+
+func NewExactInput(colors *ColorsManager) *ExactInput {
+	ti := textinput.New()
+	ti.CharLimit = 30
+	ti.Width = 30
+
+	return &ExactInput{
+		input:  ti,
+		help:   NewCustomHelp(colors),
+		colors: colors,
+		keyMap: &exactInputKeyMap{
+			Apply: key.NewBinding(
+				key.WithKeys("enter"),
+				key.WithHelp("enter", "apply"),
+			),
+			Next: key.NewBinding(
+				key.WithKeys("tab"),
+				key.WithHelp("tab", "next field"),
+			),
+			Back: key.NewBinding(
+				key.WithKeys("esc"),
+				key.WithHelp("esc", "back"),
+			),
+		},
+	}
+}
+
+func (e *ExactInput) SetWidth(width int) {
+	e.width = width
+	e.input.Width = width - 5
+}
+
+func (e *ExactInput) SetHeight(height int) {
+	e.height = height
+}
+
+// Set loads the monitor values, the prompt always opens on the position
+func (e *ExactInput) Set(monitor *MonitorSpec) tea.Cmd {
+	e.monitor = monitor
+	e.load(ExactPosition)
+	e.input.Focus()
+	return nil
+}
+
+func (e *ExactInput) Unset() tea.Cmd {
+	e.monitor = nil
+	e.warning = ""
+	e.input.SetValue("")
+	e.input.Blur()
+	return nil
+}
+
+func (e *ExactInput) load(field ExactField) {
+	e.field = field
+	e.warning = ""
+	switch field {
+	case ExactPosition:
+		e.input.Placeholder = "1920x0"
+		e.input.SetValue(fmt.Sprintf("%dx%d", e.monitor.X, e.monitor.Y))
+	case ExactScale:
+		e.input.Placeholder = "1.333333"
+		e.input.SetValue(strconv.FormatFloat(e.monitor.Scale, 'f', -1, 64))
+	case ExactMode:
+		e.input.Placeholder = "2560x1440@75"
+		e.input.SetValue(e.monitor.ModeForComparison())
+	}
+	e.input.CursorEnd()
+}
+
+func (e *ExactInput) Update(msg tea.Msg) tea.Cmd {
+	if e.monitor == nil {
+		return nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, e.keyMap.Back):
+			logrus.Debug("Close exact input")
+			return CloseExactInputCmd()
+		case key.Matches(msg, e.keyMap.Next):
+			e.load((e.field + 1) % (ExactMode + 1))
+			return nil
+		case key.Matches(msg, e.keyMap.Apply):
+			return e.apply()
+		}
+	}
+
+	var cmd tea.Cmd
+	e.input, cmd = e.input.Update(msg)
+	return cmd
+}
+
+func (e *ExactInput) apply() tea.Cmd {
+	value := strings.TrimSpace(e.input.Value())
+	e.warning = ""
+
+	switch e.field {
+	case ExactPosition:
+		x, y, err := parsePosition(value)
+		if err != nil {
+			return OperationStatusCmd(OperationNameSetPosition, err)
+		}
+		return setMonitorPositionCmd(*e.monitor.ID, x, y)
+	case ExactScale:
+		scale, err := strconv.ParseFloat(value, 64)
+		if err != nil || scale <= 0 {
+			return OperationStatusCmd(OperationNameTypeScale, fmt.Errorf("invalid scale: %s", value))
+		}
+		valid, err := e.monitor.ClosestValidScale(scale, true, true)
+		if err != nil {
+			return OperationStatusCmd(OperationNameTypeScale, fmt.Errorf("cant validate scale: %w", err))
+		}
+		if valid != scale {
+			formatted := strconv.FormatFloat(valid, 'f', -1, 64)
+			e.warning = fmt.Sprintf("%s gives fractional pixels, using %s", value, formatted)
+			e.input.SetValue(formatted)
+			e.input.CursorEnd()
+		}
+		return setMonitorScaleCmd(*e.monitor.ID, valid)
+	case ExactMode:
+		mode, err := parseCustomMode(value, e.monitor.RefreshRate)
+		if err != nil {
+			return OperationStatusCmd(OperationNameCustomMode, err)
+		}
+		if !slices.Contains(e.monitor.AvailableModes, mode) {
+			e.warning = mode + " is not an available mode, Hyprland may fall back to another one"
+		}
+		return setMonitorModeCmd(*e.monitor.ID, mode)
+	}
+
+	return nil
+}
+
+// parsePosition reads "XxY" or "X,Y", negative coordinates are allowed
+func parsePosition(value string) (int, int, error) {
+	before, after, found := strings.Cut(strings.ReplaceAll(value, ",", "x"), "x")
+	if !found {
+		return 0, 0, fmt.Errorf("expected XxY, got: %s", value)
+	}
+	x, err := strconv.Atoi(strings.TrimSpace(before))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid x: %w", err)
+	}
+	y, err := strconv.Atoi(strings.TrimSpace(after))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid y: %w", err)
+	}
+	return x, y, nil
+}
+
+// parseCustomMode reads "WxH@R", "WxH@RHz" or "WxH", the latter keeps the current refresh rate,
+// the result has the format of the available modes
+func parseCustomMode(value string, refreshRate float64) (string, error) {
+	resolution, rate, hasRate := strings.Cut(strings.TrimSuffix(value, "Hz"), "@")
+	width, height, err := parsePosition(resolution)
+	if err != nil || width <= 0 || height <= 0 {
+		return "", fmt.Errorf("expected WxH@R, got: %s", value)
+	}
+	if hasRate {
+		refreshRate, err = strconv.ParseFloat(rate, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid refresh rate: %w", err)
+		}
+	}
+	if refreshRate <= 0 {
+		return "", errors.New("refresh rate must be positive")
+	}
+	return fmt.Sprintf("%dx%d@%.2fHz", width, height, refreshRate), nil
+}
+
+func (e *ExactInput) View() string {
+	if e.monitor == nil {
+		return ""
+	}
+
+	sections := []string{}
+	availableSpace := e.height
+
+	title := e.colors.TitleStyle().Margin(0, 0, 1, 0).Render("Type exact values")
+	sections = append(sections, title)
+	availableSpace -= lipgloss.Height(title)
+
+	fields := []string{}
+	for field := ExactPosition; field <= ExactMode; field++ {
+		if field == e.field {
+			fields = append(fields, e.colors.ListItemSelected().Render("["+field.String()+"]"))
+			continue
+		}
+		fields = append(fields, e.colors.MutedStyle().Render(" "+field.String()+" "))
+	}
+	tabs := strings.Join(fields, " ")
+	sections = append(sections, tabs)
+	availableSpace -= lipgloss.Height(tabs)
+
+	inputView := e.input.View()
+	sections = append(sections, inputView)
+	availableSpace -= lipgloss.Height(inputView)
+
+	if e.warning != "" {
+		warning := e.colors.WarningStyle().Width(e.width).Render("⚠ " + e.warning)
+		sections = append(sections, warning)
+		availableSpace -= lipgloss.Height(warning)
+	}
+
+	help := lipgloss.NewStyle().Width(e.width).Render(e.help.ShortHelpView(e.keyMap.Help()))
+	availableSpace -= lipgloss.Height(help)
+
+	spacer := lipgloss.NewStyle().Height(max(availableSpace, 0)).Render("")
+	sections = append(sections, spacer)
+	sections = append(sections, help)
+
+	return lipgloss.JoinVertical(lipgloss.Top, sections...)
+}
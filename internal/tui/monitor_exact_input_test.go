@@ -0,0 +1,137 @@
+package tui_test
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/testutils"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/tui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExactInput_Update(t *testing.T) {
+	tests := []struct {
+		name            string
+		tabs            int
+		value           string
+		expectedMsg     tea.Msg
+		expectError     bool
+		expectedWarning string
+	}{
+		{
+			name:        "position",
+			value:       "-1920x0",
+			expectedMsg: tui.SetMonitorPositionCommand{MonitorID: 0, X: -1920, Y: 0},
+		},
+		{
+			name:        "position with a comma",
+			value:       "1920, 1080",
+			expectedMsg: tui.SetMonitorPositionCommand{MonitorID: 0, X: 1920, Y: 1080},
+		},
+		{
+			name:        "invalid position",
+			value:       "1920",
+			expectError: true,
+		},
+		{
+			name:        "valid scale",
+			tabs:        1,
+			value:       "1.5",
+			expectedMsg: tui.SetMonitorScaleCommand{MonitorID: 0, Scale: 1.5},
+		},
+		{
+			name:            "scale with fractional pixels snaps",
+			tabs:            1,
+			value:           "1.3",
+			expectedMsg:     tui.SetMonitorScaleCommand{MonitorID: 0, Scale: 1.28},
+			expectedWarning: "1.3 gives fractional pixels, using 1.28",
+		},
+		{
+			name:        "invalid scale",
+			tabs:        1,
+			value:       "-2",
+			expectError: true,
+		},
+		{
+			name:        "available mode",
+			tabs:        2,
+			value:       "1920x1200@120",
+			expectedMsg: tui.SetMonitorModeCommand{MonitorID: 0, Mode: "1920x1200@120.00Hz"},
+		},
+		{
+			name:            "custom mode",
+			tabs:            2,
+			value:           "2560x1600@75Hz",
+			expectedMsg:     tui.SetMonitorModeCommand{MonitorID: 0, Mode: "2560x1600@75.00Hz"},
+			expectedWarning: "2560x1600@75.00Hz is not an available mode",
+		},
+		{
+			name:            "custom mode keeps the refresh rate",
+			tabs:            2,
+			value:           "2560x1600",
+			expectedMsg:     tui.SetMonitorModeCommand{MonitorID: 0, Mode: "2560x1600@120.00Hz"},
+			expectedWarning: "is not an available mode",
+		},
+		{
+			name:        "invalid mode",
+			tabs:        2,
+			value:       "2560x@60",
+			expectError: true,
+		},
+		{
+			name:        "tab wraps back to the position",
+			tabs:        3,
+			value:       "0x0",
+			expectedMsg: tui.SetMonitorPositionCommand{MonitorID: 0, X: 0, Y: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			monitors, err := tui.LoadMonitorsFromJSON("testdata/two.json")
+			require.NoError(t, err)
+			cfg := testutils.NewTestConfig(t).Get()
+			input := tui.NewExactInput(tui.NewColorsManager(cfg))
+			input.SetHeight(20)
+			input.SetWidth(80)
+			input.Set(monitors[0])
+
+			for range tt.tabs {
+				input.Update(tea.KeyMsg{Type: tea.KeyTab})
+			}
+			for range 40 {
+				input.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+			}
+			input.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.value)})
+
+			cmd := input.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			require.NotNil(t, cmd)
+			msg := cmd()
+			if tt.expectError {
+				status, ok := msg.(tui.OperationStatus)
+				require.True(t, ok)
+				assert.True(t, status.IsError())
+				return
+			}
+			assert.Equal(t, tt.expectedMsg, msg)
+			if tt.expectedWarning != "" {
+				assert.Contains(t, input.View(), tt.expectedWarning)
+			} else {
+				assert.NotContains(t, input.View(), "⚠")
+			}
+		})
+	}
+}
+
+func TestExactInput_Close(t *testing.T) {
+	monitors, err := tui.LoadMonitorsFromJSON("testdata/two.json")
+	require.NoError(t, err)
+	cfg := testutils.NewTestConfig(t).Get()
+	input := tui.NewExactInput(tui.NewColorsManager(cfg))
+	input.Set(monitors[1])
+
+	cmd := input.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	require.NotNil(t, cmd)
+	assert.Equal(t, tui.CloseExactInputCommand{}, cmd())
+}
@@ -19,6 +19,8 @@ type MonitorItem struct {
 	inModeSelection      bool
 	inMirroringMode      bool
 	inColorSelection     bool
+	inAlignMode          bool
+	inExactInput         bool
 }
 
 func (m MonitorItem) Title() string {
@@ -49,6 +51,8 @@ func (m MonitorItem) MonitorDescriptionTrunc(colors *ColorsManager) string {
 func (m *MonitorItem) Unselect() {
 	m.inScaleMode = false
 	m.inModeSelection = false
+	m.inAlignMode = false
+	m.inExactInput = false
 	m.isSelectedForEditing = false
 }
 
@@ -64,10 +68,13 @@ func (m *MonitorItem) RemoveSelectionModes() {
 	m.inScaleMode = false
 	m.inModeSelection = false
 	m.inMirroringMode = false
+	m.inAlignMode = false
+	m.inExactInput = false
 }
 
 func (m MonitorItem) Editing() bool {
-	return m.isSelectedForEditing || m.inScaleMode || m.inModeSelection || m.inMirroringMode
+	return m.isSelectedForEditing || m.inScaleMode || m.inModeSelection || m.inMirroringMode || m.inAlignMode ||
+		m.inExactInput
 }
 
 func (m MonitorItem) Indicator(colors *ColorsManager) string {
@@ -91,6 +98,14 @@ func (m MonitorItem) Indicator(colors *ColorsManager) string {
 		return colors.MonitorMirroringMode().Render("[MIRRORING]")
 	}
 
+	if m.inAlignMode {
+		return colors.MonitorEditingMode().Render("[ALIGN]")
+	}
+
+	if m.inExactInput {
+		return colors.MonitorEditingMode().Render("[EXACT INPUT]")
+	}
+
 	if m.isSelectedForEditing {
 		return colors.MonitorEditingMode().Render("[EDITING]")
 	}
@@ -129,10 +144,12 @@ type MonitorListKeyMap struct {
 	vrr           key.Binding
 	toggle        key.Binding
 	mirror        key.Binding
+	align         key.Binding
+	exact         key.Binding
 }
 
-func NewMonitorListKeyMap() *MonitorListKeyMap {
-	return &MonitorListKeyMap{
+func newMonitorListKeyMap(keys actionKeys) MonitorListKeyMap {
+	return MonitorListKeyMap{
 		selectMonitor: key.NewBinding(
 			key.WithKeys("enter"),
 			key.WithHelp("enter", "edit a monitor"),
@@ -141,38 +158,16 @@ func NewMonitorListKeyMap() *MonitorListKeyMap {
 			key.WithKeys("enter"),
 			key.WithHelp("enter", "unselect a monitor"),
 		),
-		rotate: key.NewBinding(
-			key.WithKeys("r"),
-			key.WithHelp("r", "rotate"),
-		),
-		scale: key.NewBinding(
-			key.WithKeys("s"),
-			key.WithHelp("s", "scale"),
-		),
-		vrr: key.NewBinding(
-			key.WithKeys("v"),
-			key.WithHelp("v", "toggle vrr"),
-		),
-		toggle: key.NewBinding(
-			key.WithKeys("e"),
-			key.WithHelp("e", "enable/disable"),
-		),
-		changeMode: key.NewBinding(
-			key.WithKeys("m"),
-			key.WithHelp("m", "change mode"),
-		),
-		mirror: key.NewBinding(
-			key.WithKeys("i"),
-			key.WithHelp("i", "mirror"),
-		),
-		color: key.NewBinding(
-			key.WithKeys("C"),
-			key.WithHelp("C", "color"),
-		),
-		flip: key.NewBinding(
-			key.WithKeys("L"),
-			key.WithHelp("L", "flip"),
-		),
+		rotate:     keys.binding("rotate", "r", "rotate"),
+		scale:      keys.binding("scale", "s", "scale"),
+		vrr:        keys.binding("toggle_vrr", "v", "toggle vrr"),
+		toggle:     keys.binding("toggle_monitor", "e", "enable/disable"),
+		changeMode: keys.binding("change_mode", "m", "change mode"),
+		mirror:     keys.binding("mirror", "i", "mirror"),
+		color:      keys.binding("color", "C", "color"),
+		flip:       keys.binding("flip", "L", "flip"),
+		align:      keys.binding("align", "a", "align"),
+		exact:      keys.binding("exact", "x", "exact"),
 	}
 }
 
@@ -188,6 +183,8 @@ func (m MonitorListKeyMap) ShortHelp(state AppState) []key.Binding {
 			m.mirror,
 			m.color,
 			m.flip,
+			m.align,
+			m.exact,
 		}
 	}
 	return []key.Binding{
@@ -201,9 +198,9 @@ type MonitorDelegate struct {
 	colors *ColorsManager
 }
 
-func NewMonitorDelegate(colors *ColorsManager) MonitorDelegate {
+func NewMonitorDelegate(colors *ColorsManager, keys *KeyBindings) MonitorDelegate {
 	return MonitorDelegate{
-		keymap: NewMonitorListKeyMap(),
+		keymap: &keys.monitor,
 		colors: colors,
 	}
 }
@@ -269,6 +266,24 @@ func (d MonitorDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
 		item.RemoveSelectionModes()
 		item.inMirroringMode = !previous
 		sendMonitorSelection = true
+	case AlignMonitorsCommand, CloseMonitorAlignListCommand:
+		logrus.Debug("Received final align command")
+		if !item.Editing() {
+			return nil
+		}
+		previous := item.inAlignMode
+		item.RemoveSelectionModes()
+		item.inAlignMode = !previous
+		sendMonitorSelection = true
+	case CloseExactInputCommand:
+		logrus.Debug("Received close exact input command")
+		if !item.Editing() {
+			return nil
+		}
+		previous := item.inExactInput
+		item.RemoveSelectionModes()
+		item.inExactInput = !previous
+		sendMonitorSelection = true
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, d.keymap.selectMonitor), key.Matches(msg, d.keymap.unselect):
@@ -333,6 +348,24 @@ func (d MonitorDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
 			item.RemoveSelectionModes()
 			item.inModeSelection = !previous
 			sendMonitorSelection = true
+		case key.Matches(msg, d.keymap.align):
+			logrus.Debugf("List called with align")
+			if !item.Editing() {
+				return nil
+			}
+			previous := item.inAlignMode
+			item.RemoveSelectionModes()
+			item.inAlignMode = !previous
+			sendMonitorSelection = true
+		case key.Matches(msg, d.keymap.exact):
+			logrus.Debugf("List called with exact")
+			if !item.Editing() {
+				return nil
+			}
+			previous := item.inExactInput
+			item.RemoveSelectionModes()
+			item.inExactInput = !previous
+			sendMonitorSelection = true
 		case key.Matches(msg, d.keymap.color):
 			logrus.Debugf("List called with color")
 			if !item.Editing() {
@@ -355,6 +388,8 @@ func (d MonitorDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
 				ModesEditor:    item.inModeSelection,
 				MirroringMode:  item.inMirroringMode,
 				ColorSelection: item.inColorSelection,
+				AlignSelection: item.inAlignMode,
+				ExactInput:     item.inExactInput,
 			}
 		})
 	}
@@ -390,10 +425,11 @@ func (d MonitorDelegate) Render(w io.Writer, m list.Model, index int, item list.
 }
 
 type monitorListKeyMap struct {
-	Up    key.Binding
-	Down  key.Binding
-	Left  key.Binding
-	Right key.Binding
+	Up       key.Binding
+	Down     key.Binding
+	Left     key.Binding
+	Right    key.Binding
+	NextPage key.Binding
 }
 
 func (m *monitorListKeyMap) Help(state AppState) []key.Binding {
@@ -418,13 +454,13 @@ type MonitorList struct {
 	colors               *ColorsManager
 }
 
-func NewMonitorList(monitors []*MonitorSpec, colors *ColorsManager) *MonitorList {
+func NewMonitorList(monitors []*MonitorSpec, colors *ColorsManager, keys *KeyBindings) *MonitorList {
 	monitorItems := make([]list.Item, len(monitors))
 	for i, monitor := range monitors {
 		monitorItems[i] = MonitorItem{monitor: monitor}
 	}
 
-	delegate := NewMonitorDelegate(colors)
+	delegate := NewMonitorDelegate(colors, keys)
 	monitorsList := list.New(monitorItems, delegate, 0, 0)
 	monitorsList.Title = "Connected Monitors"
 	monitorsList.SetShowStatusBar(false)
@@ -435,10 +471,11 @@ func NewMonitorList(monitors []*MonitorSpec, colors *ColorsManager) *MonitorList
 	return &MonitorList{
 		L: monitorsList,
 		keys: &monitorListKeyMap{
-			Up:    rootKeyMap.Up,
-			Down:  rootKeyMap.Down,
-			Left:  rootKeyMap.Left,
-			Right: rootKeyMap.Right,
+			Up:       keys.root.Up,
+			Down:     keys.root.Down,
+			Left:     keys.root.Left,
+			Right:    keys.root.Right,
+			NextPage: keys.root.NextPage,
 		},
 		help:     NewCustomHelp(colors),
 		delegate: delegate,
@@ -457,13 +494,15 @@ func (c *MonitorList) Update(msg tea.Msg) tea.Cmd {
 		c.selectedMonitorIndex = -1
 	case StateChanged:
 		c.state = msg.State
+	case SelectMonitorCommand:
+		return c.selectForEditing(msg.ListIndex)
 	case tea.KeyMsg:
 		switch {
-		case key.Matches(msg, rootKeyMap.Down),
-			key.Matches(msg, rootKeyMap.Up),
-			key.Matches(msg, rootKeyMap.Left),
-			key.Matches(msg, rootKeyMap.Right),
-			key.Matches(msg, rootKeyMap.NextPage):
+		case key.Matches(msg, c.keys.Down),
+			key.Matches(msg, c.keys.Up),
+			key.Matches(msg, c.keys.Left),
+			key.Matches(msg, c.keys.Right),
+			key.Matches(msg, c.keys.NextPage):
 			if c.state.Panning {
 				logrus.Debug("In panning mode, exiting")
 				return nil
@@ -482,6 +521,39 @@ func (c *MonitorList) Update(msg tea.Msg) tea.Cmd {
 	return cmd
 }
 
+// selectForEditing moves the editing selection to the monitor at listIndex, the monitor edited
+// so far is unselected first
+func (c *MonitorList) selectForEditing(listIndex int) tea.Cmd {
+	if listIndex < 0 || listIndex >= len(c.L.Items()) {
+		return nil
+	}
+	if c.monitorSelected && c.L.Index() == listIndex {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	if current, ok := c.L.SelectedItem().(MonitorItem); ok && current.Editing() {
+		current.Unselect()
+		current.RemoveSelectionModes()
+		cmds = append(cmds, c.L.SetItem(c.L.Index(), current))
+	}
+
+	c.L.Select(listIndex)
+	item, ok := c.L.SelectedItem().(MonitorItem)
+	if !ok {
+		return tea.Batch(cmds...)
+	}
+	item.isSelectedForEditing = true
+	cmds = append(cmds, c.L.SetItem(listIndex, item), func() tea.Msg {
+		return MonitorBeingEdited{
+			ListIndex: listIndex,
+			MonitorID: *item.monitor.ID,
+		}
+	})
+
+	return tea.Batch(cmds...)
+}
+
 func (c *MonitorList) processArrows(msg tea.KeyMsg) tea.Cmd {
 	logrus.Debug("Processing arrows for list updates")
 	if !c.state.EditingMonitor || c.selectedMonitorIndex == -1 {
@@ -496,13 +568,13 @@ func (c *MonitorList) handleMove(msg tea.KeyMsg) tea.Cmd {
 	stepY := DeltaNone
 
 	switch {
-	case key.Matches(msg, rootKeyMap.Up):
+	case key.Matches(msg, c.keys.Up):
 		stepY = DeltaLess
-	case key.Matches(msg, rootKeyMap.Down):
+	case key.Matches(msg, c.keys.Down):
 		stepY = DeltaMore
-	case key.Matches(msg, rootKeyMap.Left):
+	case key.Matches(msg, c.keys.Left):
 		stepX = DeltaLess
-	case key.Matches(msg, rootKeyMap.Right):
+	case key.Matches(msg, c.keys.Right):
 		stepX = DeltaMore
 	}
 
@@ -8,6 +8,7 @@ import (
 	"github.com/fiffeek/hyprdynamicmonitors/internal/tui"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMonitorList_Update(t *testing.T) {
@@ -108,7 +109,7 @@ func TestMonitorList_Update(t *testing.T) {
 			cfg := testutils.NewTestConfig(t).Get()
 			colors := tui.NewColorsManager(cfg)
 			monitors := []*tui.MonitorSpec{monitor}
-			monitorList := tui.NewMonitorList(monitors, colors)
+			monitorList := tui.NewMonitorList(monitors, colors, tui.NewKeyBindings(cfg))
 
 			if tt.setupState != nil {
 				monitorList.Update(tui.StateChanged{State: *tt.setupState})
@@ -224,7 +225,7 @@ func TestMonitorList_DelegateUpdate(t *testing.T) {
 			cfg := testutils.NewTestConfig(t).Get()
 			colors := tui.NewColorsManager(cfg)
 			monitors := []*tui.MonitorSpec{monitor}
-			monitorList := tui.NewMonitorList(monitors, colors)
+			monitorList := tui.NewMonitorList(monitors, colors, tui.NewKeyBindings(cfg))
 
 			if tt.setupEditing {
 				monitorList.Update(tea.KeyMsg{Type: tea.KeyEnter})
@@ -238,3 +239,46 @@ func TestMonitorList_DelegateUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestMonitorList_SelectMonitorCommand(t *testing.T) {
+	monitors := []*tui.MonitorSpec{
+		{Name: "eDP-1", ID: utils.IntPtr(1)},
+		{Name: "DP-1", ID: utils.IntPtr(2)},
+	}
+	cfg := testutils.NewTestConfig(t).Get()
+	monitorList := tui.NewMonitorList(monitors, tui.NewColorsManager(cfg), tui.NewKeyBindings(cfg))
+
+	edited := func(cmd tea.Cmd) []tui.MonitorBeingEdited {
+		found := []tui.MonitorBeingEdited{}
+		msg := cmd()
+		batch, ok := msg.(tea.BatchMsg)
+		if !ok {
+			batch = tea.BatchMsg{func() tea.Msg { return msg }}
+		}
+		for _, c := range batch {
+			if c == nil {
+				continue
+			}
+			if msg, ok := c().(tui.MonitorBeingEdited); ok {
+				found = append(found, msg)
+			}
+		}
+		return found
+	}
+
+	cmd := monitorList.Update(tui.SelectMonitorCommand{ListIndex: 1})
+	require.NotNil(t, cmd)
+	assert.Equal(t, []tui.MonitorBeingEdited{{ListIndex: 1, MonitorID: 2}}, edited(cmd))
+	assert.Equal(t, 1, monitorList.L.Index())
+	monitorList.Update(tui.MonitorBeingEdited{ListIndex: 1, MonitorID: 2})
+
+	assert.Nil(t, monitorList.Update(tui.SelectMonitorCommand{ListIndex: 1}), "already edited")
+	assert.Nil(t, monitorList.Update(tui.SelectMonitorCommand{ListIndex: 5}), "out of range")
+
+	// switching the monitor unselects the edited one
+	cmd = monitorList.Update(tui.SelectMonitorCommand{ListIndex: 0})
+	assert.Equal(t, []tui.MonitorBeingEdited{{ListIndex: 0, MonitorID: 1}}, edited(cmd))
+	previous, ok := monitorList.L.Items()[1].(tui.MonitorItem)
+	require.True(t, ok)
+	assert.False(t, previous.Editing())
+}
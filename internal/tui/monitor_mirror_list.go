@@ -20,6 +20,7 @@ type MirrorList struct {
 	monitors []*MonitorSpec
 	help     *CustomHelp
 	colors   *ColorsManager
+	keys     *keyMap
 }
 
 func (m MirrorItem) FilterValue() string {
@@ -32,11 +33,13 @@ func (m MirrorItem) View() string {
 
 type MirrorDelegate struct {
 	colors *ColorsManager
+	keys   *keyMap
 }
 
-func NewMirrorDelegate(colors *ColorsManager) MirrorDelegate {
+func NewMirrorDelegate(colors *ColorsManager, keys *KeyBindings) MirrorDelegate {
 	return MirrorDelegate{
 		colors: colors,
+		keys:   &keys.root,
 	}
 }
 
@@ -58,11 +61,11 @@ func (d MirrorDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
 	// nolint:gocritic
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "up", "k", "down", "j":
+		switch {
+		case key.Matches(msg, d.keys.Up, d.keys.Down):
 			logrus.Debugf("Setting mode to: %s", item.mirrorName)
 			cmds = append(cmds, ChangeMirrorPreviewCmd(item.mirrorName))
-		case "enter":
+		case msg.String() == "enter":
 			logrus.Debugf("Setting final to: %s", item.mirrorName)
 			cmds = append(cmds, ChangeMirrorCmd(item.mirrorName))
 		}
@@ -91,20 +94,22 @@ func (d MirrorDelegate) Render(w io.Writer, m list.Model, index int, item list.I
 	fmt.Fprintf(w, "%s", content)
 }
 
-func NewMirrorList(monitors []*MonitorSpec, colors *ColorsManager) *MirrorList {
+func NewMirrorList(monitors []*MonitorSpec, colors *ColorsManager, keys *KeyBindings) *MirrorList {
 	modesItems := []list.Item{}
-	delegate := NewMirrorDelegate(colors)
+	delegate := NewMirrorDelegate(colors, keys)
 	modesList := list.New(modesItems, delegate, 0, 0)
 	modesList.SetShowStatusBar(false)
 	modesList.SetFilteringEnabled(false)
 	modesList.SetShowHelp(false)
 	modesList.SetShowTitle(false)
+	modesList.KeyMap = keys.listKeyMap()
 
 	return &MirrorList{
 		L:        modesList,
 		monitors: monitors,
 		help:     NewCustomHelp(colors),
 		colors:   colors,
+		keys:     &keys.root,
 	}
 }
 
@@ -166,7 +171,7 @@ func (m *MirrorList) View() string {
 	logrus.Debugf("Items: %v", m.L.Items())
 
 	help := m.help.ShortHelpView([]key.Binding{
-		rootKeyMap.Up, rootKeyMap.Down, rootKeyMap.Enter, rootKeyMap.Back,
+		m.keys.Up, m.keys.Down, m.keys.Enter, m.keys.Back,
 	})
 	availHeight -= lipgloss.Height(help)
 
@@ -56,7 +56,7 @@ func TestMirrorList_Update(t *testing.T) {
 			}
 			cfg := testutils.NewTestConfig(t).Get()
 			colors := tui.NewColorsManager(cfg)
-			mirrorList := tui.NewMirrorList(monitors, colors)
+			mirrorList := tui.NewMirrorList(monitors, colors, tui.NewKeyBindings(cfg))
 
 			if tt.setupItems {
 				mirrorList.SetItems(monitor)
@@ -21,6 +21,7 @@ type MonitorModeList struct {
 	monitors []*MonitorSpec
 	help     *CustomHelp
 	colors   *ColorsManager
+	keys     *keyMap
 }
 
 func (m ModeItem) FilterValue() string {
@@ -90,11 +91,13 @@ func (m ModeItem) getResolutionSuffix(width, height int) string {
 
 type ModeDelegate struct {
 	colors *ColorsManager
+	keys   *keyMap
 }
 
-func NewModeDelegate(colors *ColorsManager) ModeDelegate {
+func NewModeDelegate(colors *ColorsManager, keys *KeyBindings) ModeDelegate {
 	return ModeDelegate{
 		colors: colors,
+		keys:   &keys.root,
 	}
 }
 
@@ -116,11 +119,11 @@ func (d ModeDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
 	// nolint:gocritic
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "up", "k", "down", "j":
+		switch {
+		case key.Matches(msg, d.keys.Up, d.keys.Down):
 			logrus.Debugf("Setting mode to: %s", item.mode)
 			cmds = append(cmds, ChangeModePreviewCmd(item.mode))
-		case "enter":
+		case msg.String() == "enter":
 			logrus.Debugf("Setting final to: %s", item.mode)
 			cmds = append(cmds, ChangeModeCmd(item.mode))
 		}
@@ -148,20 +151,22 @@ func (d ModeDelegate) Render(w io.Writer, m list.Model, index int, item list.Ite
 	fmt.Fprintf(w, "%s", title)
 }
 
-func NewMonitorModeList(monitors []*MonitorSpec, colors *ColorsManager) *MonitorModeList {
+func NewMonitorModeList(monitors []*MonitorSpec, colors *ColorsManager, keys *KeyBindings) *MonitorModeList {
 	modesItems := []list.Item{}
-	delegate := NewModeDelegate(colors)
+	delegate := NewModeDelegate(colors, keys)
 	modesList := list.New(modesItems, delegate, 0, 0)
 	modesList.SetShowStatusBar(false)
 	modesList.SetFilteringEnabled(false)
 	modesList.SetShowHelp(false)
 	modesList.SetShowTitle(false)
+	modesList.KeyMap = keys.listKeyMap()
 
 	return &MonitorModeList{
 		L:        modesList,
 		monitors: monitors,
 		help:     NewCustomHelp(colors),
 		colors:   colors,
+		keys:     &keys.root,
 	}
 }
 
@@ -214,7 +219,7 @@ func (m *MonitorModeList) View() string {
 	logrus.Debugf("Items: %v", m.L.Items())
 
 	help := m.help.ShortHelpView([]key.Binding{
-		rootKeyMap.Up, rootKeyMap.Down, rootKeyMap.Enter, rootKeyMap.Back,
+		m.keys.Up, m.keys.Down, m.keys.Enter, m.keys.Back,
 	})
 	availHeight -= lipgloss.Height(help)
 
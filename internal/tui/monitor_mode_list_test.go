@@ -55,7 +55,7 @@ func TestMonitorModeList_Update(t *testing.T) {
 			cfg := testutils.NewTestConfig(t).Get()
 			colors := tui.NewColorsManager(cfg)
 			monitors := []*tui.MonitorSpec{monitor}
-			modeList := tui.NewMonitorModeList(monitors, colors)
+			modeList := tui.NewMonitorModeList(monitors, colors, tui.NewKeyBindings(cfg))
 
 			if tt.setupItems {
 				modeList.SetItems(monitor)
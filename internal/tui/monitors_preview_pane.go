@@ -38,9 +38,32 @@ type MonitorsPreviewPane struct {
 	snapGridY             *int
 	zoomStep              float64
 	colors                *ColorsManager
+	keys                  *keyMap
+	title                 string
+	// where the pane content and its grid were last drawn, used to map mouse events
+	originX, originY      int
+	gridTop               int
+	gridWidth, gridHeight int
+	pickerOpen            bool
+	drag                  *monitorDrag
+	panDrag               *panDrag
 }
 
-func NewMonitorsPreviewPane(monitors []*MonitorSpec, colors *ColorsManager) *MonitorsPreviewPane {
+// monitorDrag is a monitor being moved with the left mouse button, the offset keeps the
+// grabbed point of the monitor under the cursor
+type monitorDrag struct {
+	index            int
+	offsetX, offsetY int
+	moved            bool
+}
+
+// panDrag is the view being moved with the middle mouse button
+type panDrag struct {
+	column, row int
+	panX, panY  int
+}
+
+func NewMonitorsPreviewPane(monitors []*MonitorSpec, colors *ColorsManager, keys *KeyBindings) *MonitorsPreviewPane {
 	pane := &MonitorsPreviewPane{
 		monitors:              monitors,
 		selectedIndex:         -1,
@@ -57,6 +80,8 @@ func NewMonitorsPreviewPane(monitors []*MonitorSpec, colors *ColorsManager) *Mon
 		snapping:              true,
 		zoomStep:              1.1,
 		colors:                colors,
+		keys:                  &keys.root,
+		title:                 "Monitor Preview",
 	}
 
 	pane.autoFitMonitors()
@@ -166,48 +191,58 @@ func (p *MonitorsPreviewPane) Update(msg tea.Msg) tea.Cmd {
 		if p.followMonitor {
 			p.panToMonitorCenter()
 		}
+	case DragMonitorCommand:
+		p.snapGridX = nil
+		p.snapGridY = nil
 	case MonitorBeingEdited:
 		p.selectedIndex = msg.ListIndex
-		p.panToMonitorCenter()
+		// keep the view still under the cursor when the selection comes from a click
+		if p.drag == nil {
+			p.panToMonitorCenter()
+		}
 	case MonitorUnselected:
 		p.selectedIndex = -1
 	case StateChanged:
 		p.panning = msg.State.IsPanning()
 		p.snapping = msg.State.Snapping
 		p.followMonitor = msg.State.MonitorFollowMode
+		p.pickerOpen = msg.State.MirrorSelection || msg.State.ModeSelection || msg.State.Scaling ||
+			msg.State.ColorSelection || msg.State.AlignSelection || msg.State.ExactInput
 		if msg.State.MirrorSelection || msg.State.ModeSelection || msg.State.Scaling {
 			p.snapGridX = nil
 			p.snapGridY = nil
 		}
+	case tea.MouseMsg:
+		return p.handleMouse(msg)
 
 	case tea.KeyMsg:
 		switch {
-		case key.Matches(msg, rootKeyMap.Up):
+		case key.Matches(msg, p.keys.Up):
 			if p.panning {
 				p.panY -= p.panStep
 			}
-		case key.Matches(msg, rootKeyMap.Down):
+		case key.Matches(msg, p.keys.Down):
 			if p.panning {
 				p.panY += p.panStep
 			}
-		case key.Matches(msg, rootKeyMap.Left):
+		case key.Matches(msg, p.keys.Left):
 			if p.panning {
 				p.panX -= p.panStep
 			}
-		case key.Matches(msg, rootKeyMap.Right):
+		case key.Matches(msg, p.keys.Right):
 			if p.panning {
 				p.panX += p.panStep
 			}
-		case key.Matches(msg, rootKeyMap.Center):
+		case key.Matches(msg, p.keys.Center):
 			p.panX = 0
 			p.panY = 0
-		case key.Matches(msg, rootKeyMap.ZoomIn):
+		case key.Matches(msg, p.keys.ZoomIn):
 			p.ZoomIn()
-		case key.Matches(msg, rootKeyMap.ZoomOut):
+		case key.Matches(msg, p.keys.ZoomOut):
 			p.ZoomOut()
-		case key.Matches(msg, rootKeyMap.ResetZoom):
+		case key.Matches(msg, p.keys.ResetZoom):
 			p.ResetZoom()
-		case key.Matches(msg, rootKeyMap.FitMonitors):
+		case key.Matches(msg, p.keys.FitMonitors):
 			p.autoFitMonitors()
 		}
 	}
@@ -215,6 +250,168 @@ func (p *MonitorsPreviewPane) Update(msg tea.Msg) tea.Cmd {
 	return nil
 }
 
+// handleMouse selects and drags monitors with the left button, pans with the middle one (or the
+// left one in panning mode) and zooms with the wheel around the cursor
+func (p *MonitorsPreviewPane) handleMouse(msg tea.MouseMsg) tea.Cmd {
+	x, y, inside := p.cellToLayout(msg.X, msg.Y)
+
+	switch msg.Action {
+	case tea.MouseActionRelease:
+		p.drag = nil
+		p.panDrag = nil
+	case tea.MouseActionPress:
+		if !inside {
+			return nil
+		}
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			p.zoomAround(msg, x, y, p.ZoomIn)
+		case tea.MouseButtonWheelDown:
+			p.zoomAround(msg, x, y, p.ZoomOut)
+		case tea.MouseButtonMiddle:
+			p.startPan(msg.X, msg.Y)
+		case tea.MouseButtonLeft:
+			if p.panning {
+				p.startPan(msg.X, msg.Y)
+				return nil
+			}
+			return p.startDrag(x, y)
+		}
+	case tea.MouseActionMotion:
+		switch {
+		case p.panDrag != nil:
+			p.panTo(msg.X, msg.Y)
+		case p.drag != nil && msg.Button == tea.MouseButtonLeft:
+			return p.dragTo(x, y)
+		}
+	}
+
+	return nil
+}
+
+// cellToLayout maps a terminal cell to layout coordinates, the reverse of DrawMonitor,
+// the bool is false when the cell is outside of the grid
+func (p *MonitorsPreviewPane) cellToLayout(column, row int) (int, int, bool) {
+	if p.gridWidth <= 0 || p.gridHeight <= 0 {
+		return 0, 0, false
+	}
+
+	gridX := column - p.originX
+	gridY := row - p.originY - p.gridTop
+	inside := gridX >= 0 && gridX < p.gridWidth && gridY >= 0 && gridY < p.gridHeight
+
+	scaleX, scaleY := p.gridScale()
+	x := p.panX + int((float64(gridX-p.gridWidth/2)+0.5)/scaleX)
+	y := p.panY + int((float64(gridY-p.gridHeight/2)+0.5)/scaleY)
+	return x, y, inside
+}
+
+func (p *MonitorsPreviewPane) gridScale() (float64, float64) {
+	scaleX := float64(p.gridWidth) / float64(p.virtualWidth)
+	scaleY := float64(p.gridHeight) / float64(p.virtualHeight) * p.AspectRatio()
+	return scaleX, scaleY
+}
+
+// monitorAt returns the index of the monitor drawn at x, y, the selected monitor is drawn on top
+// so it wins, -1 when there is none
+func (p *MonitorsPreviewPane) monitorAt(x, y int) int {
+	contains := func(monitor *MonitorSpec) bool {
+		if monitor.Disabled {
+			return false
+		}
+		width, height := p.visualSize(monitor)
+		return x >= monitor.X && x < monitor.X+width && y >= monitor.Y && y < monitor.Y+height
+	}
+
+	if p.selectedIndex >= 0 && p.selectedIndex < len(p.monitors) && contains(p.monitors[p.selectedIndex]) {
+		return p.selectedIndex
+	}
+	for i := len(p.monitors) - 1; i >= 0; i-- {
+		if contains(p.monitors[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p *MonitorsPreviewPane) visualSize(monitor *MonitorSpec) (int, int) {
+	scaledWidth := int(float64(monitor.Width) / monitor.Scale)
+	scaledHeight := int(float64(monitor.Height) / monitor.Scale)
+	if monitor.NeedsDimensionsSwap() {
+		return scaledHeight, scaledWidth
+	}
+	return scaledWidth, scaledHeight
+}
+
+// startDrag selects the clicked monitor for editing and grabs it, pickers keep the current
+// selection
+func (p *MonitorsPreviewPane) startDrag(x, y int) tea.Cmd {
+	if p.pickerOpen {
+		return nil
+	}
+	index := p.monitorAt(x, y)
+	if index == -1 {
+		return nil
+	}
+
+	monitor := p.monitors[index]
+	p.drag = &monitorDrag{index: index, offsetX: x - monitor.X, offsetY: y - monitor.Y}
+	if index == p.selectedIndex {
+		return nil
+	}
+	return selectMonitorCmd(index)
+}
+
+func (p *MonitorsPreviewPane) dragTo(x, y int) tea.Cmd {
+	monitor := p.monitors[p.drag.index]
+	newDrag := !p.drag.moved
+	p.drag.moved = true
+	return dragMonitorCmd(*monitor.ID, x-p.drag.offsetX, y-p.drag.offsetY, newDrag)
+}
+
+func (p *MonitorsPreviewPane) startPan(column, row int) {
+	p.panDrag = &panDrag{column: column, row: row, panX: p.panX, panY: p.panY}
+}
+
+func (p *MonitorsPreviewPane) panTo(column, row int) {
+	scaleX, scaleY := p.gridScale()
+	p.panX = p.panDrag.panX - int(float64(column-p.panDrag.column)/scaleX)
+	p.panY = p.panDrag.panY - int(float64(row-p.panDrag.row)/scaleY)
+}
+
+// zoomAround zooms keeping the layout point x, y under the cursor
+func (p *MonitorsPreviewPane) zoomAround(msg tea.MouseMsg, x, y int, zoom func()) {
+	zoom()
+	scaleX, scaleY := p.gridScale()
+	gridX := msg.X - p.originX
+	gridY := msg.Y - p.originY - p.gridTop
+	p.panX = x - int((float64(gridX-p.gridWidth/2)+0.5)/scaleX)
+	p.panY = y - int((float64(gridY-p.gridHeight/2)+0.5)/scaleY)
+}
+
+// SetMonitors replaces the previewed monitors and fits the view to them
+func (p *MonitorsPreviewPane) SetMonitors(monitors []*MonitorSpec) {
+	p.monitors = monitors
+	p.selectedIndex = -1
+	p.snapGridX = nil
+	p.snapGridY = nil
+	p.autoFitMonitors()
+}
+
+// SetOrigin is the terminal cell where the pane content starts, mouse events are relative to it
+func (p *MonitorsPreviewPane) SetOrigin(x, y int) {
+	p.originX = x
+	p.originY = y
+}
+
+func (p *MonitorsPreviewPane) SetTitle(title string) {
+	p.title = title
+}
+
+func (p *MonitorsPreviewPane) SetSnapping(snapping bool) {
+	p.snapping = snapping
+}
+
 func (p *MonitorsPreviewPane) SetHeight(h int) {
 	p.height = h
 }
@@ -234,7 +431,7 @@ func (p *MonitorsPreviewPane) Render(availableWidth, availableHeight int) string
 		return "No monitors detected"
 	}
 
-	title := p.colors.TitleStyle().Render("Monitor Preview")
+	title := p.colors.TitleStyle().Render(p.title)
 	legend := p.renderLegend()
 
 	titleHeight := lipgloss.Height(title)
@@ -242,7 +439,10 @@ func (p *MonitorsPreviewPane) Render(availableWidth, availableHeight int) string
 	titleWidth := lipgloss.Width(title)
 
 	gridHeight := availableHeight - legendHeight - titleHeight
-	grid := p.renderGrid(availableWidth-4, gridHeight)
+	p.gridTop = titleHeight
+	p.gridWidth = availableWidth - 4
+	p.gridHeight = gridHeight
+	grid := p.renderGrid(p.gridWidth, p.gridHeight)
 
 	scaleInfo := p.ScaleInfo()
 	scaleInfoWidth := lipgloss.Width(scaleInfo)
@@ -606,6 +806,10 @@ func (p *MonitorsPreviewPane) ZoomOut() {
 	}
 }
 
+func (p *MonitorsPreviewPane) GetMonitors() []*MonitorSpec {
+	return p.monitors
+}
+
 func (p *MonitorsPreviewPane) GetSelectedIndex() int {
 	return p.selectedIndex
 }
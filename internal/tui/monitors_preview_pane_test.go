@@ -6,7 +6,9 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/testutils"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/tui"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMonitorsPreviewPane_Update(t *testing.T) {
@@ -95,7 +97,7 @@ func TestMonitorsPreviewPane_Update(t *testing.T) {
 			}
 			cfg := testutils.NewTestConfig(t).Get()
 			colors := tui.NewColorsManager(cfg)
-			pane := tui.NewMonitorsPreviewPane(monitors, colors)
+			pane := tui.NewMonitorsPreviewPane(monitors, colors, tui.NewKeyBindings(cfg))
 
 			if tt.setupMsg != nil {
 				pane.Update(tt.setupMsg())
@@ -203,7 +205,7 @@ func TestMonitorsPreviewPane_KeyboardControls(t *testing.T) {
 			}
 			cfg := testutils.NewTestConfig(t).Get()
 			colors := tui.NewColorsManager(cfg)
-			pane := tui.NewMonitorsPreviewPane(monitors, colors)
+			pane := tui.NewMonitorsPreviewPane(monitors, colors, tui.NewKeyBindings(cfg))
 
 			if tt.setupCmd != nil {
 				pane.Update(tt.setupCmd())
@@ -218,3 +220,94 @@ func TestMonitorsPreviewPane_KeyboardControls(t *testing.T) {
 		})
 	}
 }
+
+func TestMonitorsPreviewPane_Mouse(t *testing.T) {
+	newPane := func(t *testing.T) (*tui.MonitorsPreviewPane, []*tui.MonitorSpec) {
+		monitors := []*tui.MonitorSpec{
+			{ID: utils.JustPtr(0), Name: "eDP-1", X: 0, Y: 0, Width: 1920, Height: 1080, Scale: 1.0},
+			{ID: utils.JustPtr(1), Name: "HDMI-1", X: 1920, Y: 0, Width: 1920, Height: 1080, Scale: 1.0},
+		}
+		cfg := testutils.NewTestConfig(t).Get()
+		pane := tui.NewMonitorsPreviewPane(monitors, tui.NewColorsManager(cfg), tui.NewKeyBindings(cfg))
+		pane.SetWidth(104)
+		pane.SetHeight(30)
+		pane.SetOrigin(10, 5)
+		// the grid geometry is known once rendered
+		pane.View()
+		return pane, monitors
+	}
+	// the view is centered between the monitors, 100 columns wide grid
+	leftMonitor := tea.MouseMsg{X: 10 + 40, Y: 5 + 14, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress}
+	rightMonitor := tea.MouseMsg{X: 10 + 60, Y: 5 + 14, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress}
+
+	t.Run("click selects the monitor under the cursor", func(t *testing.T) {
+		pane, _ := newPane(t)
+		cmd := pane.Update(rightMonitor)
+		require.NotNil(t, cmd)
+		assert.Equal(t, tui.SelectMonitorCommand{ListIndex: 1}, cmd())
+
+		cmd = pane.Update(leftMonitor)
+		require.NotNil(t, cmd)
+		assert.Equal(t, tui.SelectMonitorCommand{ListIndex: 0}, cmd())
+	})
+
+	t.Run("click outside of the monitors does nothing", func(t *testing.T) {
+		pane, _ := newPane(t)
+		assert.Nil(t, pane.Update(tea.MouseMsg{X: 11, Y: 7, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress}))
+		assert.Nil(t, pane.Update(tea.MouseMsg{X: 0, Y: 0, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress}))
+	})
+
+	t.Run("drag moves the grabbed monitor", func(t *testing.T) {
+		pane, monitors := newPane(t)
+		pane.Update(tui.MonitorBeingEdited{ListIndex: 1, MonitorID: 1})
+		assert.Nil(t, pane.Update(rightMonitor), "the monitor is already selected")
+
+		motion := rightMonitor
+		motion.Action = tea.MouseActionMotion
+		motion.X += 10
+		cmd := pane.Update(motion)
+		require.NotNil(t, cmd)
+		drag, ok := cmd().(tui.DragMonitorCommand)
+		require.True(t, ok)
+		assert.True(t, drag.NewDrag)
+		assert.Equal(t, 1, drag.MonitorID)
+		assert.Greater(t, drag.X, monitors[1].X+500)
+		assert.Equal(t, monitors[1].Y, drag.Y)
+
+		motion.X += 10
+		drag, ok = pane.Update(motion)().(tui.DragMonitorCommand)
+		require.True(t, ok)
+		assert.False(t, drag.NewDrag)
+
+		motion.Action = tea.MouseActionRelease
+		assert.Nil(t, pane.Update(motion))
+		motion.Action = tea.MouseActionMotion
+		assert.Nil(t, pane.Update(motion), "the drag ends on release")
+	})
+
+	t.Run("drag is disabled while a picker is open", func(t *testing.T) {
+		pane, _ := newPane(t)
+		pane.Update(tui.StateChangedCmd(tui.AppState{ModeSelection: true})())
+		assert.Nil(t, pane.Update(rightMonitor))
+	})
+
+	t.Run("wheel zooms around the cursor", func(t *testing.T) {
+		pane, _ := newPane(t)
+		width := pane.GetVirtualWidth()
+		pane.Update(tea.MouseMsg{X: 10 + 50, Y: 5 + 14, Button: tea.MouseButtonWheelUp, Action: tea.MouseActionPress})
+		assert.Less(t, pane.GetVirtualWidth(), width)
+		pane.Update(tea.MouseMsg{X: 10 + 50, Y: 5 + 14, Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress})
+		pane.Update(tea.MouseMsg{X: 10 + 50, Y: 5 + 14, Button: tea.MouseButtonWheelDown, Action: tea.MouseActionPress})
+		assert.Greater(t, pane.GetVirtualWidth(), width)
+	})
+
+	t.Run("middle drag pans the view", func(t *testing.T) {
+		pane, _ := newPane(t)
+		panX, panY := pane.GetPanX(), pane.GetPanY()
+		pane.Update(tea.MouseMsg{X: 60, Y: 19, Button: tea.MouseButtonMiddle, Action: tea.MouseActionPress})
+		pane.Update(tea.MouseMsg{X: 50, Y: 17, Button: tea.MouseButtonMiddle, Action: tea.MouseActionMotion})
+		assert.Greater(t, pane.GetPanX(), panX)
+		assert.Greater(t, pane.GetPanY(), panY)
+		pane.Update(tea.MouseMsg{X: 50, Y: 17, Button: tea.MouseButtonMiddle, Action: tea.MouseActionRelease})
+	})
+}
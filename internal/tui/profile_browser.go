@@ -0,0 +1,597 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/generators"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/layout"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/matchers"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/power"
+	"github.com/sirupsen/logrus"
+)
+
+var ErrFallbackProfileAction = errors.New("the fallback profile can only be opened or applied")
+
+var ErrFallbackConditions = errors.New("the fallback profile has no conditions")
+
+type ProfileBrowserItem struct {
+	profile   *matchers.MatchedProfile
+	score     int
+	fullScore int
+	active    bool
+	fallback  bool
+}
+
+func (p ProfileBrowserItem) FilterValue() string {
+	return p.profile.Profile.Name
+}
+
+func (p ProfileBrowserItem) Name() string {
+	if p.fallback {
+		return "(fallback)"
+	}
+	return p.profile.Profile.Name
+}
+
+func (p ProfileBrowserItem) Score() (int, int) {
+	return p.score, p.fullScore
+}
+
+func (p ProfileBrowserItem) Active() bool {
+	return p.active
+}
+
+// Matches mirrors the matcher, a profile is a candidate only when every condition is met
+func (p ProfileBrowserItem) Matches() bool {
+	return !p.fallback && p.fullScore > 0 && p.score == p.fullScore
+}
+
+func (p ProfileBrowserItem) Status(colors *ColorsManager) string {
+	switch {
+	case p.active:
+		return colors.SuccessStyle().Render("● active")
+	case p.fallback:
+		return colors.MutedStyle().Render("used when nothing matches")
+	case p.Matches():
+		return colors.InfoStyle().Render("matches")
+	default:
+		return colors.MutedStyle().Render("no match")
+	}
+}
+
+func (p ProfileBrowserItem) Conditions() string {
+	if p.fallback {
+		return "no conditions"
+	}
+	if summary := p.profile.Profile.Conditions.Summary(); summary != "" {
+		return summary
+	}
+	return "no conditions"
+}
+
+type ProfileBrowserDelegate struct {
+	colors *ColorsManager
+}
+
+func NewProfileBrowserDelegate(colors *ColorsManager) ProfileBrowserDelegate {
+	return ProfileBrowserDelegate{
+		colors: colors,
+	}
+}
+
+func (d ProfileBrowserDelegate) Height() int {
+	return 2
+}
+
+func (d ProfileBrowserDelegate) Spacing() int {
+	return 1
+}
+
+func (d ProfileBrowserDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
+	return nil
+}
+
+func (d ProfileBrowserDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	profileItem, ok := item.(ProfileBrowserItem)
+	if !ok {
+		return
+	}
+
+	style := d.colors.ListItemUnselected()
+	prefix := "  "
+	if index == m.Index() {
+		style = d.colors.ListItemSelected()
+		prefix = "► "
+	}
+
+	score := ""
+	if !profileItem.fallback {
+		score = d.colors.MutedStyle().Render(fmt.Sprintf(" [%d/%d]", profileItem.score, profileItem.fullScore))
+	}
+	title := style.Render(prefix+profileItem.Name()) + score + " " + profileItem.Status(d.colors)
+	conditions := "  " + d.colors.ListItemSubtitle().Render(profileItem.Conditions())
+
+	fmt.Fprintf(w, "%s\n%s", title, conditions)
+}
+
+type profileBrowserKeyMap struct {
+	Open       key.Binding
+	Duplicate  key.Binding
+	Rename     key.Binding
+	Delete     key.Binding
+	Apply      key.Binding
+	Conditions key.Binding
+	Submit     key.Binding
+	Back       key.Binding
+}
+
+func (p *profileBrowserKeyMap) Help() []key.Binding {
+	return []key.Binding{
+		p.Open,
+		p.Duplicate,
+		p.Rename,
+		p.Delete,
+		p.Apply,
+		p.Conditions,
+	}
+}
+
+func newProfileBrowserKeyMap(keys actionKeys) profileBrowserKeyMap {
+	return profileBrowserKeyMap{
+		Open:       keys.binding("open_profile", "enter/e", "open in $EDITOR"),
+		Duplicate:  keys.binding("duplicate", "d", "duplicate"),
+		Rename:     keys.binding("rename", "r", "rename"),
+		Delete:     keys.binding("delete", "D", "delete"),
+		Apply:      keys.binding("apply_profile", "A", "apply (ephemeral)"),
+		Conditions: keys.binding("conditions", "c", "conditions"),
+		Submit: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "confirm"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "return/back"),
+		),
+	}
+}
+
+func (p *profileBrowserKeyMap) InputHelp() []key.Binding {
+	return []key.Binding{
+		p.Submit,
+		p.Back,
+	}
+}
+
+// ProfileBrowser lists every profile with its score against the current monitors and power
+// state, the selected profile is rendered and previewed as the layout it would produce
+type ProfileBrowser struct {
+	cfg        *config.Config
+	matcher    *matchers.Matcher
+	generator  *generators.ConfigGenerator
+	monitors   []*MonitorSpec
+	powerState power.PowerState
+	lidState   power.LidState
+	L          list.Model
+	preview    *MonitorsPreviewPane
+	previewErr error
+	previewed  string
+	pulled     bool
+	nameInput  textinput.Model
+	// nameAction is the action waiting for the new profile name, nil when not typing
+	nameAction *ProfileAction
+	// conditions is the open conditions form, nil when closed
+	conditions *ProfileConditionsForm
+	keymap     *profileBrowserKeyMap
+	help       *CustomHelp
+	height     int
+	width      int
+	colors     *ColorsManager
+}
+
+func NewProfileBrowser(cfg *config.Config, matcher *matchers.Matcher, generator *generators.ConfigGenerator,
+	monitors []*MonitorSpec, powerState power.PowerState, lidState power.LidState, colors *ColorsManager,
+	keys *KeyBindings,
+) *ProfileBrowser {
+	profilesList := list.New([]list.Item{}, NewProfileBrowserDelegate(colors), 0, 0)
+	profilesList.SetShowStatusBar(false)
+	profilesList.SetFilteringEnabled(false)
+	profilesList.SetShowHelp(false)
+	profilesList.SetShowTitle(false)
+	profilesList.KeyMap = keys.listKeyMap()
+
+	preview := NewMonitorsPreviewPane([]*MonitorSpec{}, colors, keys)
+	preview.SetSnapping(false)
+	preview.SetTitle("Layout Preview")
+
+	ti := textinput.New()
+	ti.Placeholder = "New Profile Name"
+	ti.CharLimit = 50
+
+	return &ProfileBrowser{
+		cfg:        cfg,
+		matcher:    matcher,
+		generator:  generator,
+		monitors:   monitors,
+		powerState: powerState,
+		lidState:   lidState,
+		L:          profilesList,
+		preview:    preview,
+		nameInput:  ti,
+		help:       NewCustomHelp(colors),
+		colors:     colors,
+		keymap:     &keys.profileBrowser,
+	}
+}
+
+// Typing is true while the name of a renamed or duplicated profile or a conditions field is
+// being entered
+func (p *ProfileBrowser) Typing() bool {
+	return p.NamingProfile() || (p.conditions != nil && p.conditions.Typing())
+}
+
+func (p *ProfileBrowser) NamingProfile() bool {
+	return p.nameAction != nil
+}
+
+func (p *ProfileBrowser) EditingConditions() bool {
+	return p.conditions != nil
+}
+
+func (p *ProfileBrowser) Update(msg tea.Msg) tea.Cmd {
+	cmds := []tea.Cmd{}
+
+	switch msg := msg.(type) {
+	case ConfigReloaded, ViewChanged:
+		p.pulled = false
+	case PowerStateChanged:
+		p.powerState = msg.state
+		p.pulled = false
+	case LidStateChanged:
+		p.lidState = msg.state
+		p.pulled = false
+	}
+
+	if !p.pulled {
+		p.pulled = true
+		cmds = append(cmds, p.pull())
+	}
+
+	if p.NamingProfile() {
+		cmds = append(cmds, p.updateNameInput(msg))
+		return tea.Batch(cmds...)
+	}
+	if p.EditingConditions() {
+		cmd, done := p.conditions.Update(msg)
+		if done {
+			p.conditions = nil
+		}
+		cmds = append(cmds, cmd)
+		return tea.Batch(cmds...)
+	}
+
+	selected, ok := p.L.SelectedItem().(ProfileBrowserItem)
+	// nolint:gocritic
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case !ok:
+		case key.Matches(msg, p.keymap.Open):
+			cmds = append(cmds, openEditor(selected.profile.Profile.ConfigFile))
+		case key.Matches(msg, p.keymap.Duplicate):
+			cmds = append(cmds, p.requestName(selected, ProfileActionDuplicate))
+		case key.Matches(msg, p.keymap.Rename):
+			cmds = append(cmds, p.requestName(selected, ProfileActionRename))
+		case key.Matches(msg, p.keymap.Delete):
+			if selected.fallback {
+				cmds = append(cmds, OperationStatusCmd(ProfileActionDelete.Operation(), ErrFallbackProfileAction))
+				break
+			}
+			cmds = append(cmds, profileActionConfirmationCmd(ProfileActionCommand{
+				action: ProfileActionDelete,
+				name:   selected.profile.Profile.Name,
+			}))
+		case key.Matches(msg, p.keymap.Conditions):
+			if selected.fallback {
+				cmds = append(cmds, OperationStatusCmd(OperationNameEditConditions, ErrFallbackConditions))
+				break
+			}
+			p.conditions = NewProfileConditionsForm(selected.profile, p.monitors, p.colors)
+		case key.Matches(msg, p.keymap.Apply):
+			cmds = append(cmds, profileActionConfirmationCmd(ProfileActionCommand{
+				action:     ProfileActionApply,
+				name:       selected.Name(),
+				profile:    selected.profile,
+				powerState: p.powerState,
+				lidState:   p.lidState,
+			}))
+		}
+	}
+
+	var cmd tea.Cmd
+	p.L, cmd = p.L.Update(msg)
+	cmds = append(cmds, cmd)
+	p.refreshPreview()
+	cmds = append(cmds, p.preview.Update(msg))
+
+	return tea.Batch(cmds...)
+}
+
+func (p *ProfileBrowser) requestName(selected ProfileBrowserItem, action ProfileAction) tea.Cmd {
+	if selected.fallback {
+		return OperationStatusCmd(action.Operation(), ErrFallbackProfileAction)
+	}
+	p.nameAction = &action
+	p.nameInput.SetValue("")
+	return p.nameInput.Focus()
+}
+
+func (p *ProfileBrowser) updateNameInput(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, p.keymap.Back):
+			p.nameAction = nil
+			p.nameInput.Blur()
+			return nil
+		case key.Matches(msg, p.keymap.Submit):
+			selected, ok := p.L.SelectedItem().(ProfileBrowserItem)
+			if !ok {
+				return nil
+			}
+			action := *p.nameAction
+			name := p.nameInput.Value()
+			if err := validateProfileName(name); err != nil {
+				return OperationStatusCmd(action.Operation(), err)
+			}
+			p.nameAction = nil
+			p.nameInput.Blur()
+			return profileActionCmd(ProfileActionCommand{
+				action:  action,
+				name:    selected.profile.Profile.Name,
+				newName: name,
+			})
+		}
+	}
+
+	var cmd tea.Cmd
+	p.nameInput, cmd = p.nameInput.Update(msg)
+	return cmd
+}
+
+// pull scores every profile in the order they are defined, the same order decides ties
+func (p *ProfileBrowser) pull() tea.Cmd {
+	if p.cfg == nil {
+		return nil
+	}
+	mons, err := ConvertToHyprMonitors(p.monitors)
+	if err != nil {
+		return OperationStatusCmd(OperationNameMatchingProfile, err)
+	}
+
+	cfg := p.cfg.Get()
+	_, active, err := p.matcher.Match(cfg, mons, p.powerState, p.lidState, power.BatteryState{})
+	if err != nil {
+		return OperationStatusCmd(OperationNameMatchingProfile, err)
+	}
+
+	items := []list.Item{}
+	for _, name := range cfg.OrderedProfileKeys() {
+		score, fullScore, profile := p.matcher.Score(cfg, cfg.Profiles[name], mons, p.powerState,
+			p.lidState, power.BatteryState{})
+		items = append(items, ProfileBrowserItem{
+			profile:   profile,
+			score:     score,
+			fullScore: fullScore,
+			active:    active != nil && active.Profile == cfg.Profiles[name],
+		})
+	}
+	if cfg.FallbackProfile != nil {
+		items = append(items, ProfileBrowserItem{
+			profile:  matchers.NewFallbackProfile(cfg.FallbackProfile),
+			active:   active != nil && active.Profile == cfg.FallbackProfile,
+			fallback: true,
+		})
+	}
+
+	index := p.L.Index()
+	cmd := p.L.SetItems(items)
+	if index >= len(items) {
+		index = len(items) - 1
+	}
+	p.L.Select(max(index, 0))
+	p.previewed = ""
+	p.refreshPreview()
+	return cmd
+}
+
+// refreshPreview renders the selected profile when the selection changed
+func (p *ProfileBrowser) refreshPreview() {
+	selected, ok := p.L.SelectedItem().(ProfileBrowserItem)
+	if !ok {
+		p.previewed = ""
+		p.preview.SetMonitors([]*MonitorSpec{})
+		return
+	}
+	if selected.Name() == p.previewed {
+		return
+	}
+	p.previewed = selected.Name()
+
+	monitors, err := p.renderLayout(selected.profile)
+	p.previewErr = err
+	if err != nil {
+		logrus.WithError(err).Debug("Cant render the profile preview")
+		monitors = []*MonitorSpec{}
+	}
+	p.preview.SetMonitors(monitors)
+}
+
+// renderLayout renders the profile with the current monitors and converts the monitor= lines back
+// into monitors, auto positioned ones are lined up to the right like Hyprland does
+func (p *ProfileBrowser) renderLayout(profile *matchers.MatchedProfile) ([]*MonitorSpec, error) {
+	mons, err := ConvertToHyprMonitors(p.monitors)
+	if err != nil {
+		return nil, err
+	}
+	rendered, err := p.generator.Render(p.cfg.Get(), profile, mons, p.powerState, p.lidState, power.BatteryState{})
+	if err != nil {
+		return nil, err
+	}
+
+	placements, _ := layout.Parse(rendered, mons)
+	monitors := []*MonitorSpec{}
+	right := 0.0
+	for _, placement := range placements {
+		if !placement.Disabled && placement.Positioned {
+			right = math.Max(right, placement.X+placement.Width)
+		}
+	}
+	for _, placement := range placements {
+		monitor := NewMonitorSpec(placement.Monitor)
+		monitor.Disabled = placement.Disabled
+		if !placement.Disabled {
+			if !placement.Positioned {
+				placement.X, placement.Y = right, 0
+				right += placement.Width
+			}
+			width, height := placement.Width, placement.Height
+			if placement.Transform%2 == 1 {
+				width, height = height, width
+			}
+			monitor.Width = int(math.Round(width * placement.Scale))
+			monitor.Height = int(math.Round(height * placement.Scale))
+			monitor.Scale = placement.Scale
+			monitor.Transform = placement.Transform % 4
+			monitor.Flipped = placement.Transform >= 4
+			monitor.X = int(placement.X)
+			monitor.Y = int(placement.Y)
+		}
+		monitors = append(monitors, monitor)
+	}
+	if len(monitors) == 0 {
+		return nil, errors.New("no monitor= line targets the connected monitors")
+	}
+	return monitors, nil
+}
+
+func (p *ProfileBrowser) SetHeight(height int) {
+	p.height = height
+}
+
+func (p *ProfileBrowser) SetWidth(width int) {
+	p.width = width
+	p.nameInput.Width = width - 5
+}
+
+func (p *ProfileBrowser) SetPreviewSize(width, height int) {
+	p.preview.SetWidth(width)
+	p.preview.SetHeight(height)
+}
+
+func (p *ProfileBrowser) View() string {
+	if p.conditions != nil {
+		p.conditions.SetWidth(p.width)
+		p.conditions.SetHeight(p.height)
+		return p.conditions.View()
+	}
+
+	sections := []string{}
+	availableHeight := p.height
+
+	title := p.colors.TitleStyle().Margin(0, 0, 1, 0).Render("Profiles")
+	sections = append(sections, title)
+	availableHeight -= lipgloss.Height(title)
+
+	help := p.colors.HelpStyle().Width(p.width).Render(p.help.ShortHelpView(p.keymap.Help()))
+	availableHeight -= lipgloss.Height(help)
+
+	details := p.renderDetails()
+	availableHeight -= lipgloss.Height(details)
+
+	if len(p.L.Items()) == 0 {
+		empty := lipgloss.NewStyle().Width(p.width).Height(max(availableHeight, 0)).Render("No profiles defined")
+		sections = append(sections, empty)
+	} else {
+		p.L.SetHeight(max(availableHeight, 0))
+		p.L.SetWidth(p.width)
+		content := lipgloss.NewStyle().Height(max(availableHeight, 0)).Render(p.L.View())
+		sections = append(sections, content)
+	}
+	sections = append(sections, details, help)
+
+	return lipgloss.JoinVertical(lipgloss.Top, sections...)
+}
+
+func (p *ProfileBrowser) renderDetails() string {
+	selected, ok := p.L.SelectedItem().(ProfileBrowserItem)
+	if !ok {
+		return ""
+	}
+	profile := selected.profile.Profile
+	content := p.colors.SubtitleStyle().Render("Config File: ") + filepath.Base(profile.ConfigFile)
+	if profile.ConfigType != nil {
+		content += "\n" + p.colors.SubtitleStyle().Render("Config Type: ") + profile.ConfigType.Value()
+	}
+	return p.colors.ConfigPaneBorderStyle().Width(p.width).Render(content)
+}
+
+func (p *ProfileBrowser) NameInputView(height int) string {
+	sections := []string{}
+	action := "Duplicate"
+	if p.nameAction != nil && *p.nameAction == ProfileActionRename {
+		action = "Rename"
+	}
+	name := ""
+	if selected, ok := p.L.SelectedItem().(ProfileBrowserItem); ok {
+		name = selected.Name()
+	}
+
+	title := p.colors.TitleStyle().Margin(0, 0, 1, 0).Render(fmt.Sprintf("%s %s as", action, name))
+	sections = append(sections, title)
+	ti := p.nameInput.View()
+	sections = append(sections, ti)
+	help := p.help.ShortHelpView(p.keymap.InputHelp())
+
+	spacer := lipgloss.NewStyle().Height(max(height-lipgloss.Height(title)-lipgloss.Height(ti)-
+		lipgloss.Height(help), 0)).Render("")
+	sections = append(sections, spacer, help)
+
+	return lipgloss.JoinVertical(lipgloss.Top, sections...)
+}
+
+func (p *ProfileBrowser) PreviewView() string {
+	if p.previewErr != nil {
+		return lipgloss.JoinVertical(lipgloss.Top,
+			p.colors.TitleStyle().Margin(0, 0, 1, 0).Render("Layout Preview"),
+			p.colors.ErrorStyle().Width(p.preview.width).Render(
+				fmt.Sprintf("Cant render %s: %v", p.previewed, p.previewErr)))
+	}
+	return p.preview.View()
+}
+
+func (p *ProfileBrowser) GetPreview() *MonitorsPreviewPane {
+	return p.preview
+}
+
+func (p *ProfileBrowser) GetConditionsForm() *ProfileConditionsForm {
+	return p.conditions
+}
+
+func (p *ProfileBrowser) GetItems() []ProfileBrowserItem {
+	items := []ProfileBrowserItem{}
+	for _, item := range p.L.Items() {
+		if profileItem, ok := item.(ProfileBrowserItem); ok {
+			items = append(items, profileItem)
+		}
+	}
+	return items
+}
@@ -0,0 +1,73 @@
+package tui_test
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/generators"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/matchers"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/power"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/testutils"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/tui"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileBrowser_Update(t *testing.T) {
+	cfg := testutils.NewTestConfig(t).WithProfiles(map[string]*config.Profile{
+		"docked": {
+			ConfigType: utils.JustPtr(config.Static),
+			Conditions: &config.ProfileCondition{
+				RequiredMonitors: []*config.RequiredMonitor{
+					{Name: utils.StringPtr("eDP-1")},
+					{Name: utils.StringPtr("DP-1")},
+				},
+			},
+		},
+		"laptop": {
+			ConfigType: utils.JustPtr(config.Static),
+			Conditions: &config.ProfileCondition{
+				PowerState: utils.JustPtr(config.BAT),
+				RequiredMonitors: []*config.RequiredMonitor{
+					{Name: utils.StringPtr("eDP-1")},
+				},
+			},
+		},
+	}).FillProfileConfigFile("docked", "testdata/profiles/docked.conf").Get()
+
+	generator, err := generators.NewConfigGenerator(cfg)
+	require.NoError(t, err)
+	monitors := []*tui.MonitorSpec{}
+	for _, monitor := range loadMonitorsFromTestdata(t, "two.json") {
+		monitors = append(monitors, tui.NewMonitorSpec(monitor))
+	}
+	browser := tui.NewProfileBrowser(cfg, matchers.NewMatcher(), generator, monitors,
+		power.ACPowerState, power.OpenedLidState, tui.NewColorsManager(cfg), tui.NewKeyBindings(cfg))
+
+	browser.Update(tui.ViewChanged{})
+	items := browser.GetItems()
+	require.Len(t, items, 2)
+	assert.True(t, items[0].Active())
+	assert.True(t, items[0].Matches())
+	score, full := items[1].Score()
+	assert.Positive(t, score)
+	assert.Less(t, score, full)
+
+	preview := browser.GetPreview().GetMonitors()
+	require.Len(t, preview, 2)
+	assert.Equal(t, "eDP-1", preview[0].Name)
+	assert.Equal(t, []int{2560, 0, 2880, 1920}, []int{preview[0].X, preview[0].Y, preview[0].Width, preview[0].Height})
+	assert.Equal(t, 1.5, preview[1].Scale)
+
+	browser.Update(tui.PowerStateChangedCmd(power.BatteryPowerState))
+	items = browser.GetItems()
+	assert.True(t, items[1].Active())
+	assert.False(t, items[0].Active())
+
+	browser.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	assert.True(t, browser.Typing())
+	browser.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, browser.Typing())
+}
@@ -0,0 +1,502 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/matchers"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/profilemaker"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+)
+
+// MatchMode is how a required monitor is matched against the connected ones
+type MatchMode int
+
+const (
+	MatchByDescription MatchMode = iota
+	MatchByName
+	MatchByDescriptionRegex
+	MatchByNameRegex
+)
+
+var allMatchModes = []MatchMode{MatchByDescription, MatchByName, MatchByDescriptionRegex, MatchByNameRegex}
+
+func (m MatchMode) String() string {
+	switch m {
+	case MatchByDescription:
+		return "description"
+	case MatchByName:
+		return "name"
+	case MatchByDescriptionRegex:
+		return "description regex"
+	case MatchByNameRegex:
+		return "name regex"
+	}
+	return "unknown"
+}
+
+func (m MatchMode) regex() bool {
+	return m == MatchByDescriptionRegex || m == MatchByNameRegex
+}
+
+func (m MatchMode) description() bool {
+	return m == MatchByDescription || m == MatchByDescriptionRegex
+}
+
+// conditionsFormRow is a connected monitor or a required monitor that is not connected right now
+type conditionsFormRow struct {
+	// monitor is nil for rules that target a disconnected monitor
+	monitor *MonitorSpec
+	// rule is written back as is when the row is left untouched
+	rule     *config.RequiredMonitor
+	required bool
+	mode     MatchMode
+	value    string
+	tag      string
+	changed  bool
+}
+
+func newRuleRow(monitor *MonitorSpec, rule *config.RequiredMonitor) *conditionsFormRow {
+	row := &conditionsFormRow{
+		monitor:  monitor,
+		rule:     rule,
+		required: true,
+		mode:     MatchByName,
+	}
+	if rule.Name != nil {
+		row.value = *rule.Name
+		if rule.MatchNameUsingRegex != nil && *rule.MatchNameUsingRegex {
+			row.mode = MatchByNameRegex
+		}
+	}
+	if rule.Description != nil {
+		row.value = *rule.Description
+		row.mode = MatchByDescription
+		if rule.MatchDescriptionUsingRegex != nil && *rule.MatchDescriptionUsingRegex {
+			row.mode = MatchByDescriptionRegex
+		}
+	}
+	if rule.MonitorTag != nil {
+		row.tag = *rule.MonitorTag
+	}
+	return row
+}
+
+// newMonitorRow prefers the description like freeze does, so port swaps keep matching
+func newMonitorRow(monitor *MonitorSpec) *conditionsFormRow {
+	row := &conditionsFormRow{monitor: monitor, mode: MatchByDescription}
+	if monitor.Description == "" {
+		row.mode = MatchByName
+	}
+	row.value = row.valueFor(row.mode)
+	return row
+}
+
+func (r *conditionsFormRow) valueFor(mode MatchMode) string {
+	if r.monitor == nil {
+		return r.value
+	}
+	value := r.monitor.Name
+	if mode.description() {
+		value = r.monitor.Description
+	}
+	if mode.regex() {
+		return "^" + regexp.QuoteMeta(value) + "$"
+	}
+	return value
+}
+
+// cycleMode skips description matching for monitors without one
+func (r *conditionsFormRow) cycleMode() {
+	for i, mode := range allMatchModes {
+		if mode != r.mode {
+			continue
+		}
+		next := allMatchModes[(i+1)%len(allMatchModes)]
+		if next.description() && r.monitor != nil && r.monitor.Description == "" {
+			next = allMatchModes[(i+2)%len(allMatchModes)]
+		}
+		r.mode = next
+		r.value = r.valueFor(next)
+		r.changed = true
+		return
+	}
+}
+
+func (r *conditionsFormRow) Label() string {
+	if r.monitor == nil {
+		return "(not connected)"
+	}
+	return r.monitor.Name
+}
+
+func (r *conditionsFormRow) RequiredMonitor() *config.RequiredMonitor {
+	if !r.changed && r.rule != nil {
+		return r.rule
+	}
+	rule := &config.RequiredMonitor{}
+	value := r.value
+	if r.mode.description() {
+		rule.Description = &value
+		rule.MatchDescriptionUsingRegex = utils.JustPtr(r.mode.regex())
+	} else {
+		rule.Name = &value
+		rule.MatchNameUsingRegex = utils.JustPtr(r.mode.regex())
+	}
+	if r.tag != "" {
+		rule.MonitorTag = utils.StringPtr(r.tag)
+	}
+	return rule
+}
+
+type conditionsFormField int
+
+const (
+	conditionsFormNoField conditionsFormField = iota
+	conditionsFormTagField
+	conditionsFormValueField
+)
+
+var (
+	powerStateChoices = []string{"", config.AC.Value(), config.BAT.Value()}
+	lidStateChoices   = []string{"", config.OpenedLidStateType.Value(), config.ClosedLidStateType.Value()}
+)
+
+type profileConditionsFormKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Toggle key.Binding
+	Mode   key.Binding
+	Tag    key.Binding
+	Value  key.Binding
+	Save   key.Binding
+	Submit key.Binding
+	Back   key.Binding
+}
+
+func (p *profileConditionsFormKeyMap) Help() []key.Binding {
+	return []key.Binding{
+		p.Toggle,
+		p.Mode,
+		p.Tag,
+		p.Value,
+		p.Save,
+		p.Back,
+	}
+}
+
+func (p *profileConditionsFormKeyMap) InputHelp() []key.Binding {
+	return []key.Binding{
+		p.Submit,
+		p.Back,
+	}
+}
+
+// ProfileConditionsForm edits the required monitors, power_state and lid_state of a profile, the
+// last two rows are the power and lid state
+type ProfileConditionsForm struct {
+	profile    string
+	rows       []*conditionsFormRow
+	powerState string
+	lidState   string
+	cursor     int
+	input      textinput.Model
+	field      conditionsFormField
+	keymap     *profileConditionsFormKeyMap
+	help       *CustomHelp
+	width      int
+	height     int
+	colors     *ColorsManager
+}
+
+// NewProfileConditionsForm pairs the connected monitors with the rules that matched them, rules
+// that did not match anything are kept as required rows
+func NewProfileConditionsForm(profile *matchers.MatchedProfile, monitors []*MonitorSpec,
+	colors *ColorsManager,
+) *ProfileConditionsForm {
+	rows := []*conditionsFormRow{}
+	used := map[*config.RequiredMonitor]bool{}
+	for _, monitor := range monitors {
+		var rule *config.RequiredMonitor
+		if monitor.ID != nil {
+			rule = profile.MonitorToRule[*monitor.ID]
+		}
+		if rule == nil {
+			rows = append(rows, newMonitorRow(monitor))
+			continue
+		}
+		used[rule] = true
+		rows = append(rows, newRuleRow(monitor, rule))
+	}
+
+	powerState, lidState := "", ""
+	if conditions := profile.Profile.Conditions; conditions != nil {
+		for _, rule := range conditions.RequiredMonitors {
+			if !used[rule] {
+				rows = append(rows, newRuleRow(nil, rule))
+			}
+		}
+		if conditions.PowerState != nil {
+			powerState = conditions.PowerState.Value()
+		}
+		if conditions.LidState != nil {
+			lidState = conditions.LidState.Value()
+		}
+	}
+
+	ti := textinput.New()
+	ti.CharLimit = 200
+
+	return &ProfileConditionsForm{
+		profile:    profile.Profile.Name,
+		rows:       rows,
+		powerState: powerState,
+		lidState:   lidState,
+		input:      ti,
+		help:       NewCustomHelp(colors),
+		colors:     colors,
+		keymap: &profileConditionsFormKeyMap{
+			Up: key.NewBinding(
+				key.WithKeys("up", "k"),
+			),
+			Down: key.NewBinding(
+				key.WithKeys("down", "j"),
+			),
+			Toggle: key.NewBinding(
+				key.WithKeys(" "),
+				key.WithHelp("space", "toggle/cycle"),
+			),
+			Mode: key.NewBinding(
+				key.WithKeys("m"),
+				key.WithHelp("m", "match by"),
+			),
+			Tag: key.NewBinding(
+				key.WithKeys("t"),
+				key.WithHelp("t", "tag"),
+			),
+			Value: key.NewBinding(
+				key.WithKeys("v"),
+				key.WithHelp("v", "edit value"),
+			),
+			Save: key.NewBinding(
+				key.WithKeys("enter"),
+				key.WithHelp("enter", "save"),
+			),
+			Submit: key.NewBinding(
+				key.WithKeys("enter"),
+				key.WithHelp("enter", "confirm"),
+			),
+			Back: key.NewBinding(
+				key.WithKeys("esc"),
+				key.WithHelp("esc", "return/back"),
+			),
+		},
+	}
+}
+
+// Typing is true while a tag or a match value is being entered
+func (f *ProfileConditionsForm) Typing() bool {
+	return f.field != conditionsFormNoField
+}
+
+// Update returns done when the form should be closed
+func (f *ProfileConditionsForm) Update(msg tea.Msg) (tea.Cmd, bool) {
+	if f.Typing() {
+		return f.updateInput(msg), false
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return nil, false
+	}
+	row := f.selectedRow()
+	switch {
+	case key.Matches(keyMsg, f.keymap.Back):
+		return nil, true
+	case key.Matches(keyMsg, f.keymap.Save):
+		return f.save()
+	case key.Matches(keyMsg, f.keymap.Up):
+		f.cursor = max(f.cursor-1, 0)
+	case key.Matches(keyMsg, f.keymap.Down):
+		f.cursor = min(f.cursor+1, len(f.rows)+1)
+	case key.Matches(keyMsg, f.keymap.Toggle):
+		switch {
+		case row != nil:
+			row.required = !row.required
+		case f.cursor == len(f.rows):
+			f.powerState = nextChoice(powerStateChoices, f.powerState)
+		default:
+			f.lidState = nextChoice(lidStateChoices, f.lidState)
+		}
+	case key.Matches(keyMsg, f.keymap.Mode) && row != nil:
+		row.cycleMode()
+	case key.Matches(keyMsg, f.keymap.Tag) && row != nil:
+		return f.startInput(conditionsFormTagField, row.tag, "monitor_tag"), false
+	case key.Matches(keyMsg, f.keymap.Value) && row != nil:
+		return f.startInput(conditionsFormValueField, row.value, row.mode.String()), false
+	}
+	return nil, false
+}
+
+func (f *ProfileConditionsForm) startInput(field conditionsFormField, value, placeholder string) tea.Cmd {
+	f.field = field
+	f.input.Placeholder = placeholder
+	f.input.SetValue(value)
+	f.input.CursorEnd()
+	return f.input.Focus()
+}
+
+func (f *ProfileConditionsForm) updateInput(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, f.keymap.Back):
+			f.field = conditionsFormNoField
+			f.input.Blur()
+			return nil
+		case key.Matches(msg, f.keymap.Submit):
+			row := f.selectedRow()
+			value := strings.TrimSpace(f.input.Value())
+			switch f.field {
+			case conditionsFormTagField:
+				row.tag = value
+			case conditionsFormValueField:
+				if value == "" {
+					return OperationStatusCmd(OperationNameEditConditions, errors.New("match value cant be empty"))
+				}
+				if row.mode.regex() {
+					if _, err := regexp.Compile(value); err != nil {
+						return OperationStatusCmd(OperationNameEditConditions, fmt.Errorf("invalid regex: %w", err))
+					}
+				}
+				row.value = value
+			case conditionsFormNoField:
+			}
+			row.changed = true
+			f.field = conditionsFormNoField
+			f.input.Blur()
+			return nil
+		}
+	}
+
+	var cmd tea.Cmd
+	f.input, cmd = f.input.Update(msg)
+	return cmd
+}
+
+func (f *ProfileConditionsForm) save() (tea.Cmd, bool) {
+	update := f.Conditions()
+	if len(update.RequiredMonitors) == 0 {
+		return OperationStatusCmd(OperationNameEditConditions,
+			errors.New("at least one monitor has to be required")), false
+	}
+	return profileActionCmd(ProfileActionCommand{
+		action:     ProfileActionEditConditions,
+		name:       f.profile,
+		conditions: update,
+	}), true
+}
+
+// Conditions is what gets written to the configuration on save
+func (f *ProfileConditionsForm) Conditions() *profilemaker.ConditionsUpdate {
+	monitors := []*config.RequiredMonitor{}
+	for _, row := range f.rows {
+		if row.required {
+			monitors = append(monitors, row.RequiredMonitor())
+		}
+	}
+	return &profilemaker.ConditionsUpdate{
+		RequiredMonitors: monitors,
+		Values: map[string]string{
+			"power_state": f.powerState,
+			"lid_state":   f.lidState,
+		},
+	}
+}
+
+func (f *ProfileConditionsForm) selectedRow() *conditionsFormRow {
+	if f.cursor < len(f.rows) {
+		return f.rows[f.cursor]
+	}
+	return nil
+}
+
+func nextChoice(choices []string, current string) string {
+	for i, choice := range choices {
+		if choice == current {
+			return choices[(i+1)%len(choices)]
+		}
+	}
+	return choices[0]
+}
+
+func (f *ProfileConditionsForm) SetWidth(width int) {
+	f.width = width
+	f.input.Width = width - 5
+}
+
+func (f *ProfileConditionsForm) SetHeight(height int) {
+	f.height = height
+}
+
+func (f *ProfileConditionsForm) View() string {
+	sections := []string{}
+	title := f.colors.TitleStyle().Margin(0, 0, 1, 0).Render("Conditions of " + f.profile)
+	sections = append(sections, title)
+
+	lines := []string{}
+	for i, row := range f.rows {
+		check := "[ ]"
+		if row.required {
+			check = "[x]"
+		}
+		lines = append(lines, f.renderLine(i, check+" "+row.Label()))
+		details := fmt.Sprintf("%s: %s", row.mode, row.value)
+		if row.tag != "" {
+			details += ", tag: " + row.tag
+		}
+		lines = append(lines, "      "+f.colors.ListItemSubtitle().Render(details))
+	}
+	lines = append(lines, "",
+		f.renderLine(len(f.rows), "power_state: "+anyIfEmpty(f.powerState)),
+		f.renderLine(len(f.rows)+1, "lid_state: "+anyIfEmpty(f.lidState)))
+	content := strings.Join(lines, "\n")
+	sections = append(sections, content)
+
+	help := f.help.ShortHelpView(f.keymap.Help())
+	if f.Typing() {
+		input := f.input.View()
+		sections = append(sections, "", input)
+		help = f.help.ShortHelpView(f.keymap.InputHelp())
+	}
+	help = f.colors.HelpStyle().Width(f.width).Render(help)
+
+	used := 0
+	for _, section := range sections {
+		used += lipgloss.Height(section)
+	}
+	spacer := lipgloss.NewStyle().Height(max(f.height-used-lipgloss.Height(help), 0)).Render("")
+	sections = append(sections, spacer, help)
+
+	return lipgloss.JoinVertical(lipgloss.Top, sections...)
+}
+
+func (f *ProfileConditionsForm) renderLine(index int, text string) string {
+	if index == f.cursor {
+		return f.colors.ListItemSelected().Render("► " + text)
+	}
+	return f.colors.ListItemUnselected().Render("  " + text)
+}
+
+func anyIfEmpty(value string) string {
+	if value == "" {
+		return "any"
+	}
+	return value
+}
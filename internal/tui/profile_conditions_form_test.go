@@ -0,0 +1,70 @@
+package tui_test
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/matchers"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/testutils"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/tui"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileConditionsForm_Update(t *testing.T) {
+	monitors := []*tui.MonitorSpec{}
+	for _, monitor := range loadMonitorsFromTestdata(t, "two.json") {
+		monitors = append(monitors, tui.NewMonitorSpec(monitor))
+	}
+	laptop := &config.RequiredMonitor{Name: utils.StringPtr("eDP-1"), MonitorTag: utils.StringPtr("laptop")}
+	projector := &config.RequiredMonitor{Name: utils.StringPtr("HDMI-A-1")}
+	profile := &config.Profile{
+		Name: "docked",
+		Conditions: &config.ProfileCondition{
+			PowerState:       utils.JustPtr(config.AC),
+			RequiredMonitors: []*config.RequiredMonitor{laptop, projector},
+		},
+	}
+	form := tui.NewProfileConditionsForm(matchers.NewMatchedProfile(profile, map[int]*config.RequiredMonitor{
+		*monitors[0].ID: laptop,
+	}), monitors, tui.NewColorsManager(testutils.NewTestConfig(t).Get()))
+
+	press := func(keys ...tea.KeyMsg) {
+		for _, msg := range keys {
+			_, done := form.Update(msg)
+			require.False(t, done)
+		}
+	}
+	runes := func(r rune) tea.KeyMsg {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+	}
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+
+	conditions := form.Conditions()
+	assert.Equal(t, []*config.RequiredMonitor{laptop, projector}, conditions.RequiredMonitors)
+	assert.Equal(t, map[string]string{"power_state": "AC", "lid_state": ""}, conditions.Values)
+
+	// require the dell by a regex on its description and tag it
+	press(runes('j'), space, runes('m'), runes('m'), runes('t'))
+	assert.True(t, form.Typing())
+	press(runes('d'), runes('e'), runes('s'), runes('k'), tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, form.Typing())
+
+	// drop the disconnected projector, cycle power to BAT and lid to Opened
+	press(runes('j'), space, runes('j'), space, runes('j'), space)
+
+	conditions = form.Conditions()
+	require.Len(t, conditions.RequiredMonitors, 2)
+	assert.Equal(t, laptop, conditions.RequiredMonitors[0])
+	assert.Equal(t, &config.RequiredMonitor{
+		Description:                utils.StringPtr(`^Dell Inc\. DELL U2723QE 5YNK3H3$`),
+		MatchDescriptionUsingRegex: utils.JustPtr(true),
+		MonitorTag:                 utils.StringPtr("desk"),
+	}, conditions.RequiredMonitors[1])
+	assert.Equal(t, map[string]string{"power_state": "BAT", "lid_state": "Opened"}, conditions.Values)
+
+	_, done := form.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.True(t, done)
+}
@@ -106,7 +106,7 @@ func (p *ProfileNamePicker) Update(msg tea.Msg) tea.Cmd {
 				p.textInput.SetValue("")
 				logrus.Debugf("Setting name to: %s", text)
 				file := fmt.Sprintf("hyprconfigs/%s.go.tmpl", text)
-				cmds = append(cmds, createNewProfileCmd(text, file))
+				cmds = append(cmds, createNewProfileConfirmationCmd(text, file))
 			}
 		}
 	}
@@ -3,6 +3,7 @@ package tui
 
 import (
 	"fmt"
+	"path/filepath"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -20,17 +21,21 @@ import (
 type Model struct {
 	config    *config.Config
 	keys      keyMap
+	bindings  *KeyBindings
 	layout    *Layout
 	rootState *RootState
 
 	// universal components
 	confirmationPrompt *ConfirmationPrompt
+	themePicker        *ThemePicker
 
 	// components for monitor view
 	monitorsList        *MonitorList
 	monitorsPreviewPane *MonitorsPreviewPane
 	monitorModes        *MonitorModeList
 	monitorMirrors      *MirrorList
+	monitorAligns       *AlignList
+	exactInput          *ExactInput
 	help                *CustomHelp
 	header              *Header
 	hyprPreviewPane     *HyprPreviewPane
@@ -43,6 +48,9 @@ type Model struct {
 	hdmProfilePreview         *HDMProfilePreview
 	hdmGeneratevConfigPreview *HDMGeneratedConfigPreview
 
+	// components for profiles view
+	profileBrowser *ProfileBrowser
+
 	// stores
 	monitorEditor *MonitorEditorStore
 	colors        *ColorsManager
@@ -52,8 +60,9 @@ type Model struct {
 	profileMaker *profilemaker.Service
 
 	// for tests
-	duration *time.Duration
-	start    time.Time
+	duration         *time.Duration
+	start            time.Time
+	runningUnderTest bool
 }
 
 func NewModel(cfg *config.Config, hyprMonitors hypr.MonitorSpecs,
@@ -65,7 +74,7 @@ func NewModel(cfg *config.Config, hyprMonitors hypr.MonitorSpecs,
 		monitors[i] = NewMonitorSpec(monitor)
 	}
 
-	state := NewState(monitors, cfg)
+	state := NewState(monitors, cfg, powerState, lidState)
 	matcher := matchers.NewMatcher()
 	generator, err := generators.NewConfigGenerator(cfg)
 	if err != nil {
@@ -73,32 +82,39 @@ func NewModel(cfg *config.Config, hyprMonitors hypr.MonitorSpecs,
 	}
 
 	colors := NewColorsManager(cfg)
+	keys := NewKeyBindings(cfg)
 
 	model := Model{
 		config:                    cfg,
-		keys:                      rootKeyMap,
+		keys:                      keys.root,
+		bindings:                  keys,
 		rootState:                 state,
 		layout:                    NewLayout(),
-		monitorsList:              NewMonitorList(monitors, colors),
-		colorPicker:               NewColorPicker(colors),
-		monitorsPreviewPane:       NewMonitorsPreviewPane(monitors, colors),
+		monitorsList:              NewMonitorList(monitors, colors, keys),
+		colorPicker:               NewColorPicker(colors, keys),
+		monitorsPreviewPane:       NewMonitorsPreviewPane(monitors, colors, keys),
 		help:                      NewCustomHelp(colors),
 		header:                    NewHeader("HyprDynamicMonitors", state.viewModes, version, colors),
 		hyprPreviewPane:           NewHyprPreviewPane(monitors, colors),
 		monitorEditor:             NewMonitorEditor(monitors),
-		monitorModes:              NewMonitorModeList(monitors, colors),
-		monitorMirrors:            NewMirrorList(monitors, colors),
+		monitorModes:              NewMonitorModeList(monitors, colors, keys),
+		monitorMirrors:            NewMirrorList(monitors, colors, keys),
+		monitorAligns:             NewAlignList(monitors, colors, keys),
+		exactInput:                NewExactInput(colors),
 		hyprApply:                 NewHyprApply(profileMaker, generator),
-		hdm:                       NewHDMConfigPane(cfg, matcher, monitors, powerState, lidState, colors),
+		hdm:                       NewHDMConfigPane(cfg, matcher, monitors, powerState, lidState, colors, keys),
 		profileMaker:              profileMaker,
 		profileNamePicker:         NewProfileNamePicker(colors),
 		confirmationPrompt:        nil,
-		scaleSelector:             NewScaleSelector(colors),
-		hdmProfilePreview:         NewHDMProfilePreview(cfg, matcher, monitors, powerState, runningUnderTest, lidState, colors),
+		scaleSelector:             NewScaleSelector(colors, keys),
+		hdmProfilePreview:         NewHDMProfilePreview(cfg, matcher, generator, monitors, powerState, runningUnderTest, lidState, colors),
 		start:                     time.Now(),
 		duration:                  duration,
+		runningUnderTest:          runningUnderTest,
 		hdmGeneratevConfigPreview: NewHDMGeneratedConfigPreview(cfg, runningUnderTest, colors),
+		profileBrowser:            NewProfileBrowser(cfg, matcher, generator, monitors, powerState, lidState, colors, keys),
 		colors:                    colors,
+		themePicker:               NewThemePicker(cfg, colors, keys),
 	}
 
 	return model, nil
@@ -120,15 +136,28 @@ func (m Model) View() string {
 	logrus.Debug("Rendering the root model")
 
 	if m.rootState.State.ShowConfirmationPrompt {
-		m.confirmationPrompt.SetWidth(m.layout.PromptWidth())
-		m.confirmationPrompt.SetHeight(m.layout.PromptHeight())
-		prompt := m.colors.ActiveStyle().Width(m.layout.PromptWidth()).Height(
-			m.layout.PromptHeight()).Render(m.confirmationPrompt.View())
+		width, height := m.layout.PromptWidth(), m.layout.PromptHeight()
+		if m.confirmationPrompt.HasDiff() {
+			width, height = m.layout.DiffPromptWidth(), m.layout.DiffPromptHeight()
+		}
+		m.confirmationPrompt.SetWidth(width)
+		m.confirmationPrompt.SetHeight(height)
+		prompt := m.colors.ActiveStyle().Width(width).Height(height).Render(m.confirmationPrompt.View())
 
 		return lipgloss.Place(m.layout.AvailableWidth(), m.layout.AvailableHeight(),
 			lipgloss.Center, lipgloss.Center, prompt)
 	}
 
+	if m.rootState.State.ThemeSelection {
+		width, height := m.layout.DiffPromptWidth(), m.layout.DiffPromptHeight()
+		m.themePicker.SetWidth(width)
+		m.themePicker.SetHeight(height)
+		picker := m.colors.ActiveStyle().Width(width).Height(height).Render(m.themePicker.View())
+
+		return lipgloss.Place(m.layout.AvailableWidth(), m.layout.AvailableHeight(),
+			lipgloss.Center, lipgloss.Center, picker)
+	}
+
 	logrus.Debugf("Visible height: %d", m.layout.visibleHeight)
 
 	m.header.SetWidth(m.layout.visibleWidth)
@@ -141,6 +170,13 @@ func (m Model) View() string {
 	m.layout.SetReservedTop(globalHelpHeight + headerHeight + 2)
 	logrus.Debugf("Available height: %d", m.layout.AvailableHeight())
 
+	// the preview sits right of the left panels, inside its border
+	previewOriginX := m.layout.LeftPanesWidth() + 3
+	if m.rootState.State.Fullscreen {
+		previewOriginX = 1
+	}
+	m.monitorsPreviewPane.SetOrigin(previewOriginX, headerHeight+1)
+
 	left := m.leftPanels()
 	right := m.rightPanels()
 
@@ -189,6 +225,13 @@ func (m Model) rightPanels() []string {
 		rightSections = append(rightSections, generatedPreview)
 	}
 
+	if m.rootState.CurrentView() == ProfilesBrowserView {
+		m.profileBrowser.SetPreviewSize(m.layout.RightPanesWidth(), m.layout.AvailableHeight()+2)
+		preview := m.colors.InactiveStyle().Width(m.layout.RightPanesWidth()).Height(
+			m.layout.AvailableHeight() + 2).Render(m.profileBrowser.PreviewView())
+		rightSections = append(rightSections, preview)
+	}
+
 	if m.rootState.CurrentView() == MonitorsListView {
 		previewStyle := m.colors.InactiveStyle()
 		if m.rootState.State.Panning {
@@ -255,17 +298,38 @@ func (m Model) leftPanels() []string {
 		return left
 	}
 
+	if m.rootState.CurrentView() == ProfilesBrowserView {
+		mainPanelStyle := m.colors.ActiveStyle()
+		if m.profileBrowser.NamingProfile() {
+			leftMainPanelSize = m.layout.LeftMonitorsHeight()
+			mainPanelStyle = m.colors.InactiveStyle()
+		}
+		m.profileBrowser.SetHeight(leftMainPanelSize)
+		m.profileBrowser.SetWidth(m.layout.LeftPanesWidth())
+		left = append(left, mainPanelStyle.Width(m.layout.LeftPanesWidth()).Height(
+			leftMainPanelSize).Render(m.profileBrowser.View()))
+
+		if m.profileBrowser.NamingProfile() {
+			nameInput := m.colors.ActiveStyle().Width(m.layout.LeftPanesWidth()).Height(
+				m.layout.LeftSubpaneHeight()).Render(m.profileBrowser.NameInputView(m.layout.LeftSubpaneHeight()))
+			left = append(left, nameInput)
+		}
+		return left
+	}
+
 	// monitor view, different panels
 	if m.rootState.CurrentView() == MonitorsListView {
-		if m.rootState.State.ModeSelection || m.rootState.State.MirrorSelection || m.rootState.State.Scaling {
+		if m.rootState.State.ModeSelection || m.rootState.State.MirrorSelection || m.rootState.State.Scaling ||
+			m.rootState.State.AlignSelection || m.rootState.State.ExactInput {
 			leftMainPanelSize = m.layout.LeftMonitorsHeight()
 		}
 		m.monitorsList.SetHeight(leftMainPanelSize)
 		m.monitorsList.SetWidth(m.layout.LeftPanesWidth())
 		logrus.Debugf("Monitors list height: %d", leftMainPanelSize)
 		monitorViewStyle := m.colors.ActiveStyle()
-		if m.rootState.State.ModeSelection || m.rootState.State.MirrorSelection ||
-			m.rootState.State.Scaling || m.rootState.State.Panning || m.rootState.State.ColorSelection {
+		if m.rootState.State.ModeSelection || m.rootState.State.MirrorSelection || m.rootState.State.AlignSelection ||
+			m.rootState.State.Scaling || m.rootState.State.Panning || m.rootState.State.ColorSelection ||
+			m.rootState.State.ExactInput {
 			monitorViewStyle = m.colors.InactiveStyle()
 		}
 		monitorView := monitorViewStyle.Width(m.layout.LeftPanesWidth()).Height(
@@ -295,6 +359,21 @@ func (m Model) leftPanels() []string {
 			left = append(left, pane)
 		}
 
+		if m.rootState.State.AlignSelection {
+			m.monitorAligns.SetHeight(m.layout.LeftSubpaneHeight())
+			pane := subpaneStyle.Width(m.layout.LeftPanesWidth()).Height(
+				m.layout.LeftSubpaneHeight()).Render(m.monitorAligns.View())
+			left = append(left, pane)
+		}
+
+		if m.rootState.State.ExactInput {
+			m.exactInput.SetHeight(m.layout.LeftSubpaneHeight())
+			m.exactInput.SetWidth(m.layout.LeftPanesWidth())
+			pane := subpaneStyle.Width(m.layout.LeftPanesWidth()).Height(
+				m.layout.LeftSubpaneHeight()).Render(m.exactInput.View())
+			left = append(left, pane)
+		}
+
 		if m.rootState.State.Scaling {
 			m.scaleSelector.SetHeight(m.layout.LeftSubpaneHeight())
 			m.scaleSelector.SetWidth(m.layout.LeftPanesWidth())
@@ -324,11 +403,41 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	logrus.Debugf("Received a message in root: %v", msg)
 	var cmds []tea.Cmd
 	stateChanged := false
+	historyDepth := m.monitorEditor.HistoryDepth()
+
+	// the theme picker is a modal, the views underneath only get the non input messages
+	if m.rootState.State.ThemeSelection {
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			if key.Matches(msg, m.keys.Quit) {
+				return m, tea.Quit
+			}
+			return m, m.themePicker.Update(msg)
+		case tea.MouseMsg:
+			return m, nil
+		}
+	}
 
 	switch msg := msg.(type) {
 	case ConfigReloaded:
 		logrus.Debug("Received config reloaded event in root")
+		// the reloaded config has the picked theme, or the theme file was regenerated
+		if !m.rootState.State.ThemeSelection {
+			m.colors.ClearPreview()
+		}
 		cmds = append(cmds, OperationStatusCmd(OperationNameHDMConfigReloadRequested, nil))
+	case PowerStateChanged:
+		if !msg.simulated && !m.rootState.SetDetectedPowerState(msg.state) {
+			logrus.Debug("Power state is simulated, not passing the detected one")
+			return m, nil
+		}
+		return m, m.broadcastStates(msg)
+	case LidStateChanged:
+		if !msg.simulated && !m.rootState.SetDetectedLidState(msg.state) {
+			logrus.Debug("Lid state is simulated, not passing the detected one")
+			return m, nil
+		}
+		return m, m.broadcastStates(msg)
 	case MonitorBeingEdited:
 		logrus.Debug("Monitor selected event in root")
 		m.rootState.SetMonitorEditState(msg)
@@ -336,6 +445,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// todo move this to the components, let them rely on the index, easier testing ?
 		cmds = append(cmds, m.monitorModes.SetItems(m.rootState.monitors[msg.ListIndex]))
 		cmds = append(cmds, m.monitorMirrors.SetItems(m.rootState.monitors[msg.ListIndex]))
+		cmds = append(cmds, m.monitorAligns.SetItems(m.rootState.monitors[msg.ListIndex]))
+		cmds = append(cmds, m.exactInput.Set(m.rootState.monitors[msg.ListIndex]))
 		cmds = append(cmds, m.scaleSelector.Set(m.rootState.monitors[msg.ListIndex]))
 		cmds = append(cmds, m.colorPicker.SetMonitor(m.rootState.monitors[msg.ListIndex]))
 	case MonitorUnselected:
@@ -343,12 +454,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.rootState.ClearMonitorEditState()
 		cmds = append(cmds, m.monitorModes.ClearItems())
 		cmds = append(cmds, m.monitorMirrors.ClearItems())
+		cmds = append(cmds, m.monitorAligns.ClearItems())
+		cmds = append(cmds, m.exactInput.Unset())
 		cmds = append(cmds, m.scaleSelector.Unset())
 		cmds = append(cmds, m.colorPicker.Unset())
 		stateChanged = true
 	case MoveMonitorCommand:
 		logrus.Debug("Received a monitor move command")
 		cmds = append(cmds, m.monitorEditor.MoveMonitor(msg.MonitorID, msg.StepX, msg.StepY))
+	case DragMonitorCommand:
+		logrus.Debug("Received a monitor drag command")
+		cmds = append(cmds, m.monitorEditor.DragMonitor(msg.MonitorID, msg.X, msg.Y, msg.NewDrag))
+	case SetMonitorPositionCommand:
+		logrus.Debug("Received a monitor position command")
+		cmds = append(cmds, m.monitorEditor.SetPosition(msg.MonitorID, msg.X, msg.Y))
+	case SetMonitorScaleCommand:
+		logrus.Debug("Received a typed monitor scale command")
+		cmds = append(cmds, m.monitorEditor.SetScale(msg.MonitorID, msg.Scale))
+	case SetMonitorModeCommand:
+		logrus.Debug("Received a custom monitor mode command")
+		cmds = append(cmds, m.monitorEditor.SetCustomMode(msg.MonitorID, msg.Mode))
 	case ToggleMonitorCommand:
 		logrus.Debug("Received a monitor toggle command")
 		cmds = append(cmds, m.monitorEditor.ToggleDisable(msg.MonitorID))
@@ -397,29 +522,57 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, m.monitorEditor.SetColorPreset(
 			m.rootState.State.MonitorEditedID, msg.Preset))
 		cmds = append(cmds, m.monitorsList.Update(msg))
-	case CloseMonitorModeListCommand, CloseMonitorMirrorListCommand, CloseColorPickerCommand:
+	case AlignMonitorsCommand:
+		logrus.Debug("Received align monitors")
+		cmds = append(cmds, m.monitorEditor.Align(msg.Action))
+		cmds = append(cmds, m.monitorsList.Update(msg))
+	case CloseMonitorModeListCommand, CloseMonitorMirrorListCommand, CloseColorPickerCommand,
+		CloseMonitorAlignListCommand, CloseExactInputCommand:
 		cmds = append(cmds, m.monitorsList.Update(msg))
 	case ChangeModeCommand:
 		logrus.Debug("Received change for monitor mode")
 		cmds = append(cmds, m.monitorEditor.SetMode(
 			m.rootState.State.MonitorEditedID, msg.Mode))
 		cmds = append(cmds, m.monitorsList.Update(msg))
+	case RenderHDMConfigConfirmationCommand:
+		logrus.Debug("Received render hdm profile confirm")
+		changes, err := m.hyprApply.PreviewGenerate(m.config, msg.profile, m.rootState.monitors,
+			msg.powerState, msg.lidState)
+		if err != nil {
+			cmds = append(cmds, OperationStatusCmd(OperationNameHydrate, err))
+			break
+		}
+		m.showDiffConfirmation("Render the profile to the destination?", changes,
+			RenderHDMConfigCmd(msg.profile, msg.lidState, msg.powerState))
+		stateChanged = true
 	case RenderHDMConfigCommand:
 		logrus.Debug("Received render hdm profile")
 		cmds = append(cmds, m.hyprApply.GenerateThroughHDM(m.config, msg.profile,
 			m.rootState.monitors, msg.powerState, msg.lidState))
 		cmds = append(cmds, m.hdmGeneratevConfigPreview.Update(msg))
+	case CreateNewProfileConfirmationCommand:
+		logrus.Debug("Received create new profile confirm")
+		changes, err := m.hyprApply.PreviewCreateProfile(m.monitorEditor.GetMonitors(), msg.name, msg.file)
+		if err != nil {
+			cmds = append(cmds, OperationStatusCmd(OperationNameCreateProfile, err))
+			break
+		}
+		m.showDiffConfirmation(fmt.Sprintf("Create the %s profile?", msg.name), changes,
+			createNewProfileCmd(msg.name, msg.file))
+		stateChanged = true
 	case CreateNewProfileCommand:
 		logrus.Debug("Received create new profile")
 		cmds = append(cmds, m.hyprApply.CreateProfile(m.monitorEditor.GetMonitors(), msg.name, msg.file))
 		cmds = append(cmds, m.hdm.Update(msg))
 	case EditProfileConfirmationCommand:
 		logrus.Debug("Received edit existing profile confirm")
-		m.confirmationPrompt = NewConfirmationPrompt(
-			fmt.Sprintf("Apply edited settings to %s profile?", msg.name),
-			tea.Batch(toggleConfirmationPromptCmd(), editProfileCmd(msg.name)),
-			toggleConfirmationPromptCmd())
-		m.rootState.ToggleConfirmationPrompt()
+		changes, err := m.hyprApply.PreviewEditProfile(m.monitorEditor.GetMonitors(), msg.name)
+		if err != nil {
+			cmds = append(cmds, OperationStatusCmd(OperationNameEditProfile, err))
+			break
+		}
+		m.showDiffConfirmation(fmt.Sprintf("Apply edited settings to %s profile?", msg.name), changes,
+			editProfileCmd(msg.name))
 		stateChanged = true
 	case EditProfileCommand:
 		logrus.Debug("Received edit existing profile")
@@ -428,9 +581,50 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		logrus.Debug("Profile name requested")
 		m.rootState.ToggleProfileNameRequested()
 		stateChanged = true
+	case ProfileActionConfirmationCommand:
+		logrus.Debug("Received profile action confirm")
+		m.confirmationPrompt = NewConfirmationPrompt(
+			msg.command.Prompt(),
+			tea.Batch(toggleConfirmationPromptCmd(), profileActionCmd(msg.command)),
+			toggleConfirmationPromptCmd(), m.bindings)
+		m.rootState.ToggleConfirmationPrompt()
+		stateChanged = true
+	case ProfileActionCommand:
+		logrus.Debugf("Received profile action %d for %s", msg.action, msg.name)
+		switch msg.action {
+		case ProfileActionDuplicate:
+			cmds = append(cmds, m.hyprApply.DuplicateProfile(msg.name, msg.newName))
+		case ProfileActionRename:
+			cmds = append(cmds, m.hyprApply.RenameProfile(msg.name, msg.newName))
+		case ProfileActionDelete:
+			cmds = append(cmds, m.hyprApply.DeleteProfile(msg.name))
+		case ProfileActionApply:
+			cmds = append(cmds, m.hyprApply.ApplyProfile(m.config, msg.profile, m.rootState.monitors,
+				msg.powerState, msg.lidState))
+		case ProfileActionEditConditions:
+			cmds = append(cmds, m.hyprApply.EditConditions(msg.name, msg.conditions))
+		}
 	case ApplyEphemeralCommand:
 		logrus.Debug("Applying hypr settings")
 		cmds = append(cmds, m.hyprApply.ApplyCurrent(m.monitorEditor.GetMonitors()))
+	case ResetEditsCommand:
+		logrus.Debug("Resetting all edits")
+		cmds = append(cmds, m.monitorEditor.ResetToInitial())
+	case ApplyThemeCommand:
+		logrus.Debugf("Received apply theme %s", msg.Path)
+		// the preview stays until the config is reloaded with the new source
+		m.rootState.ToggleThemeSelection()
+		stateChanged = true
+		cmds = append(cmds, m.hyprApply.SetTheme(msg.Path))
+	case CloseThemePickerCommand:
+		logrus.Debug("Closing theme picker")
+		m.rootState.ToggleThemeSelection()
+		m.colors.ClearPreview()
+		stateChanged = true
+	case OperationStatus:
+		if msg.name == OperationNameSetTheme && msg.IsError() {
+			m.colors.ClearPreview()
+		}
 	case ToggleConfirmationPromptCommand:
 		logrus.Debug("Toggling confirmation prompt")
 		m.rootState.ToggleConfirmationPrompt()
@@ -440,10 +634,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.layout.SetWidth(msg.Width)
 	case tea.KeyMsg:
 		switch {
-		case key.Matches(msg, m.keys.Quit):
+		case key.Matches(msg, m.keys.Quit) && !m.typing(msg):
 			return m, tea.Quit
 		case key.Matches(msg, m.keys.Tab):
-			if !m.rootState.State.ShowConfirmationPrompt {
+			if !m.rootState.State.ShowConfirmationPrompt && !m.profileBrowser.Typing() &&
+				!m.typingExactValue() {
 				m.rootState.NextView()
 				cmds = append(cmds, ViewChangedCmd(m.rootState.CurrentView()))
 			}
@@ -467,7 +662,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
-	if m.rootState.CurrentView() == MonitorsListView {
+	if msg, ok := msg.(tea.KeyMsg); ok && m.canSimulate() {
+		switch {
+		case key.Matches(msg, m.keys.SimulatePower):
+			state, simulated := m.rootState.CyclePowerSimulation()
+			cmds = append(cmds, simulatedPowerStateCmd(state, simulated))
+			stateChanged = true
+		case key.Matches(msg, m.keys.SimulateLid):
+			state, simulated := m.rootState.CycleLidSimulation()
+			cmds = append(cmds, simulatedLidStateCmd(state, simulated))
+			stateChanged = true
+		}
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok && key.Matches(msg, m.keys.PickTheme) && m.canPickTheme() {
+		logrus.Debug("Opening theme picker")
+		m.rootState.ToggleThemeSelection()
+		cmds = append(cmds, m.themePicker.Open())
+		stateChanged = true
+	}
+
+	if m.rootState.CurrentView() == MonitorsListView && !m.typingExactValue() {
 		// nolint:gocritic
 		switch msg := msg.(type) {
 		case tea.KeyMsg:
@@ -493,17 +708,37 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.rootState.ToggleSnapping()
 				m.monitorEditor.SetSnapping(m.rootState.State.Snapping)
 				stateChanged = true
+			case key.Matches(msg, m.keys.Undo):
+				if m.canUseHistory() {
+					cmds = append(cmds, m.monitorEditor.Undo())
+				}
+			case key.Matches(msg, m.keys.Redo):
+				if m.canUseHistory() {
+					cmds = append(cmds, m.monitorEditor.Redo())
+				}
+			case key.Matches(msg, m.keys.ResetEdits):
+				if m.canUseHistory() {
+					m.confirmationPrompt = NewConfirmationPrompt(
+						"Reset all edits to the state at startup?",
+						tea.Batch(toggleConfirmationPromptCmd(), resetEditsCmd()),
+						toggleConfirmationPromptCmd(), m.bindings)
+					m.rootState.ToggleConfirmationPrompt()
+					stateChanged = true
+				}
 			case key.Matches(msg, m.keys.ApplyHypr):
 				logrus.Debug("Toggling confirmationPrompt for hypr apply")
 				m.confirmationPrompt = NewConfirmationPrompt(
 					"Apply hypr settings now?",
 					tea.Batch(toggleConfirmationPromptCmd(), applyEphemeralCmd()),
-					toggleConfirmationPromptCmd())
+					toggleConfirmationPromptCmd(), m.bindings)
 				m.rootState.ToggleConfirmationPrompt()
 				stateChanged = true
 			}
 		}
 	}
+	if depth := m.monitorEditor.HistoryDepth(); depth != historyDepth {
+		cmds = append(cmds, HistoryChangedCmd(depth))
+	}
 	if stateChanged {
 		cmds = append(cmds, func() tea.Msg {
 			return StateChanged{
@@ -523,6 +758,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				case m.rootState.State.MirrorSelection:
 					cmd := m.monitorMirrors.Update(msg)
 					cmds = append(cmds, cmd)
+				case m.rootState.State.AlignSelection:
+					cmds = append(cmds, m.monitorAligns.Update(msg))
+				case m.rootState.State.ExactInput:
+					cmds = append(cmds, m.exactInput.Update(msg))
 				case m.rootState.State.ColorSelection:
 					cmd := m.colorPicker.Update(msg)
 					cmds = append(cmds, cmd)
@@ -546,6 +785,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				cmds = append(cmds, m.hdmProfilePreview.Update(msg))
 				cmds = append(cmds, m.hdmGeneratevConfigPreview.Update(msg))
 			}
+		case ProfilesBrowserView:
+			cmds = append(cmds, m.profileBrowser.Update(msg))
 		}
 	} else {
 		cmds = append(cmds, m.confirmationPrompt.Update(msg))
@@ -557,27 +798,96 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// showDiffConfirmation asks to confirm a write showing what it changes in the files, accepted
+// runs the write
+func (m *Model) showDiffConfirmation(title string, changes []profilemaker.FileChange, accepted tea.Cmd) {
+	label := func(path string) string { return path }
+	if m.runningUnderTest {
+		label = filepath.Base
+	}
+	// the prompt has to close before the write reports back, otherwise the prompt swallows the status
+	m.confirmationPrompt = NewDiffConfirmationPrompt(title, renderDiff(changes, m.colors, label),
+		tea.Sequence(toggleConfirmationPromptCmd(), accepted),
+		toggleConfirmationPromptCmd(), m.bindings)
+	m.rootState.ToggleConfirmationPrompt()
+}
+
+// typing lets printable quit keys through to the profile name, conditions and exact value
+// inputs, ctrl+c still quits
+func (m *Model) typing(msg tea.KeyMsg) bool {
+	typing := (m.rootState.CurrentView() == ProfilesBrowserView && m.profileBrowser.Typing()) ||
+		m.typingExactValue()
+	return typing && msg.Type == tea.KeyRunes
+}
+
+// typingExactValue keeps the monitor view keys from firing while a value is typed
+func (m *Model) typingExactValue() bool {
+	return m.rootState.CurrentView() == MonitorsListView && m.rootState.State.ExactInput &&
+		!m.rootState.State.ShowConfirmationPrompt
+}
+
+// canSimulate limits the power and lid simulation, and the theme picker, to the profile views when
+// nothing is typed
+func (m *Model) canSimulate() bool {
+	view := m.rootState.CurrentView()
+	return (view == ProfileView || view == ProfilesBrowserView) && !m.rootState.State.ShowConfirmationPrompt &&
+		!m.rootState.State.ProfileNameRequested && !m.profileBrowser.Typing()
+}
+
+// canPickTheme is true in every view unless a picker, a prompt or a text input takes the keys
+func (m *Model) canPickTheme() bool {
+	state := m.rootState.State
+	if m.rootState.CurrentView() == MonitorsListView {
+		return m.canUseHistory()
+	}
+	return !state.ShowConfirmationPrompt && !state.ProfileNameRequested && !m.profileBrowser.Typing()
+}
+
+// broadcastStates passes a power or lid state change to every component matching profiles, not
+// only the ones of the current view
+func (m *Model) broadcastStates(msg tea.Msg) tea.Cmd {
+	if m.config == nil {
+		return nil
+	}
+	return tea.Batch(m.hdm.Update(msg), m.hdmProfilePreview.Update(msg), m.profileBrowser.Update(msg))
+}
+
+// canUseHistory is false while a picker previews a value, undoing under it would desync the picker
+func (m *Model) canUseHistory() bool {
+	state := m.rootState.State
+	return !state.ShowConfirmationPrompt && !state.ModeSelection && !state.MirrorSelection &&
+		!state.ColorSelection && !state.Scaling && !state.AlignSelection && !state.ExactInput
+}
+
 func (m *Model) GlobalHelp() []key.Binding {
 	bindings := []key.Binding{}
 	if m.rootState.HasMoreThanOneView() {
-		bindings = append(bindings, rootKeyMap.Tab)
+		bindings = append(bindings, m.keys.Tab)
 	}
 	if m.rootState.CurrentView() == MonitorsListView {
 		monitors := []key.Binding{
-			rootKeyMap.Pan,
-			rootKeyMap.Fullscreen, rootKeyMap.FollowMonitor, rootKeyMap.Center, rootKeyMap.ZoomIn, rootKeyMap.ZoomOut,
-			rootKeyMap.ToggleSnapping, rootKeyMap.ApplyHypr,
-			rootKeyMap.ExpandHyprPreview, rootKeyMap.ResetZoom, rootKeyMap.FitMonitors,
+			m.keys.Pan,
+			m.keys.Fullscreen, m.keys.FollowMonitor, m.keys.Center, m.keys.ZoomIn, m.keys.ZoomOut,
+			m.keys.ToggleSnapping, m.keys.ApplyHypr,
+			m.keys.ExpandHyprPreview, m.keys.ResetZoom, m.keys.FitMonitors,
+			m.keys.Undo, m.keys.Redo, m.keys.ResetEdits, m.keys.PickTheme,
 		}
 		bindings = append(bindings, monitors...)
 
 	}
 	if m.rootState.CurrentView() == ProfileView {
 		profile := []key.Binding{
-			rootKeyMap.EditHDMConfig,
-			rootKeyMap.EditHyprGeneratedConfig,
+			m.keys.EditHDMConfig,
+			m.keys.EditHyprGeneratedConfig,
+			m.keys.SimulatePower,
+			m.keys.SimulateLid,
+			m.keys.PickTheme,
 		}
 		bindings = append(bindings, profile...)
 	}
+	if m.rootState.CurrentView() == ProfilesBrowserView {
+		bindings = append(bindings, m.keys.ZoomIn, m.keys.ZoomOut, m.keys.ResetZoom,
+			m.keys.FitMonitors, m.keys.SimulatePower, m.keys.SimulateLid, m.keys.PickTheme)
+	}
 	return bindings
 }
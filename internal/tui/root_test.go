@@ -41,6 +41,18 @@ var (
 )
 
 func TestModel_Update_UserFlows(t *testing.T) {
+	bundledThemes, err := filepath.Abs("../../themes/static")
+	require.NoError(t, err)
+	previousThemeDirs := tui.BundledThemeDirs
+	tui.BundledThemeDirs = []string{bundledThemes}
+	t.Cleanup(func() { tui.BundledThemeDirs = previousThemeDirs })
+	themesConfigDir := t.TempDir()
+	// nolint:gosec
+	userTheme, err := os.ReadFile(filepath.Join(bundledThemes, "nord", "theme.toml"))
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(themesConfigDir, "themes"), 0o750))
+	require.NoError(t, utils.WriteAtomic(filepath.Join(themesConfigDir, "themes", "mytheme.toml"), userTheme))
+
 	tests := []struct {
 		name                string
 		cfg                 *config.Config
@@ -750,6 +762,183 @@ func TestModel_Update_UserFlows(t *testing.T) {
 			steps:        []step{},
 		},
 
+		{
+			name:         "mouse_drag",
+			monitorsData: defaultMonitorData,
+			runFor:       utils.JustPtr(500 * time.Millisecond),
+			steps: []step{
+				{
+					msg: tea.MouseMsg{
+						X: 79, Y: 15, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress,
+					},
+					expectOutputToContain: "EDITING",
+				},
+				{
+					msg: tea.MouseMsg{
+						X: 70, Y: 12, Button: tea.MouseButtonLeft, Action: tea.MouseActionMotion,
+					},
+					expectOutputToContain: "Position: -",
+				},
+				{
+					msg: tea.MouseMsg{
+						X: 70, Y: 12, Button: tea.MouseButtonLeft, Action: tea.MouseActionRelease,
+					},
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}},
+					expectOutputToContain: "Position: 0,0",
+				},
+			},
+		},
+
+		{
+			name:         "align",
+			monitorsData: twoMonitorsData,
+			runFor:       utils.JustPtr(600 * time.Millisecond),
+			steps: []step{
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyEnter},
+					expectOutputToContain: "EDITING",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}},
+					expectOutputToContain: "Align the monitor",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}},
+					times:                 utils.IntPtr(3),
+					expectOutputToContain: "► Center on the right of DP-1",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyEnter},
+					expectOutputToContain: "Position: 3072,384",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}},
+					expectOutputToContain: "[ALIGN]",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}},
+					times:                 utils.IntPtr(8),
+					expectOutputToContain: "► Arrange all top to bottom",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyEnter},
+					expectOutputToContain: "Position: 0,1728",
+				},
+			},
+		},
+
+		{
+			name:         "exact_input",
+			monitorsData: twoMonitorsData,
+			runFor:       utils.JustPtr(1500 * time.Millisecond),
+			steps: []step{
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyEnter},
+					expectOutputToContain: "EDITING",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}},
+					expectOutputToContain: "Type exact values",
+				},
+				{
+					msg:   tea.KeyMsg{Type: tea.KeyBackspace},
+					times: utils.IntPtr(10),
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("-1440x0")},
+					expectOutputToContain: "> -1440x0",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyEnter},
+					expectOutputToContain: "Position: -1440,0",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyTab},
+					expectOutputToContain: "[Scale]",
+				},
+				{
+					msg:   tea.KeyMsg{Type: tea.KeyBackspace},
+					times: utils.IntPtr(10),
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1.3")},
+					expectOutputToContain: "> 1.3",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyEnter},
+					expectOutputToContain: "Scale: 1.2800",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyTab},
+					expectOutputToContain: "[Mode]",
+				},
+				{
+					msg:   tea.KeyMsg{Type: tea.KeyBackspace},
+					times: utils.IntPtr(20),
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2560x1600@75")},
+					expectOutputToContain: "> 2560x1600@75",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyEnter},
+					expectOutputToContain: "not an available mode",
+				},
+			},
+		},
+
+		{
+			name:         "custom_keys",
+			monitorsData: twoMonitorsData,
+			runFor:       utils.JustPtr(600 * time.Millisecond),
+			cfg: testutils.NewTestConfig(t).WithKeys(map[string][]string{
+				"rotate": {"t"},
+				"undo":   {"r"},
+				"down":   {"down", "J"},
+			}).Get(),
+			steps: []step{
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyEnter},
+					expectOutputToContain: "t rotate",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}},
+					expectOutputToContain: "Rotation: 90°",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}},
+					expectOutputToContain: "Undo: success",
+				},
+			},
+		},
+
+		{
+			name:         "mouse_zoom_pan",
+			monitorsData: defaultMonitorData,
+			runFor:       utils.JustPtr(500 * time.Millisecond),
+			steps: []step{
+				{
+					msg: tea.MouseMsg{
+						X: 79, Y: 15, Button: tea.MouseButtonWheelUp, Action: tea.MouseActionPress,
+					},
+					expectOutputToContain: "Virtual Area: 11170x11170",
+				},
+				{
+					msg: tea.MouseMsg{
+						X: 100, Y: 20, Button: tea.MouseButtonMiddle, Action: tea.MouseActionPress,
+					},
+				},
+				{
+					msg: tea.MouseMsg{
+						X: 90, Y: 20, Button: tea.MouseButtonMiddle, Action: tea.MouseActionMotion,
+					},
+					expectOutputToContain: "Center: (",
+				},
+			},
+		},
+
 		{
 			name:         "zoom_out",
 			monitorsData: defaultMonitorData,
@@ -905,7 +1094,11 @@ func TestModel_Update_UserFlows(t *testing.T) {
 					expectOutputToContain: "h",
 				},
 				{
-					msg:        tea.KeyMsg{Type: tea.KeyEnter},
+					msg:                   tea.KeyMsg{Type: tea.KeyEnter},
+					expectOutputToContain: "Create the h profile?",
+				},
+				{
+					msg:        tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Y'}},
 					sleepAfter: utils.JustPtr(100 * time.Millisecond),
 					// mimic reload in the outer process
 					validateSideEffects: func(cfg *config.Config) {
@@ -936,7 +1129,7 @@ func TestModel_Update_UserFlows(t *testing.T) {
 		{
 			name:         "new_profile",
 			monitorsData: defaultMonitorData,
-			runFor:       utils.JustPtr(800 * time.Millisecond),
+			runFor:       utils.JustPtr(1100 * time.Millisecond),
 			cfg:          testutils.NewTestConfig(t).Get(),
 			steps: []step{
 				{
@@ -952,7 +1145,11 @@ func TestModel_Update_UserFlows(t *testing.T) {
 					expectOutputToContain: "h",
 				},
 				{
-					msg:        tea.KeyMsg{Type: tea.KeyEnter},
+					msg:                   tea.KeyMsg{Type: tea.KeyEnter},
+					expectOutputToContain: "Create the h profile?",
+				},
+				{
+					msg:        tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Y'}},
 					sleepAfter: utils.JustPtr(100 * time.Millisecond),
 					// mimic reload in the outer process
 					validateSideEffects: func(cfg *config.Config) {
@@ -1002,7 +1199,7 @@ func TestModel_Update_UserFlows(t *testing.T) {
 		{
 			name:         "new_prof_render",
 			monitorsData: defaultMonitorData,
-			runFor:       utils.JustPtr(800 * time.Millisecond),
+			runFor:       utils.JustPtr(1100 * time.Millisecond),
 			cfg:          testutils.NewTestConfig(t).Get(),
 			steps: []step{
 				{
@@ -1018,7 +1215,11 @@ func TestModel_Update_UserFlows(t *testing.T) {
 					expectOutputToContain: "h",
 				},
 				{
-					msg:        tea.KeyMsg{Type: tea.KeyEnter},
+					msg:                   tea.KeyMsg{Type: tea.KeyEnter},
+					expectOutputToContain: "Create the h profile?",
+				},
+				{
+					msg:        tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Y'}},
 					sleepAfter: utils.JustPtr(100 * time.Millisecond),
 					validateSideEffects: func(cfg *config.Config) {
 						require.NoError(t, cfg.Reload())
@@ -1028,7 +1229,11 @@ func TestModel_Update_UserFlows(t *testing.T) {
 					msg: tui.ConfigReloaded{},
 				},
 				{
-					msg:        tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}},
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}},
+					expectOutputToContain: "Render the profile to the destination?",
+				},
+				{
+					msg:        tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Y'}},
 					sleepAfter: utils.JustPtr(200 * time.Millisecond),
 				},
 			},
@@ -1178,9 +1383,10 @@ func TestModel_Update_UserFlows(t *testing.T) {
 					msg:                   tui.ConfigReloaded{},
 					expectOutputToContain: "TUI AUTO START",
 				},
-				// go back to editing
+				// go back to editing, through the profiles view
 				{
 					msg:                   tea.KeyMsg{Type: tea.KeyTab},
+					times:                 utils.IntPtr(2),
 					expectOutputToContain: "► eDP-1 (BOE NE135A1M-NY...)",
 				},
 				{
@@ -1223,6 +1429,322 @@ func TestModel_Update_UserFlows(t *testing.T) {
 				},
 			},
 		},
+
+		{
+			name:         "matching_profile_diff",
+			monitorsData: twoMonitorsData,
+			runFor:       utils.JustPtr(1100 * time.Millisecond),
+			cfg: testutils.NewTestConfig(t).WithProfiles(map[string]*config.Profile{
+				"two": {
+					ConfigType: utils.JustPtr(config.Template),
+					Conditions: &config.ProfileCondition{
+						RequiredMonitors: []*config.RequiredMonitor{
+							{
+								Description: utils.StringPtr("BOE NE135A1M-NY1"),
+							},
+							{
+								Description: utils.StringPtr("Dell Inc. DELL U2723QE 5YNK3H3"),
+							},
+						},
+					},
+				},
+			}).Get(),
+			steps: []step{
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyTab},
+					expectOutputToContain: "Profile: two",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}},
+					expectOutputToContain: "+# <<<<< TUI AUTO START",
+				},
+				{
+					msg:        tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Y'}},
+					sleepAfter: utils.JustPtr(100 * time.Millisecond),
+					validateSideEffects: func(cfg *config.Config) {
+						require.NoError(t, cfg.Reload())
+					},
+				},
+				{
+					msg:                   tui.ConfigReloaded{},
+					expectOutputToContain: "TUI AUTO START",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyTab},
+					times:                 utils.IntPtr(2),
+					expectOutputToContain: "► eDP-1 (BOE NE135A1M-NY...)",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyEnter},
+					expectOutputToContain: "► eDP-1 (BOE NE135A1M-NY...) [EDITING]",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}},
+					times:                 utils.IntPtr(2),
+					expectOutputToContain: "Position: 2020,1080",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyEnter},
+					expectOutputToContain: "► eDP-1 (BOE NE135A1M-NY...)",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyTab},
+					expectOutputToContain: "Profile: two",
+				},
+				// only the moved monitor line changes
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}},
+					expectOutputToContain: "2020x1080",
+				},
+			},
+		},
+
+		{
+			name:         "profiles_browser",
+			monitorsData: twoMonitorsData,
+			runFor:       utils.JustPtr(700 * time.Millisecond),
+			cfg: testutils.NewTestConfig(t).WithProfiles(map[string]*config.Profile{
+				"docked": {
+					ConfigType: utils.JustPtr(config.Static),
+					Conditions: &config.ProfileCondition{
+						RequiredMonitors: []*config.RequiredMonitor{
+							{
+								Description: utils.StringPtr("BOE NE135A1M-NY1"),
+							},
+							{
+								Description: utils.StringPtr("Dell Inc. DELL U2723QE 5YNK3H3"),
+							},
+						},
+					},
+				},
+				"laptop": {
+					ConfigType: utils.JustPtr(config.Static),
+					Conditions: &config.ProfileCondition{
+						PowerState: utils.JustPtr(config.BAT),
+						RequiredMonitors: []*config.RequiredMonitor{
+							{
+								Name: utils.StringPtr("eDP-1"),
+							},
+						},
+					},
+				},
+			}).FillProfileConfigFile("docked", "testdata/profiles/docked.conf").Get(),
+			steps: []step{
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyTab},
+					times:                 utils.IntPtr(2),
+					expectOutputToContain: "● active",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}},
+					expectOutputToContain: "no match",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}},
+					expectOutputToContain: "Delete laptop profile?",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}},
+					expectOutputToContain: "Layout Preview",
+				},
+			},
+		},
+		{
+			name:         "profiles_browser_conditions",
+			monitorsData: twoMonitorsData,
+			runFor:       utils.JustPtr(900 * time.Millisecond),
+			cfg: testutils.NewTestConfig(t).WithProfiles(map[string]*config.Profile{
+				"laptop": {
+					ConfigType: utils.JustPtr(config.Static),
+					Conditions: &config.ProfileCondition{
+						PowerState: utils.JustPtr(config.BAT),
+						RequiredMonitors: []*config.RequiredMonitor{
+							{
+								Name:       utils.StringPtr("eDP-1"),
+								MonitorTag: utils.StringPtr("laptop"),
+							},
+						},
+					},
+				},
+			}).Get(),
+			steps: []step{
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyTab},
+					times:                 utils.IntPtr(2),
+					expectOutputToContain: "no match",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}},
+					expectOutputToContain: "Conditions of laptop",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}},
+					expectOutputToContain: "[ ] DP-1",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}},
+					expectOutputToContain: "[x] DP-1",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}},
+					expectOutputToContain: "power_state: BAT",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}},
+					expectOutputToContain: "power_state: any",
+				},
+				{
+					msg:        tea.KeyMsg{Type: tea.KeyEnter},
+					sleepAfter: utils.JustPtr(100 * time.Millisecond),
+					// mimic reload in the outer process
+					validateSideEffects: func(cfg *config.Config) {
+						require.NoError(t, cfg.Reload())
+						profile := cfg.Get().Profiles["laptop"]
+						assert.Nil(t, profile.Conditions.PowerState)
+						assert.Equal(t, []*config.RequiredMonitor{
+							{
+								Name:                       utils.JustPtr("eDP-1"),
+								MonitorTag:                 utils.JustPtr("laptop"),
+								MatchDescriptionUsingRegex: utils.JustPtr(false),
+								MatchNameUsingRegex:        utils.JustPtr(false),
+							},
+							{
+								Description:                utils.JustPtr("Dell Inc. DELL U2723QE 5YNK3H3"),
+								MatchDescriptionUsingRegex: utils.JustPtr(false),
+								MatchNameUsingRegex:        utils.JustPtr(false),
+							},
+						}, profile.Conditions.RequiredMonitors)
+					},
+				},
+				// mimic config reload send event
+				{
+					msg:                   tui.ConfigReloaded{},
+					sleepAfter:            utils.JustPtr(200 * time.Millisecond),
+					expectOutputToContain: "2 monitors",
+				},
+			},
+		},
+		{
+			name:         "simulate_power_state",
+			monitorsData: twoMonitorsData,
+			powerState:   power.ACPowerState,
+			lidState:     power.OpenedLidState,
+			runFor:       utils.JustPtr(700 * time.Millisecond),
+			cfg: testutils.NewTestConfig(t).WithProfiles(map[string]*config.Profile{
+				"desk": {
+					ConfigType: utils.JustPtr(config.Template),
+					Conditions: &config.ProfileCondition{
+						PowerState: utils.JustPtr(config.AC),
+						RequiredMonitors: []*config.RequiredMonitor{
+							{
+								Description: utils.StringPtr("Dell Inc. DELL U2723QE 5YNK3H3"),
+							},
+						},
+					},
+				},
+				"mobile": {
+					ConfigType: utils.JustPtr(config.Static),
+					Conditions: &config.ProfileCondition{
+						PowerState: utils.JustPtr(config.BAT),
+						RequiredMonitors: []*config.RequiredMonitor{
+							{
+								Description: utils.StringPtr("Dell Inc. DELL U2723QE 5YNK3H3"),
+							},
+						},
+					},
+				},
+			}).FillProfileConfigFile("desk", "testdata/profiles/power.go.tmpl").
+				FillProfileConfigFile("mobile", "testdata/profiles/docked.conf").Get(),
+			steps: []step{
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyTab},
+					expectOutputToContain: "desk",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}},
+					expectOutputToContain: "rendered for power=AC lid=Opened",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}},
+					expectOutputToContain: "3840x2160@60",
+				},
+				// detected events are ignored while simulating
+				{
+					msg: tui.PowerStateChangedCmd(power.ACPowerState),
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}},
+					expectOutputToContain: "Simulating power=BAT lid=Opened",
+				},
+			},
+		},
+		{
+			name:         "theme_picker",
+			monitorsData: twoMonitorsData,
+			runFor:       utils.JustPtr(700 * time.Millisecond),
+			cfg:          testutils.NewTestConfig(t).WithConfigDir(themesConfigDir).Get(),
+			steps: []step{
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyTab},
+					expectOutputToContain: "ctrl+t theme",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyCtrlT},
+					expectOutputToContain: "Pick a theme",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}},
+					expectOutputToContain: "► mytheme",
+				},
+			},
+		},
+		{
+			name:         "theme_picker_monitors_view",
+			monitorsData: twoMonitorsData,
+			runFor:       utils.JustPtr(700 * time.Millisecond),
+			cfg:          testutils.NewTestConfig(t).WithConfigDir(themesConfigDir).Get(),
+			steps: []step{
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyCtrlT},
+					expectOutputToContain: "Pick a theme",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyEsc},
+					expectOutputToContain: "enter edit a monitor",
+				},
+			},
+		},
+		{
+			name:         "theme_picker_apply",
+			monitorsData: twoMonitorsData,
+			runFor:       utils.JustPtr(700 * time.Millisecond),
+			cfg:          testutils.NewTestConfig(t).WithConfigDir(t.TempDir()).Get(),
+			steps: []step{
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyTab},
+					times:                 utils.IntPtr(2),
+					expectOutputToContain: "ctrl+t theme",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyCtrlT},
+					expectOutputToContain: "Pick a theme",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}},
+					expectOutputToContain: "► catppuccin-mocha",
+				},
+				{
+					msg:                   tea.KeyMsg{Type: tea.KeyEnter},
+					expectOutputToContain: "Set Theme: success",
+					// mimic reload in the outer process
+					validateSideEffects: func(cfg *config.Config) {
+						require.NoError(t, cfg.Reload())
+						assert.Equal(t, filepath.Join(bundledThemes, "catppuccin-mocha", "theme.toml"),
+							*cfg.Get().TUISection.Colors.SourceFile)
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -56,7 +56,26 @@ type ScaleSelector struct {
 	colors               *ColorsManager
 }
 
-func NewScaleSelector(colors *ColorsManager) *ScaleSelector {
+func newScaleSelectorKeyMap(keys actionKeys) scaleSelectorKeyMap {
+	return scaleSelectorKeyMap{
+		Up:   keys.binding("scale_up", "up/k", "increase"),
+		Down: keys.binding("scale_down", "down/j", "decrease"),
+		Select: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "select"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back"),
+		),
+		EnableScaleSnapping: keys.binding("scale_snapping", "e", "enable/disable scale snapping"),
+		One:                 keys.binding("scale_one", "1", "1.00x"),
+		Two:                 keys.binding("scale_two", "2", "2.00x"),
+		Custom:              keys.binding("scale_custom", "C", "custom"),
+	}
+}
+
+func NewScaleSelector(colors *ColorsManager, keys *KeyBindings) *ScaleSelector {
 	ti := textinput.New()
 	ti.Placeholder = "Enter scale (e.g., 1.5)"
 	ti.CharLimit = 12
@@ -71,40 +90,7 @@ func NewScaleSelector(colors *ColorsManager) *ScaleSelector {
 		customInput:         ti,
 		customInputMode:     false,
 		colors:              colors,
-		keyMap: &scaleSelectorKeyMap{
-			Up: key.NewBinding(
-				key.WithKeys("up", "k"),
-				key.WithHelp("up/k", "increase"),
-			),
-			Down: key.NewBinding(
-				key.WithKeys("down", "j"),
-				key.WithHelp("down/j", "decrease"),
-			),
-			Select: key.NewBinding(
-				key.WithKeys("enter"),
-				key.WithHelp("enter", "select"),
-			),
-			Back: key.NewBinding(
-				key.WithKeys("esc"),
-				key.WithHelp("esc", "back"),
-			),
-			EnableScaleSnapping: key.NewBinding(
-				key.WithKeys("e"),
-				key.WithHelp("e", "enable/disable scale snapping"),
-			),
-			One: key.NewBinding(
-				key.WithKeys("1"),
-				key.WithHelp("1", "1.00x"),
-			),
-			Two: key.NewBinding(
-				key.WithKeys("2"),
-				key.WithHelp("2", "2.00x"),
-			),
-			Custom: key.NewBinding(
-				key.WithKeys("C"),
-				key.WithHelp("C", "custom"),
-			),
-		},
+		keyMap:              &keys.scale,
 	}
 }
 
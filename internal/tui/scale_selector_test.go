@@ -125,7 +125,7 @@ func TestScaleSelector_Update(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			cfg := testutils.NewTestConfig(t).Get()
 			colors := tui.NewColorsManager(cfg)
-			selector := tui.NewScaleSelector(colors)
+			selector := tui.NewScaleSelector(colors, tui.NewKeyBindings(cfg))
 			selector.Set(tc.setupMonitor)
 
 			cmd := selector.Update(tc.message)
@@ -163,7 +163,7 @@ func TestScaleSelector_AcceleratedScaling(t *testing.T) {
 	}
 	cfg := testutils.NewTestConfig(t).Get()
 	colors := tui.NewColorsManager(cfg)
-	selector := tui.NewScaleSelector(colors)
+	selector := tui.NewScaleSelector(colors, tui.NewKeyBindings(cfg))
 	selector.Set(monitor)
 
 	selector.Update(tea.KeyMsg{Type: tea.KeyUp})
@@ -202,7 +202,7 @@ func TestScaleSelector_DPICalculation(t *testing.T) {
 
 			cfg := testutils.NewTestConfig(t).Get()
 			colors := tui.NewColorsManager(cfg)
-			selector := tui.NewScaleSelector(colors)
+			selector := tui.NewScaleSelector(colors, tui.NewKeyBindings(cfg))
 			selector.Set(monitor)
 
 			view := selector.View()
@@ -240,7 +240,7 @@ func TestScaleSelector_CustomInput(t *testing.T) {
 
 			cfg := testutils.NewTestConfig(t).Get()
 			colors := tui.NewColorsManager(cfg)
-			selector := tui.NewScaleSelector(colors)
+			selector := tui.NewScaleSelector(colors, tui.NewKeyBindings(cfg))
 			selector.Set(monitor)
 
 			// Trigger custom input mode
@@ -4,6 +4,8 @@ import (
 	"strings"
 
 	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/power"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
 )
 
 type ViewMode int
@@ -11,6 +13,7 @@ type ViewMode int
 const (
 	MonitorsListView ViewMode = iota
 	ProfileView
+	ProfilesBrowserView
 )
 
 func (v ViewMode) String() string {
@@ -19,6 +22,8 @@ func (v ViewMode) String() string {
 		return "Monitors"
 	case ProfileView:
 		return "Profile"
+	case ProfilesBrowserView:
+		return "Profiles"
 	default:
 		return "Unknown"
 	}
@@ -31,14 +36,19 @@ type AppState struct {
 	ModeSelection          bool
 	MirrorSelection        bool
 	ColorSelection         bool
+	AlignSelection         bool
+	ExactInput             bool
 	Fullscreen             bool
 	MonitorEditedListIndex int
 	MonitorEditedID        int
 	Snapping               bool
 	ProfileNameRequested   bool
 	ShowConfirmationPrompt bool
+	ThemeSelection         bool
 	MonitorFollowMode      bool
 	ExpandHyprPreview      bool
+	// Simulation describes the overridden power and lid state, empty when following the detectors
+	Simulation string
 }
 
 func (s AppState) String(currentView ViewMode) string {
@@ -49,11 +59,15 @@ func (s AppState) String(currentView ViewMode) string {
 	if s.Panning && currentView == MonitorsListView {
 		modes = append(modes, "Panning")
 	}
+	if s.Simulation != "" && currentView != MonitorsListView {
+		modes = append(modes, "Simulating "+s.Simulation)
+	}
 	return strings.Join(modes, " ")
 }
 
 func (s AppState) Editing() bool {
-	return s.EditingMonitor || s.Fullscreen || s.ModeSelection || s.Scaling || s.Panning || s.MirrorSelection
+	return s.EditingMonitor || s.Fullscreen || s.ModeSelection || s.Scaling || s.Panning || s.MirrorSelection ||
+		s.AlignSelection || s.ExactInput
 }
 
 func (s AppState) IsPanning() bool {
@@ -66,21 +80,31 @@ type RootState struct {
 	monitors         []*MonitorSpec
 	viewModes        []ViewMode
 	config           *config.Config
+	// detected states as reported by the daemon detectors
+	powerState power.PowerState
+	lidState   power.LidState
+	// simulated states override the detected ones, nil when not simulated
+	simulatedPower *power.PowerState
+	simulatedLid   *power.LidState
 }
 
-func NewState(monitors []*MonitorSpec, cfg *config.Config) *RootState {
+func NewState(monitors []*MonitorSpec, cfg *config.Config, powerState power.PowerState,
+	lidState power.LidState,
+) *RootState {
 	viewModes := []ViewMode{MonitorsListView}
 	if cfg != nil {
-		viewModes = append(viewModes, ProfileView)
+		viewModes = append(viewModes, ProfileView, ProfilesBrowserView)
 	}
 	return &RootState{
 		CurrentViewIndex: 0,
 		State: AppState{
 			Snapping: true,
 		},
-		monitors:  monitors,
-		config:    cfg,
-		viewModes: viewModes,
+		monitors:   monitors,
+		config:     cfg,
+		viewModes:  viewModes,
+		powerState: powerState,
+		lidState:   lidState,
 	}
 }
 
@@ -104,6 +128,10 @@ func (r *RootState) ToggleConfirmationPrompt() {
 	r.State.ShowConfirmationPrompt = !r.State.ShowConfirmationPrompt
 }
 
+func (r *RootState) ToggleThemeSelection() {
+	r.State.ThemeSelection = !r.State.ThemeSelection
+}
+
 func (r *RootState) ToggleFollowMonitorMode() {
 	r.State.MonitorFollowMode = !r.State.MonitorFollowMode
 }
@@ -120,6 +148,8 @@ func (r *RootState) SetMonitorEditState(msg MonitorBeingEdited) {
 	r.State.MonitorEditedID = msg.MonitorID
 	r.State.MirrorSelection = msg.MirroringMode
 	r.State.ColorSelection = msg.ColorSelection
+	r.State.AlignSelection = msg.AlignSelection
+	r.State.ExactInput = msg.ExactInput
 }
 
 func (r *RootState) ClearMonitorEditState() {
@@ -129,6 +159,8 @@ func (r *RootState) ClearMonitorEditState() {
 	r.State.MonitorEditedListIndex = -1
 	r.State.MirrorSelection = false
 	r.State.ColorSelection = false
+	r.State.AlignSelection = false
+	r.State.ExactInput = false
 	r.State.MonitorEditedID = -1
 }
 
@@ -143,3 +175,70 @@ func (r *RootState) NextView() {
 func (r *RootState) HasMoreThanOneView() bool {
 	return len(r.viewModes) > 1
 }
+
+// SetDetectedPowerState records the detector state, false when a simulation hides it
+func (r *RootState) SetDetectedPowerState(state power.PowerState) bool {
+	r.powerState = state
+	return r.simulatedPower == nil
+}
+
+// SetDetectedLidState records the detector state, false when a simulation hides it
+func (r *RootState) SetDetectedLidState(state power.LidState) bool {
+	r.lidState = state
+	return r.simulatedLid == nil
+}
+
+// CyclePowerSimulation goes through AC, BAT and back to the detected state
+func (r *RootState) CyclePowerSimulation() (power.PowerState, bool) {
+	switch {
+	case r.simulatedPower == nil:
+		r.simulatedPower = utils.JustPtr(power.ACPowerState)
+	case *r.simulatedPower == power.ACPowerState:
+		r.simulatedPower = utils.JustPtr(power.BatteryPowerState)
+	default:
+		r.simulatedPower = nil
+	}
+	r.State.Simulation = r.simulation()
+	return r.PowerState(), r.simulatedPower != nil
+}
+
+// CycleLidSimulation goes through Opened, Closed and back to the detected state
+func (r *RootState) CycleLidSimulation() (power.LidState, bool) {
+	switch {
+	case r.simulatedLid == nil:
+		r.simulatedLid = utils.JustPtr(power.OpenedLidState)
+	case *r.simulatedLid == power.OpenedLidState:
+		r.simulatedLid = utils.JustPtr(power.ClosedLidState)
+	default:
+		r.simulatedLid = nil
+	}
+	r.State.Simulation = r.simulation()
+	return r.LidState(), r.simulatedLid != nil
+}
+
+// PowerState is the state profiles are matched against, simulated or detected
+func (r *RootState) PowerState() power.PowerState {
+	if r.simulatedPower != nil {
+		return *r.simulatedPower
+	}
+	return r.powerState
+}
+
+// LidState is the state profiles are matched against, simulated or detected
+func (r *RootState) LidState() power.LidState {
+	if r.simulatedLid != nil {
+		return *r.simulatedLid
+	}
+	return r.lidState
+}
+
+func (r *RootState) simulation() string {
+	parts := []string{}
+	if r.simulatedPower != nil {
+		parts = append(parts, "power="+r.simulatedPower.String())
+	}
+	if r.simulatedLid != nil {
+		parts = append(parts, "lid="+r.simulatedLid.String())
+	}
+	return strings.Join(parts, " ")
+}
@@ -7,6 +7,8 @@ import (
 
 type ColorsManager struct {
 	cfg *config.Config
+	// preview overrides the configured colors while a theme is being picked
+	preview *config.TUIColors
 }
 
 func NewColorsManager(cfg *config.Config) *ColorsManager {
@@ -16,9 +18,31 @@ func NewColorsManager(cfg *config.Config) *ColorsManager {
 }
 
 func (c *ColorsManager) colors() *config.TUIColors {
+	if c.preview != nil {
+		return c.preview
+	}
 	return c.cfg.Get().TUISection.Colors
 }
 
+// Preview renders everything with the given colors until ClearPreview is called.
+func (c *ColorsManager) Preview(colors *config.TUIColors) {
+	c.preview = colors
+}
+
+// ClearPreview goes back to the colors from the configuration.
+func (c *ColorsManager) ClearPreview() {
+	c.preview = nil
+}
+
+// SourceFile returns the theme file the configured colors are sourced from, if any.
+func (c *ColorsManager) SourceFile() string {
+	colors := c.cfg.Get().TUISection.Colors
+	if colors == nil || colors.SourceFile == nil {
+		return ""
+	}
+	return *colors.SourceFile
+}
+
 // HelpKeyStyle returns the style for help key text.
 func (c *ColorsManager) HelpKeyStyle() lipgloss.Style {
 	return lipgloss.NewStyle().Foreground(lipgloss.Color(*c.colors().HelpKeyColor))
@@ -311,3 +335,15 @@ func (c *ColorsManager) GetMonitorBottomColor(color string) string {
 	// Fallback if color not found
 	return "255" // White as fallback
 }
+
+// DiffAddedStyle returns the style for added lines in diffs.
+func (c *ColorsManager) DiffAddedStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color(*c.colors().SuccessColor))
+}
+
+// DiffRemovedStyle returns the style for removed lines in diffs.
+func (c *ColorsManager) DiffRemovedStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color(*c.colors().ErrorColor))
+}
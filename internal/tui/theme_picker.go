@@ -0,0 +1,315 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/sirupsen/logrus"
+)
+
+// BundledThemeDirs hold the themes installed by the packages, one <name>/theme.toml per theme
+var BundledThemeDirs = []string{"/usr/share/hyprdynamicmonitors/themes/static"}
+
+type ThemeKind int
+
+const (
+	BundledTheme ThemeKind = iota
+	UserTheme
+	// CurrentTheme is the configured source when it is neither bundled nor in the themes directory
+	CurrentTheme
+)
+
+func (k ThemeKind) String() string {
+	switch k {
+	case BundledTheme:
+		return "bundled"
+	case UserTheme:
+		return "user"
+	case CurrentTheme:
+		return "current"
+	default:
+		return "unknown"
+	}
+}
+
+type ThemeItem struct {
+	Name string
+	Path string
+	Kind ThemeKind
+}
+
+func (t ThemeItem) FilterValue() string {
+	return t.Name
+}
+
+func (t ThemeItem) View() string {
+	return t.Name
+}
+
+// DiscoverThemes lists the bundled themes, the ones copied to <configDir>/themes/static and the
+// user themes in <configDir>/themes/*.toml, current is listed too when it is none of them
+func DiscoverThemes(configDir, current string) []ThemeItem {
+	themes := []ThemeItem{}
+	seen := map[string]bool{}
+	add := func(theme ThemeItem) {
+		if seen[theme.Path] {
+			return
+		}
+		seen[theme.Path] = true
+		themes = append(themes, theme)
+	}
+
+	bundledDirs := slices.Clone(BundledThemeDirs)
+	if configDir != "" {
+		bundledDirs = append(bundledDirs, filepath.Join(configDir, "themes", "static"))
+	}
+	for _, dir := range bundledDirs {
+		files, err := filepath.Glob(filepath.Join(dir, "*", "theme.toml"))
+		if err != nil {
+			logrus.WithError(err).Debugf("Cant list the themes in %s", dir)
+			continue
+		}
+		for _, file := range files {
+			add(ThemeItem{Name: filepath.Base(filepath.Dir(file)), Path: file, Kind: BundledTheme})
+		}
+	}
+
+	if configDir != "" {
+		files, err := filepath.Glob(filepath.Join(configDir, "themes", "*.toml"))
+		if err != nil {
+			logrus.WithError(err).Debug("Cant list the user themes")
+		}
+		for _, file := range files {
+			if info, err := os.Stat(file); err != nil || info.IsDir() {
+				continue
+			}
+			add(ThemeItem{
+				Name: strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)),
+				Path: file,
+				Kind: UserTheme,
+			})
+		}
+	}
+
+	slices.SortFunc(themes, func(a, b ThemeItem) int {
+		if a.Kind != b.Kind {
+			return int(a.Kind) - int(b.Kind)
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	if current != "" && !seen[current] {
+		themes = append(themes, ThemeItem{Name: filepath.Base(current), Path: current, Kind: CurrentTheme})
+	}
+
+	return themes
+}
+
+type ThemeDelegate struct {
+	colors *ColorsManager
+}
+
+func NewThemeDelegate(colors *ColorsManager) ThemeDelegate {
+	return ThemeDelegate{
+		colors: colors,
+	}
+}
+
+func (d ThemeDelegate) Height() int {
+	return 1
+}
+
+func (d ThemeDelegate) Spacing() int {
+	return 0
+}
+
+func (d ThemeDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd {
+	return nil
+}
+
+func (d ThemeDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	themeItem, ok := item.(ThemeItem)
+	if !ok {
+		return
+	}
+
+	var style lipgloss.Style
+	var prefix string
+	switch {
+	case index == m.Index():
+		style = d.colors.ListItemSelected()
+		prefix = "► "
+	default:
+		style = d.colors.ListItemUnselected()
+	}
+
+	fmt.Fprintf(w, "%s %s", style.Render(prefix+themeItem.View()),
+		d.colors.MutedStyle().Render(themeItem.Kind.String()))
+}
+
+// ThemePicker lists the themes and previews the highlighted one on the whole TUI
+type ThemePicker struct {
+	L       list.Model
+	cfg     *config.Config
+	colors  *ColorsManager
+	help    *CustomHelp
+	keys    *keyMap
+	warning string
+	width   int
+	height  int
+}
+
+func NewThemePicker(cfg *config.Config, colors *ColorsManager, keys *KeyBindings) *ThemePicker {
+	themeList := list.New([]list.Item{}, NewThemeDelegate(colors), 0, 0)
+	themeList.SetShowStatusBar(false)
+	themeList.SetFilteringEnabled(false)
+	themeList.SetShowHelp(false)
+	themeList.SetShowTitle(false)
+	themeList.KeyMap = keys.listKeyMap()
+
+	return &ThemePicker{
+		L:      themeList,
+		cfg:    cfg,
+		colors: colors,
+		help:   NewCustomHelp(colors),
+		keys:   &keys.root,
+	}
+}
+
+// Open lists the themes, highlights the configured one and starts previewing it
+func (t *ThemePicker) Open() tea.Cmd {
+	current := t.colors.SourceFile()
+	themes := DiscoverThemes(t.cfg.Get().ConfigDirPath, current)
+
+	items := make([]list.Item, len(themes))
+	selected := 0
+	for i, theme := range themes {
+		items[i] = theme
+		if theme.Path == current {
+			selected = i
+		}
+	}
+
+	cmd := t.L.SetItems(items)
+	t.L.Select(selected)
+	t.preview()
+	return cmd
+}
+
+func (t *ThemePicker) preview() {
+	item, ok := t.L.SelectedItem().(ThemeItem)
+	if !ok {
+		t.warning = "No themes found"
+		t.colors.ClearPreview()
+		return
+	}
+
+	colors, err := config.LoadTUIColors(item.Path)
+	if err != nil {
+		logrus.WithError(err).Debugf("Cant preview theme %s", item.Path)
+		t.warning = fmt.Sprintf("Cant load %s", item.Name)
+		t.colors.ClearPreview()
+		return
+	}
+	t.warning = ""
+	t.colors.Preview(colors)
+}
+
+func (t *ThemePicker) Update(msg tea.Msg) tea.Cmd {
+	// nolint:gocritic
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			logrus.Debug("Close theme picker")
+			return CloseThemePickerCmd()
+		case "enter":
+			item, ok := t.L.SelectedItem().(ThemeItem)
+			if !ok || t.warning != "" {
+				return nil
+			}
+			logrus.Debugf("Picked theme: %s", item.Path)
+			return applyThemeCmd(item.Path)
+		}
+	}
+
+	index := t.L.Index()
+	var cmd tea.Cmd
+	t.L, cmd = t.L.Update(msg)
+	if t.L.Index() != index {
+		t.preview()
+	}
+	return cmd
+}
+
+func (t *ThemePicker) View() string {
+	sections := []string{}
+	availHeight := t.height
+
+	title := t.colors.TitleStyle().Margin(0, 0, 1, 0).Render("Pick a theme")
+	availHeight -= lipgloss.Height(title)
+	sections = append(sections, title)
+
+	help := t.help.ShortHelpView([]key.Binding{
+		t.keys.Up, t.keys.Down, t.keys.Enter, t.keys.Back,
+	})
+	availHeight -= lipgloss.Height(help)
+
+	if t.warning != "" {
+		warning := t.colors.WarningStyle().Render(t.warning)
+		availHeight -= lipgloss.Height(warning)
+		sections = append(sections, warning)
+	}
+
+	listWidth := t.width / 3
+	t.L.SetHeight(availHeight)
+	t.L.SetWidth(listWidth)
+	themes := lipgloss.NewStyle().Width(listWidth).Height(availHeight).Render(t.L.View())
+	sample := lipgloss.NewStyle().Width(t.width - listWidth).Height(availHeight).Render(t.sampleView())
+	sections = append(sections, lipgloss.JoinHorizontal(lipgloss.Top, themes, sample))
+
+	sections = append(sections, help)
+
+	return lipgloss.JoinVertical(lipgloss.Top, sections...)
+}
+
+// sampleView shows the main styles of the previewed theme next to the list
+func (t *ThemePicker) sampleView() string {
+	edges := []string{}
+	for i := range t.colors.MonitorEdgeColors() {
+		edges = append(edges, t.colors.GetMonitorColorStyle(i).Render("■■"))
+	}
+
+	lines := []string{
+		t.colors.ProgramNameStyle().Render(" HyprDynamicMonitors ") + " " +
+			t.colors.HeaderIndicatorStyle().Render(" Simulating "),
+		t.colors.TabActiveStyle().Render("Monitors") + t.colors.TabInactiveStyle().Render("Profiles"),
+		t.colors.SubtitleStyle().Render("Subtitle") + " " + t.colors.InfoStyle().Render("info"),
+		t.colors.ListItemSelected().Render("► selected item"),
+		t.colors.ListItemUnselected().Render("item"),
+		t.colors.SuccessStyle().Render("success") + " " + t.colors.ErrorStyle().Render("error") + " " +
+			t.colors.WarningStyle().Render("warning") + " " + t.colors.MutedStyle().Render("muted"),
+		t.colors.LinkStyle().Render("https://fiffeek.github.io/hyprdynamicmonitors/"),
+		strings.Join(edges, " "),
+		t.help.ShortHelpView([]key.Binding{t.keys.Tab, t.keys.Quit}),
+	}
+
+	return t.colors.ActiveStyle().Padding(0, 1).Render(strings.Join(lines, "\n"))
+}
+
+func (t *ThemePicker) SetWidth(width int) {
+	t.width = width
+}
+
+func (t *ThemePicker) SetHeight(height int) {
+	t.height = height
+}
@@ -0,0 +1,146 @@
+package tui_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/testutils"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/tui"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTheme(t *testing.T, path, contents string) string {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, utils.WriteAtomic(path, []byte(contents)))
+	return path
+}
+
+func TestDiscoverThemes(t *testing.T) {
+	bundled := t.TempDir()
+	configDir := t.TempDir()
+	previous := tui.BundledThemeDirs
+	tui.BundledThemeDirs = []string{bundled}
+	t.Cleanup(func() { tui.BundledThemeDirs = previous })
+
+	nord := writeTheme(t, filepath.Join(bundled, "nord", "theme.toml"), "")
+	dracula := writeTheme(t, filepath.Join(bundled, "dracula", "theme.toml"), "")
+	copied := writeTheme(t, filepath.Join(configDir, "themes", "static", "alabaster", "theme.toml"), "")
+	matugen := writeTheme(t, filepath.Join(configDir, "themes", "matugen.toml"), "")
+	mine := writeTheme(t, filepath.Join(configDir, "themes", "mine.toml"), "")
+	writeTheme(t, filepath.Join(configDir, "themes", "notes.txt"), "")
+	elsewhere := writeTheme(t, filepath.Join(t.TempDir(), "elsewhere.toml"), "")
+
+	tests := []struct {
+		name     string
+		current  string
+		expected []tui.ThemeItem
+	}{
+		{
+			name: "bundled then user themes",
+			expected: []tui.ThemeItem{
+				{Name: "alabaster", Path: copied, Kind: tui.BundledTheme},
+				{Name: "dracula", Path: dracula, Kind: tui.BundledTheme},
+				{Name: "nord", Path: nord, Kind: tui.BundledTheme},
+				{Name: "matugen", Path: matugen, Kind: tui.UserTheme},
+				{Name: "mine", Path: mine, Kind: tui.UserTheme},
+			},
+		},
+		{
+			name:    "listed current theme is not duplicated",
+			current: nord,
+			expected: []tui.ThemeItem{
+				{Name: "alabaster", Path: copied, Kind: tui.BundledTheme},
+				{Name: "dracula", Path: dracula, Kind: tui.BundledTheme},
+				{Name: "nord", Path: nord, Kind: tui.BundledTheme},
+				{Name: "matugen", Path: matugen, Kind: tui.UserTheme},
+				{Name: "mine", Path: mine, Kind: tui.UserTheme},
+			},
+		},
+		{
+			name:    "current theme from elsewhere is appended",
+			current: elsewhere,
+			expected: []tui.ThemeItem{
+				{Name: "alabaster", Path: copied, Kind: tui.BundledTheme},
+				{Name: "dracula", Path: dracula, Kind: tui.BundledTheme},
+				{Name: "nord", Path: nord, Kind: tui.BundledTheme},
+				{Name: "matugen", Path: matugen, Kind: tui.UserTheme},
+				{Name: "mine", Path: mine, Kind: tui.UserTheme},
+				{Name: "elsewhere.toml", Path: elsewhere, Kind: tui.CurrentTheme},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tui.DiscoverThemes(configDir, tt.current))
+		})
+	}
+}
+
+func TestThemePicker_Update(t *testing.T) {
+	previous := tui.BundledThemeDirs
+	tui.BundledThemeDirs = nil
+	t.Cleanup(func() { tui.BundledThemeDirs = previous })
+
+	configDir := t.TempDir()
+	dark := writeTheme(t, filepath.Join(configDir, "themes", "dark.toml"), `title_color = "#000001"`)
+	writeTheme(t, filepath.Join(configDir, "themes", "light.toml"), `title_color = "#fffffe"`)
+	writeTheme(t, filepath.Join(configDir, "themes", "broken.toml"), `title_color = `)
+
+	tests := []struct {
+		name          string
+		keys          []tea.KeyMsg
+		expectedMsg   tea.Msg
+		expectedTitle string
+	}{
+		{
+			name:        "esc closes the picker",
+			keys:        []tea.KeyMsg{{Type: tea.KeyEsc}},
+			expectedMsg: tui.CloseThemePickerCommand{},
+		},
+		{
+			name:          "enter applies the highlighted theme",
+			keys:          []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune{'j'}}, {Type: tea.KeyEnter}},
+			expectedMsg:   tui.ApplyThemeCommand{Path: dark},
+			expectedTitle: "#000001",
+		},
+		{
+			name: "broken theme can not be applied",
+			keys: []tea.KeyMsg{{Type: tea.KeyEnter}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testutils.NewTestConfig(t).WithConfigDir(configDir).Get()
+			colors := tui.NewColorsManager(cfg)
+			picker := tui.NewThemePicker(cfg, colors, tui.NewKeyBindings(cfg))
+			picker.SetHeight(20)
+			picker.SetWidth(80)
+			picker.Open()
+			assert.Len(t, picker.L.Items(), 3)
+
+			var cmd tea.Cmd
+			for _, key := range tt.keys {
+				cmd = picker.Update(key)
+			}
+
+			if tt.expectedMsg == nil {
+				assert.Nil(t, cmd)
+				assert.Contains(t, picker.View(), "Cant load broken")
+				return
+			}
+			require.NotNil(t, cmd)
+			assert.Equal(t, tt.expectedMsg, cmd())
+			if tt.expectedTitle != "" {
+				assert.Equal(t, lipgloss.Color(tt.expectedTitle), colors.TitleStyle().GetForeground())
+			}
+		})
+	}
+}
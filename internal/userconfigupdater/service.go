@@ -11,6 +11,7 @@ import (
 
 	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/generators"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/hotplug"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/hypr"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/matchers"
 	"github.com/fiffeek/hyprdynamicmonitors/internal/notifications"
@@ -28,6 +29,8 @@ type IPowerDetector interface {
 type IMonitorDetector interface {
 	Listen() <-chan hypr.MonitorSpecs
 	GetConnectedMonitors() hypr.MonitorSpecs
+	Reconnected() <-chan struct{}
+	ConfigReloaded() <-chan struct{}
 }
 
 type ILidDetector interface {
@@ -35,21 +38,48 @@ type ILidDetector interface {
 	GetCurrentState() power.LidState
 }
 
+type IBatteryDetector interface {
+	Listen() <-chan power.BatteryEvent
+	GetCurrentState() power.BatteryState
+}
+
+type ISystemdNotifier interface {
+	Ready() error
+	Status(string) error
+}
+
+type ILidSwitchInhibitor interface {
+	Set(ctx context.Context, inhibit bool) error
+}
+
 type Service struct {
 	config               *config.Config
 	monitorDetector      IMonitorDetector
 	powerDetector        IPowerDetector
 	lidDetector          ILidDetector
+	batteryDetector      IBatteryDetector
 	matcher              *matchers.Matcher
 	serviceConfig        *Config
 	generator            *generators.ConfigGenerator
 	notificationsService *notifications.Service
+	flapDetector         *hotplug.FlapDetector
+	systemdNotifier      ISystemdNotifier
+	lidSwitchInhibitor   ILidSwitchInhibitor
 
 	stateMu          sync.RWMutex
+	rawMonitors      []*hypr.MonitorSpec
 	cachedMonitors   []*hypr.MonitorSpec
 	cachedPowerState power.PowerState
 	cachedLidState   power.LidState
-	debouncer        *utils.Debouncer
+	cachedBattery    power.BatteryState
+	lastWrite        time.Time
+	// every source debounces on its own delay, an event from one source does not
+	// reschedule an update pending for another one
+	monitorDebouncer *utils.Debouncer
+	powerDebouncer   *utils.Debouncer
+	lidDebouncer     *utils.Debouncer
+	// updateMu serializes the updates scheduled by the debouncers
+	updateMu sync.Mutex
 }
 
 type Config struct {
@@ -58,7 +88,8 @@ type Config struct {
 
 func NewService(cfg *config.Config, monitorDetector IMonitorDetector,
 	powerDetector IPowerDetector, svcCfg *Config, matcher *matchers.Matcher, generator *generators.ConfigGenerator,
-	notifications *notifications.Service, lidDetector ILidDetector,
+	notifications *notifications.Service, lidDetector ILidDetector, batteryDetector IBatteryDetector,
+	systemdNotifier ISystemdNotifier, lidSwitchInhibitor ILidSwitchInhibitor,
 ) *Service {
 	return &Service{
 		config:               cfg,
@@ -68,9 +99,15 @@ func NewService(cfg *config.Config, monitorDetector IMonitorDetector,
 		matcher:              matcher,
 		generator:            generator,
 		cachedPowerState:     power.BatteryPowerState,
-		debouncer:            utils.NewDebouncer(),
+		monitorDebouncer:     utils.NewDebouncer(),
+		powerDebouncer:       utils.NewDebouncer(),
+		lidDebouncer:         utils.NewDebouncer(),
 		notificationsService: notifications,
 		lidDetector:          lidDetector,
+		batteryDetector:      batteryDetector,
+		flapDetector:         hotplug.NewFlapDetector(),
+		systemdNotifier:      systemdNotifier,
+		lidSwitchInhibitor:   lidSwitchInhibitor,
 	}
 }
 
@@ -78,28 +115,53 @@ func (s *Service) Run(ctx context.Context) error {
 	if err := s.RunOnce(ctx); err != nil {
 		return fmt.Errorf("unable to update configuration on start: %w", err)
 	}
+	if err := s.systemdNotifier.Ready(); err != nil {
+		logrus.WithError(err).Warn("Cant notify systemd about readiness")
+	}
 
 	monitorEventsChannel := s.monitorDetector.Listen()
 	powerEventsChannel := s.powerDetector.Listen()
 	lidEventsChannel := s.lidDetector.Listen()
+	batteryEventsChannel := s.batteryDetector.Listen()
+	reconnectedChannel := s.monitorDetector.Reconnected()
+	configReloadedChannel := s.monitorDetector.ConfigReloaded()
 	logrus.Info("Listening for monitor and power events...")
 
+	// fires when the earliest monitor quarantine expires, nil while nothing is quarantined
+	var releaseTimer *time.Timer
+	var releaseC <-chan time.Time
+	resetReleaseTimer := func() {
+		if releaseTimer != nil {
+			releaseTimer.Stop()
+		}
+		releaseTimer, releaseC = nil, nil
+		if left, ok := s.flapDetector.NextRelease(); ok {
+			releaseTimer = time.NewTimer(left)
+			releaseC = releaseTimer.C
+		}
+	}
+
 	eg, ctx := errgroup.WithContext(ctx)
 
+	debouncers := []*utils.Debouncer{s.monitorDebouncer, s.powerDebouncer, s.lidDebouncer}
 	eg.Go(func() error {
 		<-ctx.Done()
-		s.debouncer.Cancel()
+		for _, debouncer := range debouncers {
+			debouncer.Cancel()
+		}
 		logrus.Debug("Context cancelled for service, shutting down")
 		return context.Cause(ctx)
 	})
 
-	eg.Go(func() error {
-		logrus.Debug("Running debouncer for userconfigupdater")
-		if err := s.debouncer.Run(ctx); err != nil {
-			return fmt.Errorf("debouncer failed: %w", err)
-		}
-		return nil
-	})
+	for _, debouncer := range debouncers {
+		eg.Go(func() error {
+			logrus.Debug("Running debouncer for userconfigupdater")
+			if err := debouncer.Run(ctx); err != nil {
+				return fmt.Errorf("debouncer failed: %w", err)
+			}
+			return nil
+		})
+	}
 
 	eg.Go(func() error {
 		for {
@@ -111,16 +173,67 @@ func (s *Service) Run(ctx context.Context) error {
 				s.stateMu.Lock()
 				s.cachedLidState = lidEvent.State
 				s.stateMu.Unlock()
-				s.debouncer.Do(ctx, time.Duration(*s.config.Get().General.DebounceTimeMs)*time.Millisecond, s.debounceUpdate)
+				s.lidDebouncer.Do(ctx, time.Duration(*s.config.Get().General.LidDebounceTimeMs)*time.Millisecond, s.debounceUpdate)
 			case monitors, ok := <-monitorEventsChannel:
 				if !ok {
 					return errors.New("monitor events channel closed")
 				}
 				logrus.WithField("monitor_count", len(monitors)).Debug("Monitor event received")
+				cfg := s.config.Get()
+				if quarantined := s.flapDetector.Observe(cfg.FlapDetection, monitors); len(quarantined) > 0 {
+					cooldown := time.Duration(*cfg.FlapDetection.CooldownMs) * time.Millisecond
+					if err := s.notificationsService.NotifyMonitorsQuarantined(
+						quarantined, cooldown, s.serviceConfig.DryRun); err != nil {
+						logrus.WithError(err).Error("swallowing notification error")
+					}
+					resetReleaseTimer()
+				}
+				s.setMonitors(monitors)
+				s.monitorDebouncer.Do(ctx, time.Duration(*cfg.General.MonitorDebounceTimeMs)*time.Millisecond, s.debounceUpdate)
+
+			case <-releaseC:
+				logrus.Info("Monitor quarantine expired, re-evaluating the profile")
+				resetReleaseTimer()
+				s.stateMu.RLock()
+				monitors := s.rawMonitors
+				s.stateMu.RUnlock()
+				s.setMonitors(monitors)
+				s.monitorDebouncer.Do(ctx, time.Duration(*s.config.Get().General.MonitorDebounceTimeMs)*time.Millisecond,
+					s.debounceUpdate)
+
+			case _, ok := <-reconnectedChannel:
+				if !ok {
+					return errors.New("monitor detector reconnect channel closed")
+				}
+				logrus.Info("Monitor detector reconnected, re-running the initial update")
+				// the compositor might have been restarted with a fresh monitor setup, refresh all state
+				if err := s.RunOnce(ctx); err != nil {
+					logrus.WithError(err).Error("Unable to update configuration after reconnecting")
+				}
+
+			case _, ok := <-configReloadedChannel:
+				if !ok {
+					return errors.New("monitor detector config reload channel closed")
+				}
+				// writing the destination makes Hyprland reload its config, do not react to our own changes
+				if s.isWithinSelfWriteGracePeriod() {
+					logrus.Debug("Ignoring config reload caused by the last applied profile")
+					continue
+				}
+				logrus.Info("Hyprland config reloaded, re-evaluating the profile")
+				s.setMonitors(s.monitorDetector.GetConnectedMonitors())
+				s.monitorDebouncer.Do(ctx, time.Duration(*s.config.Get().General.MonitorDebounceTimeMs)*time.Millisecond,
+					s.debounceUpdate)
+
+			case batteryEvent, ok := <-batteryEventsChannel:
+				if !ok {
+					return errors.New("battery events channel closed")
+				}
+				logrus.WithField("battery_state", batteryEvent.State.String()).Debug("Battery event received")
 				s.stateMu.Lock()
-				s.cachedMonitors = monitors
+				s.cachedBattery = batteryEvent.State
 				s.stateMu.Unlock()
-				s.debouncer.Do(ctx, time.Duration(*s.config.Get().General.DebounceTimeMs)*time.Millisecond, s.debounceUpdate)
+				s.powerDebouncer.Do(ctx, time.Duration(*s.config.Get().General.PowerDebounceTimeMs)*time.Millisecond, s.debounceUpdate)
 
 			case powerEvent, ok := <-powerEventsChannel:
 				if !ok {
@@ -130,10 +243,13 @@ func (s *Service) Run(ctx context.Context) error {
 				s.stateMu.Lock()
 				s.cachedPowerState = powerEvent.State
 				s.stateMu.Unlock()
-				s.debouncer.Do(ctx, time.Duration(*s.config.Get().General.DebounceTimeMs)*time.Millisecond, s.debounceUpdate)
+				s.powerDebouncer.Do(ctx, time.Duration(*s.config.Get().General.PowerDebounceTimeMs)*time.Millisecond, s.debounceUpdate)
 
 			case <-ctx.Done():
 				logrus.Debug("Event processor context cancelled, shutting down")
+				if releaseTimer != nil {
+					releaseTimer.Stop()
+				}
 				return context.Cause(ctx)
 			}
 		}
@@ -149,11 +265,14 @@ func (s *Service) RunOnce(ctx context.Context) error {
 	monitors := s.monitorDetector.GetConnectedMonitors()
 	powerState := s.powerDetector.GetCurrentState()
 	lidState := s.lidDetector.GetCurrentState()
+	batteryState := s.batteryDetector.GetCurrentState()
 
+	s.flapDetector.Seed(monitors)
+	s.setMonitors(monitors)
 	s.stateMu.Lock()
-	s.cachedMonitors = monitors
 	s.cachedPowerState = powerState
 	s.cachedLidState = lidState
+	s.cachedBattery = batteryState
 	s.stateMu.Unlock()
 
 	if err := s.UpdateOnce(ctx); err != nil {
@@ -163,6 +282,16 @@ func (s *Service) RunOnce(ctx context.Context) error {
 	return nil
 }
 
+// setMonitors stores the monitors reported by the detector, quarantined (flapping)
+// monitors are not taken into account when matching profiles
+func (s *Service) setMonitors(monitors []*hypr.MonitorSpec) {
+	filtered := s.flapDetector.Filter(monitors)
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+	s.rawMonitors = monitors
+	s.cachedMonitors = filtered
+}
+
 func (s *Service) debounceUpdate(ctx context.Context) error {
 	select {
 	case <-ctx.Done():
@@ -177,10 +306,14 @@ func (s *Service) Handle(ctx context.Context) error {
 }
 
 func (s *Service) UpdateOnce(ctx context.Context) error {
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
+
 	s.stateMu.RLock()
 	monitors := s.cachedMonitors
 	powerState := s.cachedPowerState
 	lidState := s.cachedLidState
+	batteryState := s.cachedBattery
 	s.stateMu.RUnlock()
 
 	// grab latest config and pass along for the same world-view
@@ -190,16 +323,19 @@ func (s *Service) UpdateOnce(ctx context.Context) error {
 		"monitor_count": len(monitors),
 		"power_state":   powerState.String(),
 		"lid_state":     lidState.String(),
+		"battery_state": batteryState.String(),
 		"dry_run":       s.serviceConfig.DryRun,
 	}).Debug("Updating configuration")
 
-	found, matchedProfile, err := s.matcher.Match(cfg, monitors, powerState, lidState)
+	found, matchedProfile, err := s.matcher.Match(cfg, monitors, powerState, lidState, batteryState)
 	if err != nil {
 		return fmt.Errorf("failed to match a profile %w", err)
 	}
 
 	if !found {
 		logrus.Info("No matching profile found")
+		s.notifyStatus("No matching profile")
+		s.setLidSwitchInhibitor(ctx, false)
 		return nil
 	}
 
@@ -220,10 +356,21 @@ func (s *Service) UpdateOnce(ctx context.Context) error {
 
 	destination := *cfg.General.Destination
 	changed, err := s.generator.GenerateConfig(cfg, matchedProfile, monitors, powerState,
-		lidState, destination, s.serviceConfig.DryRun)
+		lidState, batteryState, destination, s.serviceConfig.DryRun)
+	if errors.Is(err, generators.ErrLayoutBlocked) {
+		logrus.WithFields(profileFields).WithError(err).Error("Keeping the previous configuration")
+		s.notifyStatus("Layout blocked: " + matchedProfile.Profile.Name)
+		if err := s.notificationsService.NotifyLayoutBlocked(matchedProfile.Profile, err, s.serviceConfig.DryRun); err != nil {
+			logrus.WithFields(profileFields).WithError(err).Error("swallowing notification error")
+		}
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("failed to generate config: %w", err)
 	}
+	s.notifyStatus("Active profile: " + matchedProfile.Profile.Name)
+	clamshell := matchedProfile.Profile.Clamshell
+	s.setLidSwitchInhibitor(ctx, clamshell != nil && *clamshell)
 
 	// if not changed and not running in dry run then exit early
 	if !changed && !s.serviceConfig.DryRun {
@@ -231,6 +378,12 @@ func (s *Service) UpdateOnce(ctx context.Context) error {
 		return nil
 	}
 
+	if changed {
+		s.stateMu.Lock()
+		s.lastWrite = time.Now()
+		s.stateMu.Unlock()
+	}
+
 	s.tryExec(ctx, matchedProfile.Profile.PostApplyExec, cfg.General.PostApplyExec, utils.PostExecLogID)
 
 	if err := s.notificationsService.NotifyProfileApplied(matchedProfile.Profile, s.serviceConfig.DryRun); err != nil {
@@ -240,6 +393,31 @@ func (s *Service) UpdateOnce(ctx context.Context) error {
 	return nil
 }
 
+func (s *Service) notifyStatus(status string) {
+	if err := s.systemdNotifier.Status(status); err != nil {
+		logrus.WithError(err).Warn("Cant send status to systemd")
+	}
+}
+
+// setLidSwitchInhibitor keeps logind from suspending on lid close while a clamshell profile is active,
+// failures are not fatal since logind might not be running
+func (s *Service) setLidSwitchInhibitor(ctx context.Context, inhibit bool) {
+	if s.serviceConfig.DryRun {
+		logrus.WithField("inhibit", inhibit).Debug("[DRY RUN] Not changing the lid switch inhibitor")
+		return
+	}
+	if err := s.lidSwitchInhibitor.Set(ctx, inhibit); err != nil {
+		logrus.WithError(err).Warn("Cant update the logind lid switch inhibitor")
+	}
+}
+
+func (s *Service) isWithinSelfWriteGracePeriod() bool {
+	gracePeriod := time.Duration(*s.config.Get().HyprIPC.SelfWriteGracePeriodMs) * time.Millisecond
+	s.stateMu.RLock()
+	defer s.stateMu.RUnlock()
+	return !s.lastWrite.IsZero() && time.Since(s.lastWrite) < gracePeriod
+}
+
 func (s *Service) tryExec(ctx context.Context, command, fallbackCommand *string, logID utils.LogID) {
 	// fallback on a default command when it's not provided for a profile
 	if command == nil || *command == "" {
@@ -0,0 +1,120 @@
+package userconfigupdater_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fiffeek/hyprdynamicmonitors/internal/config"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/generators"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/hypr"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/matchers"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/notifications"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/power"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/testutils"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/userconfigupdater"
+	"github.com/fiffeek/hyprdynamicmonitors/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMonitorDetector struct {
+	monitors hypr.MonitorSpecs
+}
+
+func (f *fakeMonitorDetector) Listen() <-chan hypr.MonitorSpecs        { return nil }
+func (f *fakeMonitorDetector) GetConnectedMonitors() hypr.MonitorSpecs { return f.monitors }
+func (f *fakeMonitorDetector) Reconnected() <-chan struct{}            { return nil }
+func (f *fakeMonitorDetector) ConfigReloaded() <-chan struct{}         { return nil }
+
+type fakePowerDetector struct{}
+
+func (f *fakePowerDetector) GetCurrentState() power.PowerState { return power.ACPowerState }
+func (f *fakePowerDetector) Listen() <-chan power.PowerEvent   { return nil }
+
+type fakeLidDetector struct {
+	initial power.LidState
+	events  chan power.LidEvent
+}
+
+func (f *fakeLidDetector) GetCurrentState() power.LidState { return f.initial }
+func (f *fakeLidDetector) Listen() <-chan power.LidEvent   { return f.events }
+
+type fakeBatteryDetector struct{}
+
+func (f *fakeBatteryDetector) GetCurrentState() power.BatteryState { return power.BatteryState{} }
+func (f *fakeBatteryDetector) Listen() <-chan power.BatteryEvent   { return nil }
+
+type fakeSystemdNotifier struct{}
+
+func (f *fakeSystemdNotifier) Ready() error        { return nil }
+func (f *fakeSystemdNotifier) Status(string) error { return nil }
+
+type fakeLidSwitchInhibitor struct {
+	mu    sync.Mutex
+	calls []bool
+}
+
+func (f *fakeLidSwitchInhibitor) Set(ctx context.Context, inhibit bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, inhibit)
+	return nil
+}
+
+func (f *fakeLidSwitchInhibitor) Calls() []bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]bool{}, f.calls...)
+}
+
+func TestService_ClamshellHoldsLidSwitchInhibitorBeforeLidCloses(t *testing.T) {
+	external := []*config.RequiredMonitor{{Name: utils.StringPtr("DP-1")}}
+	cfg := testutils.NewTestConfig(t).WithProfiles(map[string]*config.Profile{
+		"docked": {
+			Name:      "docked",
+			Clamshell: utils.BoolPtr(true),
+			Conditions: &config.ProfileCondition{
+				LidState:         utils.JustPtr(config.OpenedLidStateType),
+				RequiredMonitors: external,
+			},
+		},
+		"docked_closed": {
+			Name:      "docked_closed",
+			Clamshell: utils.BoolPtr(true),
+			Conditions: &config.ProfileCondition{
+				LidState:         utils.JustPtr(config.ClosedLidStateType),
+				RequiredMonitors: external,
+			},
+		},
+	}).WithNotifications(&config.Notifications{Disabled: utils.BoolPtr(true)}).WithServiceDebounceTime(10).Get()
+
+	generator, err := generators.NewConfigGenerator(cfg)
+	require.NoError(t, err)
+	lid := &fakeLidDetector{initial: power.OpenedLidState, events: make(chan power.LidEvent)}
+	inhibitor := &fakeLidSwitchInhibitor{}
+	monitors := hypr.MonitorSpecs{
+		{Name: "eDP-1", ID: utils.IntPtr(0), Description: "Laptop"},
+		{Name: "DP-1", ID: utils.IntPtr(1), Description: "External"},
+	}
+	svc := userconfigupdater.NewService(cfg, &fakeMonitorDetector{monitors: monitors}, &fakePowerDetector{},
+		&userconfigupdater.Config{}, matchers.NewMatcher(), generator, notifications.NewService(cfg), lid,
+		&fakeBatteryDetector{}, &fakeSystemdNotifier{}, inhibitor)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- svc.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	require.Eventually(t, func() bool { return len(inhibitor.Calls()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	// logind decides whether to suspend as soon as the lid closes, the lock has to be held by then
+	assert.Equal(t, []bool{true}, inhibitor.Calls(), "the docked lid-open profile should hold the lock")
+
+	lid.events <- power.LidEvent{State: power.ClosedLidState}
+	require.Eventually(t, func() bool { return len(inhibitor.Calls()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []bool{true, true}, inhibitor.Calls(), "the lock should not be released once the lid is closed")
+}
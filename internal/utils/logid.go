@@ -20,6 +20,8 @@ const (
 	DryRunTemplateLogID
 	DryRunExedLogID
 	DryRunNotificationLogID
+	InvalidConfigLogID
+	ConfigRecoveredLogID
 )
 
 type LogrusCustomFields struct {
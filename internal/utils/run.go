@@ -36,3 +36,8 @@ func GetRunCmd() cmdRunner {
 func SetRunCmd(r cmdRunner) {
 	runCmd = r
 }
+
+// RunCommand runs the command and returns its trimmed stdout
+func RunCommand(ctx context.Context, name string, args ...string) (string, error) {
+	return runCmd(ctx, name, args...)
+}